@@ -0,0 +1,442 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package alert provides a chat alert sink for the logging framework.
+// High-severity entries are posted to a Slack or Microsoft Teams incoming
+// webhook, with rate limiting and deduplication so that a crash loop does not
+// flood the channel.
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goxkit/configs"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/batch"
+	"github.com/goxkit/logging/internal/checked"
+)
+
+// Format selects the webhook payload format.
+type Format int
+
+const (
+	// Slack posts Slack incoming webhook messages.
+	Slack Format = iota
+	// Teams posts Adaptive Cards to a Microsoft Teams workflow webhook.
+	Teams
+)
+
+const (
+	// DefaultRate is the number of alerts allowed per DefaultPeriod.
+	DefaultRate = 10
+	// DefaultPeriod is the period over which Rate alerts are allowed.
+	DefaultPeriod = time.Minute
+	// DefaultDedupWindow is the window during which identical alerts are suppressed.
+	DefaultDedupWindow = 5 * time.Minute
+	// DefaultTimeout bounds each webhook request.
+	DefaultTimeout = 10 * time.Second
+)
+
+// ErrMissingURL is returned when no webhook URL is configured.
+var ErrMissingURL = errors.New("alert webhook URL is required")
+
+// Config describes the alert sink.
+type Config struct {
+	// URL is the incoming webhook URL.
+	URL string
+	// Format is the payload format of the webhook. Defaults to Slack.
+	Format Format
+	// Service and Environment are shown in the alert title.
+	Service     string
+	Environment string
+	// Rate is the number of alerts allowed per Period; alerts beyond it are
+	// counted and reported with the next alert. Defaults to DefaultRate.
+	Rate int
+	// Period is the period over which Rate alerts are allowed. Defaults to DefaultPeriod.
+	Period time.Duration
+	// DedupWindow is the window during which alerts with the same level, logger
+	// and message are suppressed. Defaults to DefaultDedupWindow; negative
+	// disables deduplication.
+	DedupWindow time.Duration
+	// Timeout bounds each request. Defaults to DefaultTimeout.
+	Timeout time.Duration
+	// Client sends the requests. Defaults to an http.Client with Timeout.
+	Client *http.Client
+}
+
+// alert is a message waiting to be posted.
+type alert struct {
+	level    zapcore.Level
+	time     time.Time
+	logger   string
+	message  string
+	fields   map[string]any
+	repeated int
+	limited  int
+}
+
+// dedupEntry tracks the occurrences of an alert within the dedup window.
+type dedupEntry struct {
+	since      time.Time
+	suppressed int
+}
+
+// limiter rate limits and deduplicates the alerts.
+type limiter struct {
+	mu      sync.Mutex
+	rate    float64
+	period  time.Duration
+	window  time.Duration
+	tokens  float64
+	last    time.Time
+	limited int
+	seen    map[string]*dedupEntry
+}
+
+// core is a zapcore.Core posting entries to a chat webhook.
+type core struct {
+	zapcore.LevelEnabler
+	cfg     *Config
+	limiter *limiter
+	batcher *batch.Batcher[*alert]
+	fields  []zapcore.Field
+}
+
+// NewConfig returns a Config populated from the application configs: the
+// service name and environment.
+//
+// Parameters:
+//   - cfgs: Application configurations
+//   - url: Incoming webhook URL
+//   - format: Payload format of the webhook
+//
+// Returns:
+//   - The alert sink configuration
+func NewConfig(cfgs *configs.Configs, url string, format Format) Config {
+	return Config{
+		URL:         url,
+		Format:      format,
+		Service:     cfgs.AppConfigs.Name,
+		Environment: cfgs.AppConfigs.Environment.String(),
+	}
+}
+
+// NewCore returns a core posting entries at or above level to a chat webhook.
+// The level is typically zapcore.ErrorLevel.
+//
+// Parameters:
+//   - cfg: Alert sink configuration
+//   - level: Minimum level posted to the webhook
+//
+// Returns:
+//   - A zapcore.Core ready to be teed into a logger; Sync posts the pending alerts
+//   - ErrMissingURL if no webhook URL is configured
+func NewCore(cfg Config, level zapcore.LevelEnabler) (zapcore.Core, error) {
+	if cfg.URL == "" {
+		return nil, ErrMissingURL
+	}
+	if cfg.Rate <= 0 {
+		cfg.Rate = DefaultRate
+	}
+	if cfg.Period <= 0 {
+		cfg.Period = DefaultPeriod
+	}
+	if cfg.DedupWindow == 0 {
+		cfg.DedupWindow = DefaultDedupWindow
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: cfg.Timeout}
+	}
+
+	c := &core{
+		LevelEnabler: level,
+		cfg:          &cfg,
+		limiter: &limiter{
+			rate:   float64(cfg.Rate),
+			period: cfg.Period,
+			window: cfg.DedupWindow,
+			tokens: float64(cfg.Rate),
+			seen:   make(map[string]*dedupEntry),
+		},
+	}
+	c.batcher = batch.New(batch.Config{
+		Size:     cfg.Rate,
+		Interval: time.Second,
+		OnError:  otel.Handle,
+	}, c.send)
+
+	return c, nil
+}
+
+// With adds structured context to the core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	merged = append(merged, c.fields...)
+	merged = append(merged, fields...)
+
+	return &core{LevelEnabler: c.LevelEnabler, cfg: c.cfg, limiter: c.limiter, batcher: c.batcher, fields: merged}
+}
+
+// Check adds the core to the checked entry when the level is enabled.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write queues the entry as an alert unless it is a duplicate or the rate
+// limit is exhausted. Entries above the error level are posted before
+// returning, since the process is about to panic or exit.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	a := &alert{level: ent.Level, time: ent.Time, logger: ent.LoggerName, message: ent.Message}
+
+	if !c.limiter.allow(a) {
+		return nil
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	a.fields = enc.Fields
+
+	c.batcher.Add(a)
+
+	if ent.Level > zapcore.ErrorLevel {
+		return c.Sync()
+	}
+
+	return nil
+}
+
+// Sync posts the pending alerts.
+func (c *core) Sync() error {
+	return c.batcher.Flush()
+}
+
+// Close stops the background flusher and sends the buffered entries.
+func (c *core) Close() error {
+	return c.batcher.Close()
+}
+
+// allow reports whether the alert should be posted, recording on it the
+// duplicates and rate-limited alerts suppressed since the previous one.
+func (l *limiter) allow(a *alert) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	key := a.level.String() + "\x00" + a.logger + "\x00" + a.message
+
+	if l.window > 0 {
+		if e, ok := l.seen[key]; ok && now.Sub(e.since) < l.window {
+			e.suppressed++
+			return false
+		} else if ok {
+			a.repeated = e.suppressed
+		}
+	}
+
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate / l.period.Seconds()
+		if l.tokens > l.rate {
+			l.tokens = l.rate
+		}
+	}
+	l.last = now
+
+	if l.tokens < 1 {
+		l.limited++
+		return false
+	}
+
+	l.tokens--
+	a.limited, l.limited = l.limited, 0
+
+	if l.window > 0 {
+		// Forget the alerts whose window elapsed so the map stays bounded.
+		for k, e := range l.seen {
+			if now.Sub(e.since) >= l.window {
+				delete(l.seen, k)
+			}
+		}
+
+		l.seen[key] = &dedupEntry{since: now}
+	}
+
+	return true
+}
+
+// send posts each alert of the batch.
+func (c *core) send(alerts []*alert) error {
+	var errs []error
+
+	for _, a := range alerts {
+		if err := c.post(a); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// post sends an alert to the webhook.
+func (c *core) post(a *alert) error {
+	var payload any
+	if c.cfg.Format == Teams {
+		payload = c.teams(a)
+	} else {
+		payload = c.slack(a)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.cfg.Client.Post(c.cfg.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to post alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("failed to post alert: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// title returns the heading of an alert, e.g. "ERROR orders (production)".
+func (c *core) title(a *alert) string {
+	title := a.level.CapitalString()
+	if c.cfg.Service != "" {
+		title += " " + c.cfg.Service
+	}
+	if c.cfg.Environment != "" {
+		title += " (" + c.cfg.Environment + ")"
+	}
+
+	return title
+}
+
+// notes returns the suppression notes of an alert.
+func notes(a *alert) []string {
+	var notes []string
+	if a.repeated > 0 {
+		notes = append(notes, fmt.Sprintf("Repeated %d more times since the previous alert", a.repeated))
+	}
+	if a.limited > 0 {
+		notes = append(notes, fmt.Sprintf("%d alerts suppressed by rate limiting", a.limited))
+	}
+
+	return notes
+}
+
+// facts returns the logger and fields of an alert as sorted name/value pairs.
+func facts(a *alert) [][2]string {
+	facts := make([][2]string, 0, len(a.fields)+1)
+	if a.logger != "" {
+		facts = append(facts, [2]string{"logger", a.logger})
+	}
+
+	keys := make([]string, 0, len(a.fields))
+	for k := range a.fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		facts = append(facts, [2]string{k, value(a.fields[k])})
+	}
+
+	return facts
+}
+
+// value renders a field value as text.
+func value(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(b)
+}
+
+// slack builds a Slack incoming webhook message.
+func (c *core) slack(a *alert) map[string]any {
+	var text strings.Builder
+
+	fmt.Fprintf(&text, "*%s*\n%s", c.title(a), a.message)
+
+	if fs := facts(a); len(fs) > 0 {
+		text.WriteString("\n```")
+		for _, f := range fs {
+			fmt.Fprintf(&text, "\n%s: %s", f[0], f[1])
+		}
+		text.WriteString("\n```")
+	}
+	for _, n := range notes(a) {
+		fmt.Fprintf(&text, "\n_%s_", n)
+	}
+
+	return map[string]any{"text": text.String()}
+}
+
+// teams builds a Microsoft Teams message holding an Adaptive Card.
+func (c *core) teams(a *alert) map[string]any {
+	body := []map[string]any{
+		{"type": "TextBlock", "text": c.title(a), "weight": "Bolder", "size": "Medium", "color": "Attention"},
+		{"type": "TextBlock", "text": a.message, "wrap": true},
+	}
+
+	if fs := facts(a); len(fs) > 0 {
+		list := make([]map[string]string, 0, len(fs))
+		for _, f := range fs {
+			list = append(list, map[string]string{"title": f[0], "value": f[1]})
+		}
+		body = append(body, map[string]any{"type": "FactSet", "facts": list})
+	}
+	for _, n := range notes(a) {
+		body = append(body, map[string]any{"type": "TextBlock", "text": n, "isSubtle": true, "wrap": true})
+	}
+
+	return map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content": map[string]any{
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type":    "AdaptiveCard",
+				"version": "1.4",
+				"body":    body,
+			},
+		}},
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package async provides a non-blocking zapcore.Core for the logging framework.
+// Entries are enqueued into a bounded ring buffer and written by a background
+// goroutine, so latency-sensitive code paths never wait on stdout or exporter
+// backpressure. When the buffer is full, a DropPolicy decides whether entries
+// are dropped or the caller waits.
+package async
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+	"github.com/goxkit/logging/internal/pool"
+	"github.com/goxkit/logging/telemetry"
+)
+
+// DropPolicy decides what happens to an entry written while the buffer is full.
+type DropPolicy int
+
+const (
+	// DropOldest discards the oldest buffered entry to make room for the new one.
+	DropOldest DropPolicy = iota
+	// Block makes the caller wait until the background writer frees a slot.
+	Block
+	// DropBelowLevel discards new entries below Config.DropLevel and makes
+	// entries at or above it wait, so errors are never lost.
+	DropBelowLevel
+)
+
+// DefaultBufferSize is the number of entries buffered when Config.BufferSize is zero.
+const DefaultBufferSize = 4096
+
+// Config describes the asynchronous core.
+type Config struct {
+	// BufferSize is the capacity of the ring buffer. Defaults to DefaultBufferSize.
+	BufferSize int
+	// Policy applies when the buffer is full. Defaults to DropOldest.
+	Policy DropPolicy
+	// DropLevel is the level from which entries are never dropped with the
+	// DropBelowLevel policy, typically zapcore.ErrorLevel. The zero value is
+	// Info, which only drops Debug entries.
+	DropLevel zapcore.Level
+	// OnDrop, when set, is called with every dropped entry.
+	OnDrop func(zapcore.Entry)
+}
+
+// item is a buffered entry, with the core (carrying the With fields) it targets.
+// Its fields are a pooled copy of the fields of the entry.
+type item struct {
+	core   zapcore.Core
+	ent    zapcore.Entry
+	fields *[]zapcore.Field
+}
+
+// queue is the ring buffer shared by a Core and its With children.
+type queue struct {
+	cfg Config
+
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	idle     *sync.Cond
+	items    []item
+	head     int
+	size     int
+	writing  bool
+	closed   bool
+	done     chan struct{}
+
+	dropped atomic.Uint64
+}
+
+// Core is a zapcore.Core that writes entries to the wrapped core asynchronously.
+type Core struct {
+	inner zapcore.Core
+	queue *queue
+}
+
+// NewCore wraps inner with a bounded buffer serviced by a background writer.
+// Entries above Error (DPanic, Panic, Fatal) bypass the buffer and are written
+// synchronously after the buffer is drained, so they are not lost when the
+// process exits.
+//
+// Parameters:
+//   - inner: The core receiving the entries, typically the tee of all outputs
+//   - cfg: Buffer size and drop policy
+//
+// Returns:
+//   - The asynchronous core; Close stops its background writer
+func NewCore(inner zapcore.Core, cfg Config) *Core {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = DefaultBufferSize
+	}
+
+	q := &queue{cfg: cfg, items: make([]item, cfg.BufferSize), done: make(chan struct{})}
+	q.notEmpty = sync.NewCond(&q.mu)
+	q.notFull = sync.NewCond(&q.mu)
+	q.idle = sync.NewCond(&q.mu)
+
+	go q.run()
+
+	return &Core{inner: inner, queue: q}
+}
+
+// Dropped returns the number of entries dropped because the buffer was full.
+func (c *Core) Dropped() uint64 {
+	return c.queue.dropped.Load()
+}
+
+// Enabled delegates to the wrapped core.
+func (c *Core) Enabled(lvl zapcore.Level) bool {
+	return c.inner.Enabled(lvl)
+}
+
+// With adds structured context to the wrapped core, sharing the buffer.
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	return &Core{inner: c.inner.With(fields), queue: c.queue}
+}
+
+// Check adds the core to the checked entry when the wrapped core accepts the level.
+func (c *Core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.inner.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write enqueues the entry, applying the drop policy when the buffer is full.
+func (c *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ent.Level > zapcore.ErrorLevel {
+		c.queue.drain()
+		return checked.Write(c.inner, ent, fields)
+	}
+
+	// The caller may reuse its slice once Write returns.
+	owned := pool.GetFields()
+	*owned = append(*owned, fields...)
+
+	c.queue.push(item{core: c.inner, ent: ent, fields: owned})
+
+	return nil
+}
+
+// Sync waits for the buffered entries to be written and syncs the wrapped core.
+func (c *Core) Sync() error {
+	c.queue.drain()
+
+	return c.inner.Sync()
+}
+
+// Close writes the buffered entries and stops the background writer. Entries
+// written after Close are written synchronously.
+func (c *Core) Close() error {
+	q := c.queue
+
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		q.notEmpty.Broadcast()
+		q.notFull.Broadcast()
+	}
+	q.mu.Unlock()
+
+	<-q.done
+
+	return c.inner.Sync()
+}
+
+// push adds an entry to the buffer according to the drop policy.
+func (q *queue) push(it item) {
+	q.mu.Lock()
+
+	for q.size == len(q.items) && !q.closed {
+		switch {
+		case q.cfg.Policy == DropOldest:
+			dropped := q.items[q.head]
+			q.items[q.head] = item{}
+			q.head = (q.head + 1) % len(q.items)
+			q.size--
+			q.drop(dropped)
+		case q.cfg.Policy == DropBelowLevel && it.ent.Level < q.cfg.DropLevel:
+			q.mu.Unlock()
+			q.drop(it)
+			return
+		default:
+			q.notFull.Wait()
+		}
+	}
+
+	if q.closed {
+		q.mu.Unlock()
+		it.write()
+		return
+	}
+
+	q.items[(q.head+q.size)%len(q.items)] = it
+	q.size++
+	q.notEmpty.Signal()
+	q.mu.Unlock()
+}
+
+// drop counts a dropped entry, notifies the OnDrop callback and releases its
+// fields.
+func (q *queue) drop(it item) {
+	q.dropped.Add(1)
+	telemetry.AddAsyncDropped()
+
+	if q.cfg.OnDrop != nil {
+		q.cfg.OnDrop(it.ent)
+	}
+
+	pool.PutFields(it.fields)
+}
+
+// drain blocks until the buffer is empty and the writer is idle.
+func (q *queue) drain() {
+	q.mu.Lock()
+	for (q.size > 0 || q.writing) && !q.closed {
+		q.idle.Wait()
+	}
+	q.mu.Unlock()
+}
+
+// run is the background writer.
+func (q *queue) run() {
+	defer close(q.done)
+
+	q.mu.Lock()
+	for {
+		for q.size == 0 && !q.closed {
+			q.idle.Broadcast()
+			q.notEmpty.Wait()
+		}
+
+		if q.size == 0 && q.closed {
+			q.idle.Broadcast()
+			q.mu.Unlock()
+			return
+		}
+
+		it := q.items[q.head]
+		q.items[q.head] = item{}
+		q.head = (q.head + 1) % len(q.items)
+		q.size--
+		q.writing = true
+		q.notFull.Signal()
+		q.mu.Unlock()
+
+		it.write()
+
+		q.mu.Lock()
+		q.writing = false
+	}
+}
+
+// write writes a buffered entry and releases its fields. The cores retaining
+// fields after Write returns copy them, as the slices of the callers are
+// reused too. Write errors, which no caller awaits, go to the OpenTelemetry
+// error handler.
+func (it item) write() {
+	if err := checked.Write(it.core, it.ent, *it.fields); err != nil {
+		otel.Handle(err)
+	}
+	pool.PutFields(it.fields)
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package audit provides an audit logger for compliance events. Events follow a
+// fixed schema (actor, action, resource, outcome and reason) and are written
+// through a pipeline of their own, apart from the application logs, so they are
+// never sampled, rate limited, deduplicated or filtered by level.
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/contrib/bridges/otelzap"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging"
+	"github.com/goxkit/logging/file"
+	zapInstance "github.com/goxkit/logging/zap"
+)
+
+// DefaultName is the logger name and OpenTelemetry scope of the audit events.
+const DefaultName = "audit"
+
+// Outcomes of an audited action.
+const (
+	Success Outcome = "success"
+	Failure Outcome = "failure"
+	Denied  Outcome = "denied"
+)
+
+// ErrInvalidEvent is returned when an event misses a required attribute.
+var ErrInvalidEvent = errors.New("invalid audit event")
+
+type (
+	// Outcome is the result of an audited action.
+	Outcome string
+
+	// Event is an audit event.
+	Event struct {
+		// Actor identifies who performed the action, e.g. a user or service ID. Required.
+		Actor string
+		// Action is what was done, e.g. "user.delete". Required.
+		Action string
+		// Resource identifies what the action applied to, e.g. "user/42". Required.
+		Resource string
+		// Outcome is the result of the action. Required.
+		Outcome Outcome
+		// Reason explains the outcome, e.g. why access was denied.
+		Reason string
+		// Time is when the action happened. Defaults to the time of Log.
+		Time time.Time
+		// Metadata holds additional attributes, e.g. the source IP.
+		Metadata map[string]string
+	}
+
+	// Config describes the outputs of the audit logger. Without File nor
+	// Provider, events are written to Output.
+	Config struct {
+		// Name is the logger name of the events. Defaults to DefaultName.
+		Name string
+		// Output receives the events as JSON lines. Defaults to os.Stdout when
+		// no other output is configured.
+		Output zapcore.WriteSyncer
+		// File, when set, also writes the events to a rotating file.
+		File *file.Config
+		// Provider, when set, also exports the events to OpenTelemetry.
+		Provider *sdklog.LoggerProvider
+		// Cores are additional outputs of the events.
+		Cores []zapcore.Core
+		// Chain, when set, chains the records by hash so the trail is
+		// tamper-evident, see Verify.
+		Chain *Chain
+	}
+
+	// Logger writes audit events.
+	Logger struct {
+		core  zapcore.Core
+		name  string
+		mu    sync.Mutex
+		chain *chainState
+	}
+)
+
+// New creates an audit logger writing to the outputs of cfg. The outputs
+// accept every event regardless of the levels of the application loggers.
+//
+// Parameters:
+//   - cfg: The outputs of the events
+//
+// Returns:
+//   - The audit Logger
+func New(cfg Config) *Logger {
+	name := cfg.Name
+	if name == "" {
+		name = DefaultName
+	}
+
+	var cores []zapcore.Core
+	if cfg.File != nil {
+		cores = append(cores, file.NewCore(cfg.File, zapcore.DebugLevel))
+	}
+	if cfg.Provider != nil {
+		cores = append(cores, otelzap.NewCore(name, otelzap.WithLoggerProvider(cfg.Provider)))
+	}
+	cores = append(cores, cfg.Cores...)
+
+	output := cfg.Output
+	if output == nil && len(cores) == 0 {
+		output = os.Stdout
+	}
+	if output != nil {
+		cores = append(cores, zapcore.NewCore(zapInstance.NewEncoder(zapInstance.JSONEncoder), zapcore.Lock(output), zapcore.DebugLevel))
+	}
+
+	l := &Logger{core: zapcore.NewTee(cores...), name: name}
+	if cfg.Chain != nil {
+		l.chain = newChainState(*cfg.Chain)
+	}
+
+	return l
+}
+
+// Log validates and writes an event. Unlike application logs, write failures
+// are returned, so callers can refuse to proceed when an action cannot be
+// audited. The trace correlation fields of ctx are added to the event.
+//
+// When the records are chained, events are written one at a time, in the
+// order of the chain, preceded by an anchor record when one is due. A failed
+// write leaves a gap in the chain, which Verify reports.
+//
+// Parameters:
+//   - ctx: Context holding the active span
+//   - e: The event to write
+//
+// Returns:
+//   - ErrInvalidEvent if a required attribute is missing
+//   - An error if an output fails to write the event
+func (l *Logger) Log(ctx context.Context, e Event) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	trace := logging.TraceFields(ctx)
+
+	if l.chain == nil {
+		return l.write(&e, trace)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now := time.Now(); l.chain.anchorDue(now) {
+		if err := l.writeAnchor(now); err != nil {
+			return err
+		}
+	}
+
+	if err := l.write(&e, append(trace, l.chain.link(&e)...)); err != nil {
+		return err
+	}
+
+	if l.chain.cfg.AnchorEvery > 0 && l.chain.anchorDue(e.Time) {
+		return l.writeAnchor(time.Now())
+	}
+
+	return nil
+}
+
+// write writes e with the extra fields.
+func (l *Logger) write(e *Event, extra []zap.Field) error {
+	ent := zapcore.Entry{
+		LoggerName: l.name,
+		Time:       e.Time,
+		Level:      zapcore.InfoLevel,
+		Message:    e.Action,
+	}
+
+	return l.core.Write(ent, append(e.Fields(), extra...))
+}
+
+// writeAnchor writes an anchor record certifying the head of the chain and
+// reports it to Chain.OnAnchor. It must be called with l.mu held.
+func (l *Logger) writeAnchor(now time.Time) error {
+	anchor := l.chain.anchor(l.name, now)
+	fields := l.chain.link(&anchor)
+	// The anchor itself does not count towards the next one.
+	l.chain.sinceAnchor = 0
+
+	if err := l.write(&anchor, fields); err != nil {
+		return err
+	}
+
+	if l.chain.cfg.OnAnchor != nil {
+		l.chain.cfg.OnAnchor(l.chain.seq, l.chain.prev)
+	}
+
+	return nil
+}
+
+// Sync flushes the outputs of the audit logger.
+func (l *Logger) Sync() error {
+	return l.core.Sync()
+}
+
+// Validate reports whether the required attributes of the event are set.
+//
+// Returns:
+//   - ErrInvalidEvent wrapped with the missing attribute, or nil
+func (e *Event) Validate() error {
+	switch {
+	case e.Actor == "":
+		return fmt.Errorf("%w: actor is required", ErrInvalidEvent)
+	case e.Action == "":
+		return fmt.Errorf("%w: action is required", ErrInvalidEvent)
+	case e.Resource == "":
+		return fmt.Errorf("%w: resource is required", ErrInvalidEvent)
+	case e.Outcome == "":
+		return fmt.Errorf("%w: outcome is required", ErrInvalidEvent)
+	}
+
+	return nil
+}
+
+// Fields returns the schema fields of the event.
+//
+// Returns:
+//   - The actor, action, resource, outcome, reason and metadata fields
+func (e *Event) Fields() []zap.Field {
+	fields := []zap.Field{
+		zap.Bool("audit", true),
+		zap.String("actor", e.Actor),
+		zap.String("action", e.Action),
+		zap.String("resource", e.Resource),
+		zap.String("outcome", string(e.Outcome)),
+	}
+
+	if e.Reason != "" {
+		fields = append(fields, zap.String("reason", e.Reason))
+	}
+
+	if len(e.Metadata) > 0 {
+		fields = append(fields, zap.Object("metadata", metadata(e.Metadata)))
+	}
+
+	return fields
+}
+
+// metadata encodes the metadata of an event with sorted keys.
+type metadata map[string]string
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (m metadata) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		enc.AddString(key, m[key])
+	}
+
+	return nil
+}
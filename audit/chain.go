@@ -0,0 +1,238 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package audit
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// AnchorAction is the action of the anchor records.
+const AnchorAction = "audit.anchor"
+
+// ErrChainBroken is returned by Verify when a record was altered, removed or
+// inserted.
+var ErrChainBroken = errors.New("audit chain broken")
+
+type (
+	// Chain enables the hash chaining of the audit records: each record carries
+	// a sequence number, the hash of the previous record and its own hash, so
+	// Verify detects records altered, removed or inserted after the fact.
+	Chain struct {
+		// Key, when set, computes the hashes as HMAC-SHA256, so the chain cannot
+		// be recomputed by someone without the key. Defaults to plain SHA-256.
+		Key []byte
+		// Previous and Seq resume an existing chain: the hash and sequence number
+		// of its last record, e.g. as reported by Head before a restart.
+		Previous string
+		Seq      uint64
+		// AnchorEvery writes an anchor record every AnchorEvery records.
+		AnchorEvery int
+		// AnchorInterval writes an anchor record before the first record logged
+		// once the interval has elapsed since the previous anchor.
+		AnchorInterval time.Duration
+		// OnAnchor is called with the sequence number and hash of every anchor
+		// record, so they can be stored apart from the trail, e.g. in a WORM
+		// bucket, where they prove the trail was not truncated or rewritten.
+		OnAnchor func(seq uint64, hash string)
+	}
+
+	// chainState is the head of the chain of a Logger.
+	chainState struct {
+		cfg         Chain
+		prev        string
+		seq         uint64
+		sinceAnchor int
+		lastAnchor  time.Time
+	}
+
+	// chainedRecord is the canonical form of a record covered by its hash.
+	chainedRecord struct {
+		Seq      uint64            `json:"seq"`
+		Time     string            `json:"time"`
+		Actor    string            `json:"actor"`
+		Action   string            `json:"action"`
+		Resource string            `json:"resource"`
+		Outcome  Outcome           `json:"outcome"`
+		Reason   string            `json:"reason,omitempty"`
+		Metadata map[string]string `json:"metadata,omitempty"`
+	}
+
+	// trailRecord is a record read back by Verify.
+	trailRecord struct {
+		chainedRecord
+		PrevHash *string `json:"prev_hash"`
+		Hash     string  `json:"hash"`
+	}
+)
+
+// newChainState returns the head of a chain configured by cfg.
+func newChainState(cfg Chain) *chainState {
+	return &chainState{cfg: cfg, prev: cfg.Previous, seq: cfg.Seq, lastAnchor: time.Now()}
+}
+
+// link returns the chaining fields of e, the next record of the chain, and
+// advances the head. It must be called with the lock of the Logger held.
+func (c *chainState) link(e *Event) []zap.Field {
+	c.seq++
+
+	record := newChainedRecord(c.seq, e)
+	digest := record.hash(c.cfg.Key, c.prev)
+
+	fields := []zap.Field{
+		zap.Uint64("seq", record.Seq),
+		zap.String("time", record.Time),
+		zap.String("prev_hash", c.prev),
+		zap.String("hash", digest),
+	}
+
+	c.prev = digest
+	c.sinceAnchor++
+
+	return fields
+}
+
+// anchorDue reports whether an anchor record must be written before the next
+// record.
+func (c *chainState) anchorDue(now time.Time) bool {
+	if c.cfg.AnchorEvery > 0 && c.sinceAnchor >= c.cfg.AnchorEvery {
+		return true
+	}
+
+	return c.cfg.AnchorInterval > 0 && c.sinceAnchor > 0 && now.Sub(c.lastAnchor) >= c.cfg.AnchorInterval
+}
+
+// anchor returns the anchor event certifying the head of the chain.
+func (c *chainState) anchor(name string, now time.Time) Event {
+	c.lastAnchor = now
+
+	return Event{
+		Actor:    name,
+		Action:   AnchorAction,
+		Resource: name,
+		Outcome:  Success,
+		Time:     now,
+		Metadata: map[string]string{
+			"head_seq":  strconv.FormatUint(c.seq, 10),
+			"head_hash": c.prev,
+		},
+	}
+}
+
+// newChainedRecord returns the canonical form of e at position seq.
+func newChainedRecord(seq uint64, e *Event) chainedRecord {
+	return chainedRecord{
+		Seq:      seq,
+		Time:     e.Time.UTC().Format(time.RFC3339Nano),
+		Actor:    e.Actor,
+		Action:   e.Action,
+		Resource: e.Resource,
+		Outcome:  e.Outcome,
+		Reason:   e.Reason,
+		Metadata: e.Metadata,
+	}
+}
+
+// hash returns the hex hash of the record chained to prev.
+func (r *chainedRecord) hash(key []byte, prev string) string {
+	var h hash.Hash
+	if len(key) > 0 {
+		h = hmac.New(sha256.New, key)
+	} else {
+		h = sha256.New()
+	}
+
+	// The canonical form only holds strings, numbers and a string map, whose
+	// keys encoding/json sorts, so marshaling cannot fail and is deterministic.
+	payload, _ := json.Marshal(r)
+
+	h.Write([]byte(prev))
+	h.Write([]byte{'\n'})
+	h.Write(payload)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Head returns the sequence number and hash of the last chained record, to be
+// stored so the chain can be resumed after a restart through Chain.Previous
+// and Chain.Seq.
+//
+// Returns:
+//   - The sequence number of the last record, 0 when none was written
+//   - The hash of the last record, or Chain.Previous
+func (l *Logger) Head() (uint64, string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.chain == nil {
+		return 0, ""
+	}
+
+	return l.chain.seq, l.chain.prev
+}
+
+// Verify checks the hash chain of an audit trail written as JSON lines, such
+// as the audit file. Lines without a hash, such as application logs sharing the
+// output, are skipped. The trace fields of the records are not covered by the
+// chain.
+//
+// Parameters:
+//   - r: The audit trail
+//   - key: The HMAC key of Chain.Key, or nil for plain SHA-256
+//
+// Returns:
+//   - The number of verified records
+//   - ErrChainBroken wrapped with the line of the first invalid record, or a
+//     read error
+func Verify(r io.Reader, key []byte) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	var (
+		records int
+		prev    string
+		seq     uint64
+	)
+
+	for line := 1; scanner.Scan(); line++ {
+		var record trailRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil || record.Hash == "" {
+			continue
+		}
+
+		if record.PrevHash == nil {
+			return records, fmt.Errorf("%w: line %d has no prev_hash", ErrChainBroken, line)
+		}
+
+		if records > 0 {
+			if record.Seq != seq+1 {
+				return records, fmt.Errorf("%w: line %d has seq %d, expected %d", ErrChainBroken, line, record.Seq, seq+1)
+			}
+			if *record.PrevHash != prev {
+				return records, fmt.Errorf("%w: line %d does not follow the previous record", ErrChainBroken, line)
+			}
+		}
+
+		if !hmac.Equal([]byte(record.chainedRecord.hash(key, *record.PrevHash)), []byte(record.Hash)) {
+			return records, fmt.Errorf("%w: line %d was altered", ErrChainBroken, line)
+		}
+
+		records++
+		prev, seq = record.Hash, record.Seq
+	}
+
+	return records, scanner.Err()
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package azuremonitor provides an Azure Monitor sink for the logging framework.
+// Entries are batched and sent to a Log Analytics workspace through the Logs
+// Ingestion API, using a data collection endpoint (DCE) and rule (DCR), and
+// authenticated with Microsoft Entra ID, so services on AKS or App Service can
+// ship structured logs without a sidecar agent.
+//
+// Each entry is sent as a record with the TimeGenerated, Level, Message, Logger,
+// Caller, Stack, TraceId, SpanId and Properties (dynamic) columns, which the
+// DCR stream declaration must define.
+package azuremonitor
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/batch"
+	"github.com/goxkit/logging/internal/checked"
+)
+
+const (
+	// APIVersion is the version of the Logs Ingestion API.
+	APIVersion = "2023-01-01"
+	// DefaultTimeout bounds each ingestion request.
+	DefaultTimeout = 10 * time.Second
+)
+
+// ErrMissingConfig is returned when the endpoint, rule ID or stream is not set.
+var ErrMissingConfig = errors.New("azure monitor endpoint, rule ID and stream are required")
+
+// Config describes the Azure Monitor sink.
+type Config struct {
+	// Endpoint is the logs ingestion URL of the data collection endpoint, e.g.
+	// "https://my-dce-abcd.westeurope-1.ingest.monitor.azure.com".
+	Endpoint string
+	// RuleID is the immutable ID of the data collection rule ("dcr-...").
+	RuleID string
+	// Stream is the stream declared in the rule, e.g. "Custom-AppLogs_CL".
+	Stream string
+	// Credential authenticates the requests. Defaults to DefaultCredential.
+	Credential Credential
+	// BatchSize is the maximum number of records per request. Defaults to 100.
+	BatchSize int
+	// FlushInterval is the maximum delay before buffered records are sent. Defaults to 5s.
+	FlushInterval time.Duration
+	// Timeout bounds each request. Defaults to DefaultTimeout.
+	Timeout time.Duration
+	// Client sends the requests. Defaults to an http.Client with Timeout.
+	Client *http.Client
+}
+
+// record is a row of the DCR stream.
+type record struct {
+	TimeGenerated time.Time      `json:"TimeGenerated"`
+	Level         string         `json:"Level"`
+	Message       string         `json:"Message"`
+	Logger        string         `json:"Logger,omitempty"`
+	Caller        string         `json:"Caller,omitempty"`
+	Stack         string         `json:"Stack,omitempty"`
+	TraceID       string         `json:"TraceId,omitempty"`
+	SpanID        string         `json:"SpanId,omitempty"`
+	Properties    map[string]any `json:"Properties,omitempty"`
+}
+
+// core is a zapcore.Core batching entries to the Logs Ingestion API.
+type core struct {
+	zapcore.LevelEnabler
+	cfg     *Config
+	url     string
+	batcher *batch.Batcher[*record]
+	fields  []zapcore.Field
+}
+
+// NewCore returns a core sending entries at or above level to Azure Monitor.
+//
+// Parameters:
+//   - cfg: Azure Monitor sink configuration
+//   - level: Minimum level sent to Azure Monitor
+//
+// Returns:
+//   - A zapcore.Core ready to be teed into a logger; Sync sends the buffered records
+//   - ErrMissingConfig if the endpoint, rule ID or stream is not set
+func NewCore(cfg Config, level zapcore.LevelEnabler) (zapcore.Core, error) {
+	if cfg.Endpoint == "" || cfg.RuleID == "" || cfg.Stream == "" {
+		return nil, ErrMissingConfig
+	}
+	if cfg.Credential == nil {
+		cfg.Credential = DefaultCredential()
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: cfg.Timeout}
+	}
+
+	c := &core{
+		LevelEnabler: level,
+		cfg:          &cfg,
+		url: fmt.Sprintf("%s/dataCollectionRules/%s/streams/%s?api-version=%s",
+			strings.TrimSuffix(cfg.Endpoint, "/"),
+			url.PathEscape(cfg.RuleID),
+			url.PathEscape(cfg.Stream),
+			APIVersion,
+		),
+	}
+	c.batcher = batch.New(batch.Config{
+		Size:     cfg.BatchSize,
+		Interval: cfg.FlushInterval,
+		OnError:  otel.Handle,
+	}, c.send)
+
+	return c, nil
+}
+
+// With adds structured context to the core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	merged = append(merged, c.fields...)
+	merged = append(merged, fields...)
+
+	return &core{LevelEnabler: c.LevelEnabler, cfg: c.cfg, url: c.url, batcher: c.batcher, fields: merged}
+}
+
+// Check adds the core to the checked entry when the level is enabled.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write buffers the entry for the next request.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	rec := &record{
+		TimeGenerated: ent.Time.UTC(),
+		Level:         ent.Level.CapitalString(),
+		Message:       ent.Message,
+		Logger:        ent.LoggerName,
+		Stack:         ent.Stack,
+	}
+
+	if ent.Caller.Defined {
+		rec.Caller = ent.Caller.TrimmedPath()
+	}
+	if id, ok := enc.Fields["trace_id"].(string); ok {
+		rec.TraceID = id
+		delete(enc.Fields, "trace_id")
+	}
+	if id, ok := enc.Fields["span_id"].(string); ok {
+		rec.SpanID = id
+		delete(enc.Fields, "span_id")
+	}
+	if len(enc.Fields) > 0 {
+		rec.Properties = enc.Fields
+	}
+
+	c.batcher.Add(rec)
+
+	return nil
+}
+
+// Sync sends the buffered records.
+func (c *core) Sync() error {
+	return c.batcher.Flush()
+}
+
+// Close stops the background flusher and sends the buffered entries.
+func (c *core) Close() error {
+	return c.batcher.Close()
+}
+
+// send uploads a batch of records.
+func (c *core) send(records []*record) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+
+	token, _, err := c.cfg.Credential.Token(ctx, Scope)
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+
+	gz := gzip.NewWriter(&body)
+	if err := json.NewEncoder(gz).Encode(records); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.cfg.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send logs to azure monitor: %w", err)
+	}
+	defer resp.Body.Close()
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("failed to send logs to azure monitor: %s: %s", resp.Status, msg)
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package azuremonitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scope is the Microsoft Entra ID (AAD) scope of the Logs Ingestion API.
+const Scope = "https://monitor.azure.com/.default"
+
+// Standard Azure identity variables, as used by the Azure SDKs.
+const (
+	TenantIDEnvKey           = "AZURE_TENANT_ID"
+	ClientIDEnvKey           = "AZURE_CLIENT_ID"
+	ClientSecretEnvKey       = "AZURE_CLIENT_SECRET"
+	FederatedTokenFileEnvKey = "AZURE_FEDERATED_TOKEN_FILE"
+	AuthorityHostEnvKey      = "AZURE_AUTHORITY_HOST"
+)
+
+const (
+	// defaultAuthorityHost is the Microsoft Entra ID endpoint of the public cloud.
+	defaultAuthorityHost = "https://login.microsoftonline.com/"
+	// imdsEndpoint is the managed identity endpoint of Azure VMs and AKS nodes.
+	imdsEndpoint = "http://169.254.169.254/metadata/identity/oauth2/token"
+	// refreshMargin renews tokens before they expire.
+	refreshMargin = 5 * time.Minute
+)
+
+// Credential provides Microsoft Entra ID access tokens. Azure SDK credentials
+// can be adapted with CredentialFunc.
+type Credential interface {
+	// Token returns an access token for scope and its expiration time.
+	Token(ctx context.Context, scope string) (string, time.Time, error)
+}
+
+// CredentialFunc adapts a function to the Credential interface.
+type CredentialFunc func(ctx context.Context, scope string) (string, time.Time, error)
+
+// Token calls f.
+func (f CredentialFunc) Token(ctx context.Context, scope string) (string, time.Time, error) {
+	return f(ctx, scope)
+}
+
+// tokenResponse is the OAuth2 token response of Entra ID and IMDS.
+type tokenResponse struct {
+	AccessToken string          `json:"access_token"`
+	ExpiresIn   json.RawMessage `json:"expires_in"`
+}
+
+// cachedCredential reuses a token until it is about to expire.
+type cachedCredential struct {
+	fetch func(ctx context.Context, scope string) (*tokenResponse, error)
+
+	mu        sync.Mutex
+	token     string
+	expiresOn time.Time
+}
+
+// Token returns the cached token, fetching a new one when needed.
+func (c *cachedCredential) Token(ctx context.Context, scope string) (string, time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Until(c.expiresOn) > refreshMargin {
+		return c.token, c.expiresOn, nil
+	}
+
+	resp, err := c.fetch(ctx, scope)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	seconds, _ := strconv.Atoi(strings.Trim(string(resp.ExpiresIn), `"`))
+	c.token = resp.AccessToken
+	c.expiresOn = time.Now().Add(time.Duration(seconds) * time.Second)
+
+	return c.token, c.expiresOn, nil
+}
+
+// ClientSecretCredential authenticates a service principal with a client secret.
+//
+// Parameters:
+//   - tenantID: Directory (tenant) ID
+//   - clientID: Application (client) ID
+//   - secret: Client secret
+//
+// Returns:
+//   - A Credential caching its tokens
+func ClientSecretCredential(tenantID, clientID, secret string) Credential {
+	return &cachedCredential{fetch: func(ctx context.Context, scope string) (*tokenResponse, error) {
+		return requestToken(ctx, tenantID, url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {clientID},
+			"client_secret": {secret},
+			"scope":         {scope},
+		})
+	}}
+}
+
+// WorkloadIdentityCredential authenticates with the federated token projected
+// by AKS workload identity (AZURE_TENANT_ID, AZURE_CLIENT_ID and
+// AZURE_FEDERATED_TOKEN_FILE).
+//
+// Returns:
+//   - A Credential caching its tokens
+func WorkloadIdentityCredential() Credential {
+	return &cachedCredential{fetch: func(ctx context.Context, scope string) (*tokenResponse, error) {
+		assertion, err := os.ReadFile(os.Getenv(FederatedTokenFileEnvKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read azure federated token: %w", err)
+		}
+
+		return requestToken(ctx, os.Getenv(TenantIDEnvKey), url.Values{
+			"grant_type":            {"client_credentials"},
+			"client_id":             {os.Getenv(ClientIDEnvKey)},
+			"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+			"client_assertion":      {strings.TrimSpace(string(assertion))},
+			"scope":                 {scope},
+		})
+	}}
+}
+
+// ManagedIdentityCredential authenticates with the managed identity of the
+// Azure VM, AKS node or App Service the process runs on.
+//
+// Parameters:
+//   - clientID: Client ID of a user-assigned identity, empty for the system-assigned one
+//
+// Returns:
+//   - A Credential caching its tokens
+func ManagedIdentityCredential(clientID string) Credential {
+	return &cachedCredential{fetch: func(ctx context.Context, scope string) (*tokenResponse, error) {
+		query := url.Values{"resource": {strings.TrimSuffix(scope, "/.default")}}
+
+		// App Service and Functions expose their own identity endpoint.
+		endpoint, header := os.Getenv("IDENTITY_ENDPOINT"), os.Getenv("IDENTITY_HEADER")
+		if endpoint != "" && header != "" {
+			query.Set("api-version", "2019-08-01")
+		} else {
+			endpoint = imdsEndpoint
+			query.Set("api-version", "2018-02-01")
+		}
+		if clientID != "" {
+			query.Set("client_id", clientID)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if header != "" {
+			req.Header.Set("X-IDENTITY-HEADER", header)
+		} else {
+			req.Header.Set("Metadata", "true")
+		}
+
+		return doTokenRequest(req)
+	}}
+}
+
+// DefaultCredential selects a credential from the environment: a client secret,
+// then workload identity, then the managed identity.
+//
+// Returns:
+//   - The Credential matching the environment
+func DefaultCredential() Credential {
+	tenantID, clientID := os.Getenv(TenantIDEnvKey), os.Getenv(ClientIDEnvKey)
+
+	switch {
+	case tenantID != "" && clientID != "" && os.Getenv(ClientSecretEnvKey) != "":
+		return ClientSecretCredential(tenantID, clientID, os.Getenv(ClientSecretEnvKey))
+	case tenantID != "" && clientID != "" && os.Getenv(FederatedTokenFileEnvKey) != "":
+		return WorkloadIdentityCredential()
+	default:
+		return ManagedIdentityCredential(clientID)
+	}
+}
+
+// requestToken requests a token from the Entra ID token endpoint of the tenant.
+func requestToken(ctx context.Context, tenantID string, form url.Values) (*tokenResponse, error) {
+	authority := os.Getenv(AuthorityHostEnvKey)
+	if authority == "" {
+		authority = defaultAuthorityHost
+	}
+
+	endpoint := strings.TrimSuffix(authority, "/") + "/" + url.PathEscape(tenantID) + "/oauth2/v2.0/token"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return doTokenRequest(req)
+}
+
+// doTokenRequest sends a token request and decodes the response.
+func doTokenRequest(req *http.Request) (*tokenResponse, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request azure token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to request azure token: %s: %s", resp.Status, body)
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to decode azure token: %w", err)
+	}
+
+	return &token, nil
+}
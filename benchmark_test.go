@@ -0,0 +1,120 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/goxkit/configs"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging"
+)
+
+// BenchmarkStdout measures an entry encoded as JSON by the local output.
+func BenchmarkStdout(b *testing.B) {
+	logger, err := logging.New(
+		logging.WithOutput(io.Discard),
+		logging.WithEncoder(logging.JSONEncoder),
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	benchmarkInfo(b, logger)
+}
+
+// BenchmarkOTLP measures an entry written to the local output and handed to
+// the OpenTelemetry logger provider, whose exporter discards the records.
+func BenchmarkOTLP(b *testing.B) {
+	logger, err := logging.New(
+		logging.WithOutput(io.Discard),
+		logging.WithEncoder(logging.JSONEncoder),
+		logging.WithOTLPStdout(io.Discard),
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { _ = logger.Shutdown(context.Background()) })
+
+	benchmarkInfo(b, logger)
+}
+
+// BenchmarkNoop measures an entry of the logger created by NewLogger when the
+// OpenTelemetry export is disabled.
+func BenchmarkNoop(b *testing.B) {
+	logger, err := newNoopLogger(b)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	benchmarkInfo(b, logger)
+}
+
+// BenchmarkCheckDisabled measures the level check of an entry below the
+// minimum level, which must not allocate.
+func BenchmarkCheckDisabled(b *testing.B) {
+	logger, err := logging.New(
+		logging.WithOutput(io.Discard),
+		logging.WithLevel(zapcore.InfoLevel),
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	check := func() {
+		if ce := logger.Check(zapcore.DebugLevel, "cache miss"); ce != nil {
+			ce.Write(zap.String("key", "orders:42"))
+		}
+	}
+
+	if allocs := testing.AllocsPerRun(100, check); allocs != 0 {
+		b.Fatalf("Check of a disabled level allocates %v times, want 0", allocs)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		check()
+	}
+}
+
+// benchmarkInfo logs an Info entry with a few fields per iteration.
+func benchmarkInfo(b *testing.B, logger logging.Logger) {
+	b.ReportAllocs()
+	for b.Loop() {
+		logger.Info("request handled",
+			zap.String("method", "GET"),
+			zap.String("path", "/orders"),
+			zap.Int("status", 200),
+		)
+	}
+}
+
+// newNoopLogger creates the logger of NewLogger without OpenTelemetry export,
+// its standard output redirected to the null device.
+func newNoopLogger(b *testing.B) (logging.Logger, error) {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	b.Cleanup(func() { _ = devNull.Close() })
+
+	stdout := os.Stdout
+	os.Stdout = devNull
+	defer func() { os.Stdout = stdout }()
+
+	return logging.NewLogger(context.Background(), &configs.Configs{
+		AppConfigs: &configs.AppConfigs{
+			Environment: configs.ProductionEnv,
+			Name:        "benchmark",
+			LogLevel:    configs.INFO,
+		},
+		OTLPConfigs: &configs.OTLPConfigs{},
+	})
+}
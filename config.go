@@ -0,0 +1,608 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
+
+	"github.com/goxkit/logging/async"
+	"github.com/goxkit/logging/dedup"
+	"github.com/goxkit/logging/file"
+	"github.com/goxkit/logging/gelf"
+	"github.com/goxkit/logging/internal/checked"
+	"github.com/goxkit/logging/limit"
+	"github.com/goxkit/logging/otlp"
+	"github.com/goxkit/logging/redact"
+	"github.com/goxkit/logging/syslog"
+	zapInstance "github.com/goxkit/logging/zap"
+)
+
+// Sink types of FileSink.
+const (
+	StdoutSink   = "stdout"
+	StderrSink   = "stderr"
+	FileSinkType = "file"
+	SyslogSink   = "syslog"
+	GELFSink     = "gelf"
+)
+
+// SplitOutput is the FileConfig output writing Warn and above to stderr and
+// the other entries to stdout.
+const SplitOutput = "split"
+
+type (
+	// FileConfig is a logging configuration document, written in YAML or JSON,
+	// that lets operators manage levels, sampling, redaction and sinks apart from
+	// the application code:
+	//
+	//	level: info
+	//	levels:
+	//	  repository: debug
+	//	sampling:
+	//	  initial: 100
+	//	  thereafter: 10
+	//	redaction:
+	//	  defaults: true
+	//	  hashedKeys: [email]
+	//	sinks:
+	//	  - type: file
+	//	    path: /var/log/my-service.log
+	//
+	// Unset sections keep the settings of the options the logger was created with.
+	// The service, output, OTLP and asynchronous sections only apply when the
+	// logger is created, by NewFromConfigFile.
+	FileConfig struct {
+		// ServiceName, Namespace and Environment identify the service.
+		ServiceName string `yaml:"serviceName"`
+		Namespace   string `yaml:"namespace"`
+		Environment string `yaml:"environment"`
+		// Output is the destination of the local output: "stdout", "stderr" or
+		// "split", which writes Warn and above to stderr and the rest to stdout.
+		Output string `yaml:"output"`
+		// OTLP exports the entries to an OpenTelemetry collector.
+		OTLP *FileOTLP `yaml:"otlp"`
+		// Async writes to the outputs from a background goroutine.
+		Async *FileAsync `yaml:"async"`
+		// Level is the global minimum level, e.g. "info".
+		Level string `yaml:"level"`
+		// Levels are the levels per logger name, as in LOG_LEVELS.
+		Levels map[string]string `yaml:"levels"`
+		// Encoder is the format of the local output: "console", "json", "gcp"
+		// or "pretty".
+		Encoder string `yaml:"encoder"`
+		// TimeFormat is the encoding of the timestamps: "iso8601", "rfc3339",
+		// "rfc3339nano", "epoch", "epoch_millis" or "epoch_nanos".
+		TimeFormat string `yaml:"timeFormat"`
+		// TimeKey is the name of the timestamp field, e.g. "@timestamp".
+		TimeKey string `yaml:"timeKey"`
+		// Sampling caps the logging volume per level.
+		Sampling *FileSampling `yaml:"sampling"`
+		// RateLimits are the limits per logger name, "rate[:burst]", where the
+		// "*" name sets the default limit.
+		RateLimits map[string]string `yaml:"rateLimits"`
+		// Redaction masks sensitive fields.
+		Redaction *FileRedaction `yaml:"redaction"`
+		// Limits truncates oversized entries.
+		Limits *FileLimits `yaml:"limits"`
+		// Dedup collapses identical entries.
+		Dedup *FileDedup `yaml:"dedup"`
+		// Sinks are additional outputs.
+		Sinks []FileSink `yaml:"sinks"`
+	}
+
+	// FileOTLP is the OTLP export section of a FileConfig.
+	FileOTLP struct {
+		// Endpoint is the collector address, e.g. "otel-collector:4317".
+		Endpoint string `yaml:"endpoint"`
+		// Protocol is "grpc" or "http/protobuf". Defaults to OTEL_EXPORTER_OTLP_PROTOCOL or gRPC.
+		Protocol string `yaml:"protocol"`
+		// Headers are sent with every export request.
+		Headers map[string]string `yaml:"headers"`
+		// CAFile, CertFile and KeyFile enable TLS, with a client certificate
+		// when CertFile and KeyFile are set.
+		CAFile   string `yaml:"caFile"`
+		CertFile string `yaml:"certFile"`
+		KeyFile  string `yaml:"keyFile"`
+		// ResourceAttributes are added to the resource of the exported records.
+		ResourceAttributes map[string]string `yaml:"resourceAttributes"`
+		// Batch tunes the batch processor, see otlp.BatchConfig.
+		Batch *FileBatch `yaml:"batch"`
+		// Level is the minimum level of the exported entries, e.g. "warn".
+		// Defaults to the logger levels.
+		Level string `yaml:"level"`
+		// FailFast fails the creation of the logger when the collector is
+		// unreachable, see WithOTLPFailFast.
+		FailFast bool `yaml:"failFast"`
+	}
+
+	// FileBatch is the batch processor section of a FileOTLP.
+	FileBatch struct {
+		MaxQueueSize       int           `yaml:"maxQueueSize"`
+		ExportInterval     time.Duration `yaml:"exportInterval"`
+		ExportTimeout      time.Duration `yaml:"exportTimeout"`
+		ExportMaxBatchSize int           `yaml:"exportMaxBatchSize"`
+	}
+
+	// FileAsync is the asynchronous writing section of a FileConfig, see async.Config.
+	FileAsync struct {
+		BufferSize int `yaml:"bufferSize"`
+		// Policy is "dropOldest", "block" or "dropBelowLevel".
+		Policy string `yaml:"policy"`
+		// DropLevel is the level from which entries are never dropped, e.g. "error".
+		DropLevel string `yaml:"dropLevel"`
+	}
+
+	// FileSampling is the sampling section of a FileConfig, see Sampling.
+	FileSampling struct {
+		Tick       time.Duration `yaml:"tick"`
+		Initial    int           `yaml:"initial"`
+		Thereafter int           `yaml:"thereafter"`
+	}
+
+	// FileRedaction is the redaction section of a FileConfig, see redact.Config.
+	FileRedaction struct {
+		// Defaults starts from redact.DefaultConfig.
+		Defaults      bool     `yaml:"defaults"`
+		Keys          []string `yaml:"keys"`
+		KeyPatterns   []string `yaml:"keyPatterns"`
+		ValuePatterns []string `yaml:"valuePatterns"`
+		CardNumbers   bool     `yaml:"cardNumbers"`
+		Messages      bool     `yaml:"messages"`
+		Mask          string   `yaml:"mask"`
+		HashedKeys    []string `yaml:"hashedKeys"`
+		// HashSecretEnv is the variable holding the HMAC secret of HashedKeys,
+		// so the secret is not written in the document.
+		HashSecretEnv string `yaml:"hashSecretEnv"`
+		// Environments restricts the redaction to the listed environments.
+		Environments []string `yaml:"environments"`
+	}
+
+	// FileLimits is the size limits section of a FileConfig, see limit.Config.
+	FileLimits struct {
+		MaxMessageSize int `yaml:"maxMessageSize"`
+		MaxFieldSize   int `yaml:"maxFieldSize"`
+		MaxEntrySize   int `yaml:"maxEntrySize"`
+	}
+
+	// FileDedup is the deduplication section of a FileConfig, see dedup.Config.
+	FileDedup struct {
+		Window      time.Duration `yaml:"window"`
+		Fingerprint []string      `yaml:"fingerprint"`
+		MaxKeys     int           `yaml:"maxKeys"`
+	}
+
+	// FileSink is an additional output of a FileConfig.
+	FileSink struct {
+		// Type is StdoutSink, StderrSink, FileSinkType, SyslogSink or GELFSink.
+		Type string `yaml:"type"`
+		// Level is the minimum level of the sink. Defaults to the logger levels.
+		Level string `yaml:"level"`
+		// Encoder is the format of stdout, stderr and file sinks.
+		Encoder string `yaml:"encoder"`
+
+		// Path, MaxSizeMB, MaxAgeDays, MaxBackups and Compress configure file sinks.
+		Path       string `yaml:"path"`
+		MaxSizeMB  int    `yaml:"maxSizeMB"`
+		MaxAgeDays int    `yaml:"maxAgeDays"`
+		MaxBackups int    `yaml:"maxBackups"`
+		Compress   bool   `yaml:"compress"`
+
+		// Network and Address configure syslog and GELF sinks.
+		Network string `yaml:"network"`
+		Address string `yaml:"address"`
+		// Facility and AppName configure syslog sinks, e.g. "local0".
+		Facility string `yaml:"facility"`
+		AppName  string `yaml:"appName"`
+	}
+
+	// configSink is a sink of a configuration file with the encoder options of
+	// the file. It identifies the core built from it across reloads.
+	configSink struct {
+		sink        FileSink
+		encoderOpts zapInstance.EncoderOptions
+	}
+)
+
+// NewFromConfigFile creates a logger whose whole pipeline (service identity,
+// encoder, outputs, sinks, levels per name, OTLP export, redaction, sampling,
+// ...) is described by a YAML or JSON configuration file, so operators can
+// manage logging apart from the application code. The settings of the file
+// take precedence over opts, which can provide code-defined defaults or
+// outputs. Pass the same path to WatchConfigFile to apply later changes live.
+//
+// Parameters:
+//   - path: Path of the YAML or JSON configuration file
+//   - opts: Options applied before those of the file
+//
+// Returns:
+//   - A configured Logger implementation
+//   - An error if the file cannot be read, is invalid or the logger cannot be created
+func NewFromConfigFile(path string, opts ...Option) (Logger, error) {
+	cfg, err := LoadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fileOpts, err := cfg.Options()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(append(opts[:len(opts):len(opts)], fileOpts...)...)
+}
+
+// ParseConfig parses a logging configuration document. JSON documents are
+// accepted too, as JSON is a subset of YAML. Unknown keys are rejected.
+//
+// Parameters:
+//   - data: The YAML or JSON document
+//
+// Returns:
+//   - The parsed configuration
+//   - An error if the document is malformed
+func ParseConfig(data []byte) (*FileConfig, error) {
+	cfg := &FileConfig{}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid logging configuration: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// LoadConfigFile reads and parses a logging configuration file.
+//
+// Parameters:
+//   - path: Path of the YAML or JSON document
+//
+// Returns:
+//   - The parsed configuration
+//   - An error if the file cannot be read or is malformed
+func LoadConfigFile(path string) (*FileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseConfig(data)
+}
+
+// Options converts the configuration into the options it describes, so it can
+// be passed to New, possibly after code-defined options.
+//
+// The sinks are created by New, or by ApplyConfig, which reuses those of the
+// running pipeline whose description did not change.
+//
+// Returns:
+//   - The options of the configured sections
+//   - An error if a value is invalid
+func (c *FileConfig) Options() ([]Option, error) {
+	var opts []Option
+
+	if c.ServiceName != "" {
+		opts = append(opts, WithServiceName(c.ServiceName))
+	}
+	if c.Namespace != "" {
+		opts = append(opts, WithNamespace(c.Namespace))
+	}
+	if c.Environment != "" {
+		opts = append(opts, WithEnvironment(c.Environment))
+	}
+
+	switch c.Output {
+	case "", StdoutSink:
+	case StderrSink:
+		opts = append(opts, WithOutput(os.Stderr))
+	case SplitOutput:
+		opts = append(opts, WithSplitOutput(zapcore.WarnLevel))
+	default:
+		return nil, fmt.Errorf("invalid output %q, expected stdout, stderr or split", c.Output)
+	}
+
+	if c.OTLP != nil {
+		otlpOpts, err := c.OTLP.options()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlpOpts...)
+	}
+
+	if a := c.Async; a != nil {
+		cfg, err := a.config()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithAsync(cfg))
+	}
+
+	if c.Level != "" {
+		level, err := zapcore.ParseLevel(c.Level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid level: %w", err)
+		}
+		opts = append(opts, WithLevel(level))
+	}
+
+	if len(c.Levels) > 0 {
+		levels := make(map[string]zapcore.Level, len(c.Levels))
+		for name, raw := range c.Levels {
+			level, err := zapcore.ParseLevel(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid level of %q: %w", name, err)
+			}
+			levels[name] = level
+		}
+		opts = append(opts, WithNamedLevels(levels))
+	}
+
+	if c.Encoder != "" {
+		opts = append(opts, WithEncoder(Encoder(c.Encoder)))
+	}
+
+	encoderOpts := zapInstance.EncoderOptions{TimeKey: c.TimeKey}
+	if c.TimeFormat != "" {
+		format, err := zapInstance.ParseTimeFormat(c.TimeFormat)
+		if err != nil {
+			return nil, err
+		}
+		encoderOpts.TimeFormat = format
+		opts = append(opts, WithTimeFormat(format))
+	}
+
+	if c.TimeKey != "" {
+		opts = append(opts, WithTimeKey(c.TimeKey))
+	}
+
+	if s := c.Sampling; s != nil {
+		opts = append(opts, WithSampling(Sampling{Tick: s.Tick, Initial: s.Initial, Thereafter: s.Thereafter}))
+	}
+
+	if len(c.RateLimits) > 0 {
+		items := make([]string, 0, len(c.RateLimits))
+		for name, limit := range c.RateLimits {
+			items = append(items, name+"="+limit)
+		}
+		sort.Strings(items)
+
+		limits, err := zapInstance.ParseRateLimits(strings.Join(items, ","))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithRateLimits(*limits))
+	}
+
+	if r := c.Redaction; r != nil {
+		cfg, err := r.config()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithRedaction(cfg, r.Environments...))
+	}
+
+	if l := c.Limits; l != nil {
+		opts = append(opts, WithLimits(limit.Config{
+			MaxMessageSize: l.MaxMessageSize,
+			MaxFieldSize:   l.MaxFieldSize,
+			MaxEntrySize:   l.MaxEntrySize,
+		}))
+	}
+
+	if d := c.Dedup; d != nil {
+		opts = append(opts, WithDedup(dedup.Config{Window: d.Window, Fingerprint: d.Fingerprint, MaxKeys: d.MaxKeys}))
+	}
+
+	if len(c.Sinks) > 0 {
+		sinks := make([]configSink, 0, len(c.Sinks))
+		for _, sink := range c.Sinks {
+			sinks = append(sinks, configSink{sink: sink, encoderOpts: encoderOpts})
+		}
+		opts = append(opts, withConfigSinks(sinks))
+	}
+
+	return opts, nil
+}
+
+// withConfigSinks sets the sinks of a configuration file, replacing those of
+// a configuration applied before.
+func withConfigSinks(sinks []configSink) Option {
+	return func(o *options) {
+		o.configSinks = sinks
+	}
+}
+
+// options returns the OTLP export options of the section.
+func (c *FileOTLP) options() ([]Option, error) {
+	if c.Endpoint == "" {
+		return nil, errors.New("otlp endpoint is required")
+	}
+
+	opts := []Option{WithOTLPEndpoint(c.Endpoint)}
+
+	switch otlp.Protocol(c.Protocol) {
+	case "":
+	case otlp.GRPCProtocol, otlp.HTTPProtobufProtocol:
+		opts = append(opts, WithOTLPProtocol(otlp.Protocol(c.Protocol)))
+	default:
+		return nil, fmt.Errorf("invalid otlp protocol %q", c.Protocol)
+	}
+
+	if len(c.Headers) > 0 {
+		opts = append(opts, WithOTLPHeaders(c.Headers))
+	}
+
+	if c.FailFast {
+		opts = append(opts, WithOTLPFailFast())
+	}
+
+	if c.Level != "" {
+		level, err := zapcore.ParseLevel(c.Level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid otlp level: %w", err)
+		}
+		opts = append(opts, WithOTLPLevel(level))
+	}
+
+	if c.CAFile != "" || c.CertFile != "" {
+		tlsConfig, err := otlp.NewTLSConfig(c.CAFile, c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithOTLPTLS(tlsConfig))
+	}
+
+	if len(c.ResourceAttributes) > 0 {
+		attrs := make([]attribute.KeyValue, 0, len(c.ResourceAttributes))
+		for k, v := range c.ResourceAttributes {
+			attrs = append(attrs, attribute.String(k, v))
+		}
+		opts = append(opts, WithResourceAttributes(attrs...))
+	}
+
+	if b := c.Batch; b != nil {
+		opts = append(opts, WithBatch(otlp.BatchConfig{
+			MaxQueueSize:       b.MaxQueueSize,
+			ExportInterval:     b.ExportInterval,
+			ExportTimeout:      b.ExportTimeout,
+			ExportMaxBatchSize: b.ExportMaxBatchSize,
+		}))
+	}
+
+	return opts, nil
+}
+
+// config returns the asynchronous writing settings of the section.
+func (a *FileAsync) config() (async.Config, error) {
+	cfg := async.Config{BufferSize: a.BufferSize}
+
+	switch a.Policy {
+	case "", "dropOldest":
+		cfg.Policy = async.DropOldest
+	case "block":
+		cfg.Policy = async.Block
+	case "dropBelowLevel":
+		cfg.Policy = async.DropBelowLevel
+	default:
+		return cfg, fmt.Errorf("invalid async policy %q", a.Policy)
+	}
+
+	if a.DropLevel != "" {
+		level, err := zapcore.ParseLevel(a.DropLevel)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid async drop level: %w", err)
+		}
+		cfg.DropLevel = level
+	}
+
+	return cfg, nil
+}
+
+// config returns the redaction rules of the section.
+func (r *FileRedaction) config() (redact.Config, error) {
+	var cfg redact.Config
+	if r.Defaults {
+		cfg = redact.DefaultConfig()
+	}
+
+	cfg.Keys = append(cfg.Keys[:len(cfg.Keys):len(cfg.Keys)], r.Keys...)
+	cfg.HashedKeys = append(cfg.HashedKeys, r.HashedKeys...)
+	cfg.CardNumbers = cfg.CardNumbers || r.CardNumbers
+	cfg.Messages = cfg.Messages || r.Messages
+
+	if r.Mask != "" {
+		cfg.Mask = r.Mask
+	}
+	if r.HashSecretEnv != "" {
+		cfg.HashSecret = []byte(os.Getenv(r.HashSecretEnv))
+	}
+
+	for _, raw := range r.KeyPatterns {
+		p, err := regexp.Compile(raw)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid redaction key pattern: %w", err)
+		}
+		cfg.KeyPatterns = append(cfg.KeyPatterns, p)
+	}
+
+	patterns := cfg.ValuePatterns[:len(cfg.ValuePatterns):len(cfg.ValuePatterns)]
+	for _, raw := range r.ValuePatterns {
+		p, err := regexp.Compile(raw)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid redaction value pattern: %w", err)
+		}
+		patterns = append(patterns, p)
+	}
+	cfg.ValuePatterns = patterns
+
+	return cfg, nil
+}
+
+// core creates the output described by the sink, encoded with encoderOpts.
+func (s *FileSink) core(encoderOpts zapInstance.EncoderOptions) (zapcore.Core, error) {
+	// Without a level of its own, the sink accepts every entry enabled by the
+	// levels of the logger.
+	level := zapcore.DebugLevel
+	if s.Level != "" {
+		var err error
+		if level, err = zapcore.ParseLevel(s.Level); err != nil {
+			return nil, err
+		}
+	}
+
+	encoder := Encoder(s.Encoder)
+	if encoder == "" {
+		encoder = JSONEncoder
+	}
+
+	switch s.Type {
+	case StdoutSink:
+		return checked.NewCore(zapInstance.NewEncoderWithOptions(encoder, encoderOpts), zapcore.Lock(os.Stdout), level), nil
+	case StderrSink:
+		return checked.NewCore(zapInstance.NewEncoderWithOptions(encoder, encoderOpts), zapcore.Lock(os.Stderr), level), nil
+	case FileSinkType:
+		if s.Path == "" {
+			return nil, errors.New("path is required")
+		}
+
+		return file.NewCore(&file.Config{
+			Path:           s.Path,
+			MaxSizeMB:      s.MaxSizeMB,
+			MaxAgeDays:     s.MaxAgeDays,
+			MaxBackups:     s.MaxBackups,
+			Compress:       s.Compress,
+			Encoder:        encoder,
+			EncoderOptions: encoderOpts,
+		}, level), nil
+	case SyslogSink:
+		cfg := syslog.Config{Network: s.Network, Address: s.Address, AppName: s.AppName}
+		if s.Facility != "" {
+			facility, err := syslog.ParseFacility(s.Facility)
+			if err != nil {
+				return nil, err
+			}
+			cfg.Facility = facility
+		}
+
+		return syslog.NewCore(cfg, level)
+	case GELFSink:
+		return gelf.NewCore(gelf.Config{Network: s.Network, Address: s.Address}, level)
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", s.Type)
+	}
+}
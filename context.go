@@ -0,0 +1,84 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"context"
+)
+
+// loggerKey is the context key of the request-scoped logger.
+type loggerKey struct{}
+
+// ToContext returns a copy of ctx carrying l, so downstream code retrieves the
+// request-scoped logger, with its request ID or user fields, through
+// FromContext.
+//
+// Parameters:
+//   - ctx: The parent context
+//   - l: The logger to carry
+//
+// Returns:
+//   - A context carrying l
+func ToContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext returns the logger carried by ctx, falling back to the default
+// logger (see L) when ctx carries none.
+//
+// Parameters:
+//   - ctx: The context carrying the logger
+//
+// Returns:
+//   - The request-scoped Logger, or the default one
+func FromContext(ctx context.Context) Logger {
+	if l, ok := ctx.Value(loggerKey{}).(Logger); ok {
+		return l
+	}
+
+	return L()
+}
+
+// WithContextFields returns a copy of ctx carrying the logger of ctx extended
+// with fields, so every entry logged downstream includes them.
+//
+// Parameters:
+//   - ctx: The parent context
+//   - fields: The fields added to the request-scoped logger
+//
+// Returns:
+//   - A context carrying the extended logger
+func WithContextFields(ctx context.Context, fields ...Field) context.Context {
+	return ToContext(ctx, WithFields(FromContext(ctx), fields...))
+}
+
+// WithFields returns a child of l adding fields to every entry. Unlike
+// Logger.With, the child keeps the Logger interface, so it can be passed along
+// or stored with ToContext.
+//
+// Parameters:
+//   - l: The parent logger
+//   - fields: The fields added to every entry
+//
+// Returns:
+//   - The child Logger, or l itself when fields is empty or l cannot be extended
+func WithFields(l Logger, fields ...Field) Logger {
+	if len(fields) == 0 {
+		return l
+	}
+
+	if ll, ok := l.(*logger); ok {
+		c := *ll
+		c.Logger = ll.Logger.With(fields...)
+		return &c
+	}
+
+	z := l.With(fields...)
+	if z == nil {
+		return l
+	}
+
+	return newLogger(z, nil)
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package datadog provides a Datadog Logs API sink for the logging framework.
+// Entries are batched and posted to the Datadog log intake, tagged with the
+// service, source and environment, and correlated with APM traces through the
+// dd.trace_id and dd.span_id attributes.
+package datadog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goxkit/configs"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/batch"
+	"github.com/goxkit/logging/internal/checked"
+)
+
+const (
+	// DefaultSite is the Datadog site receiving the logs.
+	DefaultSite = "datadoghq.com"
+	// DefaultSource is the ddsource of the logs.
+	DefaultSource = "go"
+	// DefaultTimeout bounds each intake request.
+	DefaultTimeout = 10 * time.Second
+
+	// Standard Datadog agent variables used as defaults.
+	APIKeyEnvKey = "DD_API_KEY"
+	SiteEnvKey   = "DD_SITE"
+	TagsEnvKey   = "DD_TAGS"
+)
+
+// ErrMissingAPIKey is returned when no API key is configured.
+var ErrMissingAPIKey = errors.New("datadog API key is required")
+
+// Config describes the Datadog sink.
+type Config struct {
+	// APIKey authenticates the requests. Defaults to DD_API_KEY.
+	APIKey string
+	// Site is the Datadog site, e.g. "datadoghq.eu". Defaults to DD_SITE or DefaultSite.
+	Site string
+	// URL overrides the intake URL derived from Site, e.g. to go through a proxy.
+	URL string
+	// Service is the service attribute of the logs.
+	Service string
+	// Source is the ddsource attribute. Defaults to DefaultSource.
+	Source string
+	// Tags are added as ddtags ("key:value"), next to those of DD_TAGS.
+	Tags []string
+	// Hostname is the hostname attribute. Defaults to os.Hostname.
+	Hostname string
+	// BatchSize is the maximum number of logs per request. Defaults to 100.
+	BatchSize int
+	// FlushInterval is the maximum delay before buffered logs are sent. Defaults to 5s.
+	FlushInterval time.Duration
+	// Timeout bounds each request. Defaults to DefaultTimeout.
+	Timeout time.Duration
+	// Client sends the requests. Defaults to an http.Client with Timeout.
+	Client *http.Client
+}
+
+// core is a zapcore.Core batching entries to the Datadog log intake.
+type core struct {
+	zapcore.LevelEnabler
+	cfg     *Config
+	batcher *batch.Batcher[map[string]any]
+	fields  []zapcore.Field
+}
+
+// NewConfig returns a Config populated from the application configs: the
+// service from the application name, and the env and namespace tags.
+//
+// Parameters:
+//   - cfgs: Application configurations
+//
+// Returns:
+//   - The Datadog sink configuration, with the API key read from DD_API_KEY
+func NewConfig(cfgs *configs.Configs) Config {
+	cfg := Config{Service: cfgs.AppConfigs.Name}
+
+	if env := cfgs.AppConfigs.Environment.String(); env != "" {
+		cfg.Tags = append(cfg.Tags, "env:"+env)
+	}
+	if ns := cfgs.AppConfigs.Namespace; ns != "" {
+		cfg.Tags = append(cfg.Tags, "namespace:"+ns)
+	}
+
+	return cfg
+}
+
+// NewCore returns a core posting entries at or above level to Datadog.
+//
+// Parameters:
+//   - cfg: Datadog sink configuration
+//   - level: Minimum level sent to Datadog
+//
+// Returns:
+//   - A zapcore.Core ready to be teed into a logger; Sync sends the buffered logs
+//   - ErrMissingAPIKey if no API key is configured
+func NewCore(cfg Config, level zapcore.LevelEnabler) (zapcore.Core, error) {
+	if cfg.APIKey == "" {
+		cfg.APIKey = os.Getenv(APIKeyEnvKey)
+	}
+	if cfg.APIKey == "" {
+		return nil, ErrMissingAPIKey
+	}
+	if cfg.Site == "" {
+		cfg.Site = os.Getenv(SiteEnvKey)
+	}
+	if cfg.Site == "" {
+		cfg.Site = DefaultSite
+	}
+	if cfg.URL == "" {
+		cfg.URL = fmt.Sprintf("https://http-intake.logs.%s/api/v2/logs", cfg.Site)
+	}
+	if cfg.Source == "" {
+		cfg.Source = DefaultSource
+	}
+	if cfg.Hostname == "" {
+		cfg.Hostname, _ = os.Hostname()
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: cfg.Timeout}
+	}
+	if tags := os.Getenv(TagsEnvKey); tags != "" {
+		cfg.Tags = append(cfg.Tags, strings.FieldsFunc(tags, func(r rune) bool {
+			return r == ',' || r == ' '
+		})...)
+	}
+
+	c := &core{LevelEnabler: level, cfg: &cfg}
+	c.batcher = batch.New(batch.Config{
+		Size:     cfg.BatchSize,
+		Interval: cfg.FlushInterval,
+		OnError:  otel.Handle,
+	}, c.send)
+
+	return c, nil
+}
+
+// With adds structured context to the core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	merged = append(merged, c.fields...)
+	merged = append(merged, fields...)
+
+	return &core{LevelEnabler: c.LevelEnabler, cfg: c.cfg, batcher: c.batcher, fields: merged}
+}
+
+// Check adds the core to the checked entry when the level is enabled.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write buffers the entry for the next request.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	log := enc.Fields
+	log["message"] = ent.Message
+	log["status"] = status(ent.Level)
+	log["timestamp"] = ent.Time.UnixMilli()
+	log["service"] = c.cfg.Service
+	log["ddsource"] = c.cfg.Source
+	log["hostname"] = c.cfg.Hostname
+
+	if len(c.cfg.Tags) > 0 {
+		log["ddtags"] = strings.Join(c.cfg.Tags, ",")
+	}
+	if ent.LoggerName != "" {
+		log["logger.name"] = ent.LoggerName
+	}
+	if ent.Stack != "" {
+		log["error.stack"] = ent.Stack
+	}
+
+	// Datadog APM identifies traces by the lower 64 bits of the OpenTelemetry IDs.
+	if id, ok := log["trace_id"].(string); ok {
+		if ddID, ok := datadogID(id); ok {
+			log["dd.trace_id"] = ddID
+		}
+	}
+	if id, ok := log["span_id"].(string); ok {
+		if ddID, ok := datadogID(id); ok {
+			log["dd.span_id"] = ddID
+		}
+	}
+
+	c.batcher.Add(log)
+
+	return nil
+}
+
+// Sync sends the buffered logs.
+func (c *core) Sync() error {
+	return c.batcher.Flush()
+}
+
+// Close stops the background flusher and sends the buffered entries.
+func (c *core) Close() error {
+	return c.batcher.Close()
+}
+
+// send posts a batch of logs to the intake.
+func (c *core) send(logs []map[string]any) error {
+	var body bytes.Buffer
+
+	gz := gzip.NewWriter(&body)
+	if err := json.NewEncoder(gz).Encode(logs); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.cfg.URL, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("DD-API-KEY", c.cfg.APIKey)
+
+	resp, err := c.cfg.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send logs to datadog: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("failed to send logs to datadog: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// datadogID converts a hex OpenTelemetry trace or span ID into the decimal
+// representation of its lower 64 bits.
+func datadogID(id string) (string, bool) {
+	raw, err := hex.DecodeString(id)
+	if err != nil || len(raw) < 8 {
+		return "", false
+	}
+
+	return strconv.FormatUint(binary.BigEndian.Uint64(raw[len(raw)-8:]), 10), true
+}
+
+// status maps a Zap level to a Datadog log status.
+func status(level zapcore.Level) string {
+	switch level {
+	case zapcore.DebugLevel:
+		return "debug"
+	case zapcore.InfoLevel:
+		return "info"
+	case zapcore.WarnLevel:
+		return "warning"
+	case zapcore.ErrorLevel:
+		return "error"
+	case zapcore.DPanicLevel:
+		return "critical"
+	case zapcore.PanicLevel:
+		return "alert"
+	case zapcore.FatalLevel:
+		return "emergency"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package dedup provides a deduplicating zapcore.Core for the logging framework.
+// Identical entries (same level, logger, message and fingerprint fields) logged
+// within a window are collapsed: the first one is written immediately and the
+// repeats are summarized, once the window ends, by a single entry carrying a
+// repeat_count field. This tames the log storms of tight retry loops.
+package dedup
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+)
+
+const (
+	// RepeatCountKey is the field holding the number of collapsed repeats.
+	RepeatCountKey = "repeat_count"
+	// DefaultWindow is the deduplication window when Config.Window is zero.
+	DefaultWindow = time.Second
+	// DefaultMaxKeys is the number of tracked entries when Config.MaxKeys is zero.
+	DefaultMaxKeys = 10000
+)
+
+// Config describes the deduplication.
+type Config struct {
+	// Window is the period during which identical entries are collapsed.
+	// Defaults to DefaultWindow.
+	Window time.Duration
+	// Fingerprint are the names of the fields that, next to the level, logger
+	// name and message, identify an entry, e.g. "user_id" or "error".
+	Fingerprint []string
+	// MaxKeys bounds the number of tracked entries; entries logged while it is
+	// reached are not deduplicated. Defaults to DefaultMaxKeys.
+	MaxKeys int
+}
+
+// group tracks the repeats of an entry within the current window.
+type group struct {
+	start   time.Time
+	repeats int
+	core    zapcore.Core
+	ent     zapcore.Entry
+	fields  []zapcore.Field
+}
+
+// state is shared by a core and its With children.
+type state struct {
+	cfg         Config
+	fingerprint map[string]struct{}
+
+	mu     sync.Mutex
+	groups map[string]*group
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Core collapses identical entries before handing them to the wrapped core.
+type Core struct {
+	zapcore.Core
+	state *state
+	// context holds the fingerprint fields added through With.
+	context []zapcore.Field
+}
+
+// NewCore wraps c so identical entries logged within cfg.Window are collapsed.
+//
+// Parameters:
+//   - c: The core receiving the deduplicated entries
+//   - cfg: Deduplication window and fingerprint
+//
+// Returns:
+//   - The deduplicating core; Sync writes the pending summaries and Close stops
+//     its background flushing
+func NewCore(c zapcore.Core, cfg Config) *Core {
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultWindow
+	}
+	if cfg.MaxKeys <= 0 {
+		cfg.MaxKeys = DefaultMaxKeys
+	}
+
+	fingerprint := make(map[string]struct{}, len(cfg.Fingerprint))
+	for _, k := range cfg.Fingerprint {
+		fingerprint[k] = struct{}{}
+	}
+
+	s := &state{
+		cfg:         cfg,
+		fingerprint: fingerprint,
+		groups:      make(map[string]*group),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	go s.run()
+
+	return &Core{Core: c, state: s}
+}
+
+// With adds the fields to the wrapped core, keeping the fingerprint ones to
+// identify the entries.
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	context := c.context
+	for _, f := range fields {
+		if _, ok := c.state.fingerprint[f.Key]; ok {
+			context = append(context[:len(context):len(context)], f)
+		}
+	}
+
+	return &Core{Core: c.Core.With(fields), state: c.state, context: context}
+}
+
+// Check registers this core for the entry when the wrapped core accepts its
+// level, so repeats can be collapsed in Write.
+func (c *Core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Core.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write writes the first occurrence of an entry within the window and counts
+// its repeats.
+func (c *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	key := c.key(ent, fields)
+	now := time.Now()
+
+	s := c.state
+	s.mu.Lock()
+
+	g, ok := s.groups[key]
+	if ok && now.Sub(g.start) < s.cfg.Window {
+		g.repeats++
+		// The fields are copied, callers such as the async core reuse their
+		// slice once Write returns.
+		g.core, g.ent, g.fields = c.Core, ent, append(g.fields[:0], fields...)
+		s.mu.Unlock()
+
+		return nil
+	}
+
+	var expired *group
+	if ok {
+		expired = g
+		delete(s.groups, key)
+	}
+	if len(s.groups) < s.cfg.MaxKeys {
+		s.groups[key] = &group{start: now, core: c.Core}
+	}
+
+	s.mu.Unlock()
+
+	return errors.Join(expired.summarize(), checked.Write(c.Core, ent, fields))
+}
+
+// Sync writes the pending summaries and syncs the wrapped core.
+func (c *Core) Sync() error {
+	return errors.Join(c.state.flush(time.Time{}), c.Core.Sync())
+}
+
+// Close stops the background flushing, shared with the With children, and
+// writes the pending summaries. Repeats logged after Close are summarized by
+// Sync.
+func (c *Core) Close() error {
+	s := c.state
+	s.closeOnce.Do(func() { close(s.stop) })
+	<-s.done
+
+	return s.flush(time.Time{})
+}
+
+// key identifies an entry by its level, logger name, message and fingerprint fields.
+func (c *Core) key(ent zapcore.Entry, fields []zapcore.Field) string {
+	var b strings.Builder
+
+	b.WriteString(ent.Level.String())
+	b.WriteByte(0)
+	b.WriteString(ent.LoggerName)
+	b.WriteByte(0)
+	b.WriteString(ent.Message)
+
+	if len(c.state.fingerprint) == 0 {
+		return b.String()
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.context {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		if _, ok := c.state.fingerprint[f.Key]; ok {
+			f.AddTo(enc)
+		}
+	}
+
+	for _, k := range c.state.cfg.Fingerprint {
+		if v, ok := enc.Fields[k]; ok {
+			fmt.Fprintf(&b, "\x00%s=%v", k, v)
+		}
+	}
+
+	return b.String()
+}
+
+// run writes the summaries of the elapsed windows until Close.
+func (s *state) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.cfg.Window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			_ = s.flush(now)
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// flush writes the summaries of the groups whose window elapsed at now, or of
+// every group when now is zero.
+func (s *state) flush(now time.Time) error {
+	var expired []*group
+
+	s.mu.Lock()
+	for key, g := range s.groups {
+		if now.IsZero() || now.Sub(g.start) >= s.cfg.Window {
+			expired = append(expired, g)
+			delete(s.groups, key)
+		}
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	for _, g := range expired {
+		errs = append(errs, g.summarize())
+	}
+
+	return errors.Join(errs...)
+}
+
+// summarize writes the last repeat of the group with its repeat count.
+func (g *group) summarize() error {
+	if g == nil || g.repeats == 0 {
+		return nil
+	}
+
+	fields := append(g.fields[:len(g.fields):len(g.fields)], zap.Int(RepeatCountKey, g.repeats))
+
+	return checked.Write(g.core, g.ent, fields)
+}
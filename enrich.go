@@ -0,0 +1,140 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"net"
+	"os"
+	"runtime/debug"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.28.0"
+	"go.uber.org/zap"
+
+	"github.com/goxkit/logging/otlp"
+)
+
+// WithBuildInfo adds the build metadata embedded by the Go toolchain to every
+// entry and to the OTLP resource, so each log line identifies the exact build:
+// the VCS revision, commit time and dirty flag (vcs.revision, vcs.time and
+// vcs.modified), the Go version (go.version, process.runtime.version on the
+// resource) and, when the main module is versioned, service.version. Binaries
+// built without VCS stamping, e.g. with -buildvcs=false, only get the Go
+// version.
+func WithBuildInfo() Option {
+	return func(o *options) {
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+
+		o.fields = append(o.fields, zap.String("go.version", info.GoVersion))
+		o.resource = append(o.resource,
+			semconv.ProcessRuntimeName("go"),
+			semconv.ProcessRuntimeVersion(info.GoVersion),
+		)
+
+		if v := info.Main.Version; v != "" && v != "(devel)" {
+			o.resource = append(o.resource, semconv.ServiceVersion(v))
+		}
+
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision", "vcs.time":
+				o.fields = append(o.fields, zap.String(setting.Key, setting.Value))
+				o.resource = append(o.resource, attribute.String(setting.Key, setting.Value))
+			case "vcs.modified":
+				modified, _ := strconv.ParseBool(setting.Value)
+				o.fields = append(o.fields, zap.Bool(setting.Key, modified))
+				o.resource = append(o.resource, attribute.Bool(setting.Key, modified))
+			}
+		}
+	}
+}
+
+// WithHostInfo adds the hostname, process ID and primary IP address of the
+// host to every entry and to the OTLP resource, under the host.name,
+// process.pid and host.ip semantic convention attributes. The primary IP is the
+// first non-loopback address of the interfaces that are up; it is omitted when
+// there is none.
+func WithHostInfo() Option {
+	return func(o *options) {
+		pid := os.Getpid()
+		o.fields = append(o.fields, zap.Int("process.pid", pid))
+		o.resource = append(o.resource, semconv.ProcessPID(pid))
+
+		if hostname, err := os.Hostname(); err == nil {
+			o.fields = append(o.fields, zap.String("host.name", hostname))
+			o.resource = append(o.resource, semconv.HostName(hostname))
+		}
+
+		if ip := primaryIP(); ip != "" {
+			o.fields = append(o.fields, zap.String("host.ip", ip))
+			o.resource = append(o.resource, semconv.HostIP(ip))
+		}
+	}
+}
+
+// primaryIP returns the first non-loopback address of the interfaces that are
+// up, preferring IPv4, or an empty string.
+func primaryIP() string {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return ""
+	}
+
+	var v6 string
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || ipNet.IP.IsLoopback() || ipNet.IP.IsLinkLocalUnicast() {
+				continue
+			}
+
+			if ip4 := ipNet.IP.To4(); ip4 != nil {
+				return ip4.String()
+			}
+			if v6 == "" {
+				v6 = ipNet.IP.String()
+			}
+		}
+	}
+
+	return v6
+}
+
+// WithContainerID adds the ID of the container the process runs in, detected
+// from the cgroup and mount information of the process, to every entry as
+// container.id, so local outputs collected without an agent can be correlated
+// with container metrics. The OTLP resource always carries it. Nothing is added
+// outside a container.
+func WithContainerID() Option {
+	return func(o *options) {
+		if id := otlp.ContainerID(); id != "" {
+			o.fields = append(o.fields, zap.String("container.id", id))
+		}
+	}
+}
+
+// WithGoroutineID adds the ID of the goroutine logging each entry as a
+// goroutine field, to debug concurrency issues in development where no trace
+// context is available. It is off by default, as reading the ID costs a
+// runtime.Stack call per entry, and Go makes no promise about goroutine IDs
+// beyond their use in stack traces.
+func WithGoroutineID() Option {
+	return func(o *options) {
+		o.goroutineID = true
+	}
+}
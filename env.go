@@ -0,0 +1,166 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/otlp"
+	zapInstance "github.com/goxkit/logging/zap"
+)
+
+const (
+	// ServiceNameEnvKey is the OpenTelemetry variable naming the service.
+	ServiceNameEnvKey = "OTEL_SERVICE_NAME"
+	// AppNameEnvKey names the service when OTEL_SERVICE_NAME is not set.
+	AppNameEnvKey = "APP_NAME"
+	// NamespaceEnvKey is the namespace of the service.
+	NamespaceEnvKey = "NAMESPACE"
+	// EnvironmentEnvKey is the deployment environment, e.g. "production".
+	EnvironmentEnvKey = "GO_ENV"
+	// LevelEnvKey is the global minimum level, e.g. "debug".
+	LevelEnvKey = "LOG_LEVEL"
+	// FormatEnvKey is the format of the local output: "console", "json", "gcp"
+	// or "pretty".
+	FormatEnvKey = zapInstance.FormatEnvKey
+	// EndpointEnvKey is the generic OTLP endpoint.
+	EndpointEnvKey = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	// LogsEndpointEnvKey is the OTLP endpoint of the logs signal, taking
+	// precedence over EndpointEnvKey.
+	LogsEndpointEnvKey = "OTEL_EXPORTER_OTLP_LOGS_ENDPOINT"
+	// HeadersEnvKey are the generic OTLP headers, as key1=value1,key2=value2.
+	HeadersEnvKey = "OTEL_EXPORTER_OTLP_HEADERS"
+	// LogsHeadersEnvKey are the OTLP headers of the logs signal, taking
+	// precedence over HeadersEnvKey.
+	LogsHeadersEnvKey = "OTEL_EXPORTER_OTLP_LOGS_HEADERS"
+	// LogsExporterEnvKey selects the log exporter; "console" writes the
+	// records to the standard output instead of exporting them, see
+	// WithOTLPStdout.
+	LogsExporterEnvKey = "OTEL_LOGS_EXPORTER"
+	// SDKDisabledEnvKey disables the OTLP export when set to "true".
+	SDKDisabledEnvKey = "OTEL_SDK_DISABLED"
+	// OTLPFailFastEnvKey fails the creation of the logger when set to "true"
+	// and the collector is unreachable, see WithOTLPFailFast.
+	OTLPFailFastEnvKey = "LOG_OTLP_FAIL_FAST"
+)
+
+// NewFromEnv creates a logger configured only from environment variables, for
+// CLIs and small services that do not use the goxkit configs builder. The
+// service is named by OTEL_SERVICE_NAME or APP_NAME, NAMESPACE, GO_ENV,
+// LOG_LEVEL and LOG_FORMAT set the identity and the local output, and entries
+// are exported when OTEL_EXPORTER_OTLP_[LOGS_]ENDPOINT is set, using the
+// standard protocol, headers, certificate and resource attribute variables.
+// OTEL_LOGS_EXPORTER=console writes the records to the standard output
+// instead, OTEL_SDK_DISABLED=true keeps the output local, and
+// LOG_OTLP_FAIL_FAST=true fails when the collector is unreachable. LOG_LEVELS
+// and LOG_RATE_LIMITS are honored as with New.
+//
+// Parameters:
+//   - opts: Options applied after those read from the environment
+//
+// Returns:
+//   - A configured Logger implementation
+//   - An error if a variable is invalid or the logger cannot be created
+func NewFromEnv(opts ...Option) (Logger, error) {
+	envOpts, err := optionsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(append(envOpts, opts...)...)
+}
+
+// optionsFromEnv returns the options described by the environment variables.
+func optionsFromEnv() ([]Option, error) {
+	var opts []Option
+
+	if name := envOr(ServiceNameEnvKey, AppNameEnvKey); name != "" {
+		opts = append(opts, WithServiceName(name))
+	}
+	if namespace := os.Getenv(NamespaceEnvKey); namespace != "" {
+		opts = append(opts, WithNamespace(namespace))
+	}
+	if env := os.Getenv(EnvironmentEnvKey); env != "" {
+		opts = append(opts, WithEnvironment(env))
+	}
+
+	if raw := os.Getenv(LevelEnvKey); raw != "" {
+		level, err := zapcore.ParseLevel(raw)
+		if err != nil {
+			return nil, fmt.Errorf("logging: invalid %s: %w", LevelEnvKey, err)
+		}
+		opts = append(opts, WithLevel(level))
+	}
+
+	if raw := os.Getenv(FormatEnvKey); raw != "" {
+		encoder, err := zapInstance.ParseEncoder(raw)
+		if err != nil {
+			return nil, fmt.Errorf("logging: invalid %s: %w", FormatEnvKey, err)
+		}
+		opts = append(opts, WithEncoder(encoder))
+	}
+
+	if sdkDisabled() {
+		return opts, nil
+	}
+
+	if strings.EqualFold(strings.TrimSpace(os.Getenv(LogsExporterEnvKey)), "console") {
+		return append(opts, WithOTLPStdout(nil)), nil
+	}
+
+	endpoint := envOr(LogsEndpointEnvKey, EndpointEnvKey)
+	if endpoint == "" {
+		return opts, nil
+	}
+	opts = append(opts, WithOTLPEndpoint(endpoint))
+
+	if raw := os.Getenv(OTLPFailFastEnvKey); raw != "" {
+		failFast, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("logging: invalid %s: %w", OTLPFailFastEnvKey, err)
+		}
+		if failFast {
+			opts = append(opts, WithOTLPFailFast())
+		}
+	}
+
+	if raw := envOr(LogsHeadersEnvKey, HeadersEnvKey); raw != "" {
+		opts = append(opts, WithOTLPHeaders(otlp.ParseHeaders(raw)))
+	}
+
+	tlsConfig, err := otlp.TLSConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil && strings.HasPrefix(strings.ToLower(endpoint), "https://") {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if tlsConfig != nil {
+		opts = append(opts, WithOTLPTLS(tlsConfig))
+	}
+
+	return opts, nil
+}
+
+// sdkDisabled reports whether OTEL_SDK_DISABLED turns the OpenTelemetry SDK off.
+func sdkDisabled() bool {
+	disabled, _ := strconv.ParseBool(os.Getenv(SDKDisabledEnvKey))
+	return disabled
+}
+
+// envOr returns the value of the first variable, falling back to the second one.
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return os.Getenv(fallback)
+}
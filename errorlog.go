@@ -0,0 +1,67 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"log"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// HTTPServerLoggerName is the name of the logger receiving the errors of
+// http.Server, e.g. LOG_LEVELS="http=error" silences TLS handshake errors.
+const HTTPServerLoggerName = "http"
+
+// serverErrorWriter turns the lines written by http.Server into entries.
+type serverErrorWriter struct {
+	logger *zap.Logger
+}
+
+// NewServerErrorLog returns a *log.Logger for http.Server.ErrorLog, turning the
+// errors of the server into structured entries of a sublogger named "http":
+// handler panics are logged at Error level with their stack, TLS handshake
+// errors and other client-side failures at Warn level, and the remaining
+// messages, such as accept errors, at Error level.
+//
+//	server := &http.Server{Addr: ":8443", Handler: mux, ErrorLog: logging.NewServerErrorLog(logger)}
+//
+// Parameters:
+//   - l: The logger the server errors are routed to
+//
+// Returns:
+//   - A *log.Logger writing into l
+func NewServerErrorLog(l Logger) *log.Logger {
+	z := l.With()
+	if z == nil {
+		z = zap.NewNop()
+	}
+
+	// The caller would always be the log package.
+	z = z.Named(HTTPServerLoggerName).WithOptions(zap.WithCaller(false))
+
+	return log.New(&serverErrorWriter{logger: z}, "", 0)
+}
+
+// Write logs a message of the server.
+func (w *serverErrorWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSpace(string(p))
+	msg = strings.TrimPrefix(msg, "http: ")
+
+	switch {
+	case strings.HasPrefix(msg, "panic serving"):
+		first, stack, _ := strings.Cut(msg, "\n")
+		w.logger.Error(first, zap.String("panic_stack", stack))
+	case strings.HasPrefix(msg, "TLS handshake error"),
+		strings.HasPrefix(msg, "URL query contains semicolon"),
+		strings.HasPrefix(msg, "superfluous response.WriteHeader"),
+		strings.Contains(msg, "response.Write on hijacked connection"):
+		w.logger.Warn(msg)
+	default:
+		w.logger.Error(msg)
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// maxErrorChain bounds the number of causes recorded by ErrorField, guarding
+// against cyclic or runaway unwrap chains.
+const maxErrorChain = 32
+
+type (
+	// errorObject encodes an error with its type, unwrap chain and stack trace.
+	// It implements error and Unwrap, so cores looking for the logged error,
+	// such as Sentry's, find it.
+	errorObject struct {
+		err error
+	}
+
+	// errorChain encodes the causes of an error.
+	errorChain []error
+)
+
+// ErrorField returns an error field under the error key recording, instead of
+// the flat message of zap.Error, an object with the message, the Go type, the
+// unwrap chain (causes, including those of errors.Join) and the stack trace of
+// the deepest error carrying one, such as those created by github.com/pkg/errors.
+//
+// Parameters:
+//   - err: The error to record; a nil err is skipped
+//
+// Returns:
+//   - The error field
+func ErrorField(err error) Field {
+	if err == nil {
+		return zap.Skip()
+	}
+
+	return zap.Object("error", errorObject{err: err})
+}
+
+// WithError returns a child of l adding the error, as recorded by ErrorField,
+// to every entry.
+//
+// Parameters:
+//   - l: The parent logger
+//   - err: The error to record
+//
+// Returns:
+//   - The child Logger, or l itself when err is nil
+func WithError(l Logger, err error) Logger {
+	if err == nil {
+		return l
+	}
+
+	return WithFields(l, ErrorField(err))
+}
+
+// Error returns the message of the error.
+func (e errorObject) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the error.
+func (e errorObject) Unwrap() error {
+	return e.err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (e errorObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("message", e.err.Error())
+	enc.AddString("type", fmt.Sprintf("%T", e.err))
+
+	causes := unwrapChain(e.err)
+	if len(causes) > 0 {
+		if err := enc.AddArray("chain", errorChain(causes)); err != nil {
+			return err
+		}
+	}
+
+	if pcs := deepestStack(e.err, causes); len(pcs) > 0 {
+		enc.AddString("stack", formatStack(pcs))
+	}
+
+	return nil
+}
+
+// MarshalLogArray implements zapcore.ArrayMarshaler.
+func (c errorChain) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, err := range c {
+		if err := enc.AppendObject(zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("message", err.Error())
+			enc.AddString("type", fmt.Sprintf("%T", err))
+			return nil
+		})); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unwrapChain returns the causes of err, depth first, excluding err itself.
+func unwrapChain(err error) []error {
+	var causes []error
+
+	var walk func(error)
+	walk = func(err error) {
+		var next []error
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			next = []error{u.Unwrap()}
+		case interface{ Unwrap() []error }:
+			next = u.Unwrap()
+		}
+
+		for _, cause := range next {
+			if cause == nil || len(causes) >= maxErrorChain {
+				continue
+			}
+			causes = append(causes, cause)
+			walk(cause)
+		}
+	}
+	walk(err)
+
+	return causes
+}
+
+// deepestStack returns the stack trace of the deepest error of the chain of
+// err carrying one.
+func deepestStack(err error, causes []error) []uintptr {
+	for i := len(causes) - 1; i >= 0; i-- {
+		if pcs := errorStack(causes[i]); len(pcs) > 0 {
+			return pcs
+		}
+	}
+
+	return errorStack(err)
+}
+
+// errorStack returns the program counters of the stack trace carried by err
+// through a StackTrace method returning them, as the github.com/pkg/errors
+// errors do.
+func errorStack(err error) []uintptr {
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil
+	}
+
+	trace := method.Call(nil)[0]
+	if trace.Kind() != reflect.Slice || trace.Type().Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	pcs := make([]uintptr, trace.Len())
+	for i := range pcs {
+		pcs[i] = uintptr(trace.Index(i).Uint())
+	}
+
+	return pcs
+}
+
+// formatStack formats the program counters as zap formats the stacks of the
+// entries.
+func formatStack(pcs []uintptr) string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(frame.Function)
+		b.WriteString("\n\t")
+		b.WriteString(frame.File)
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(frame.Line))
+		if !more {
+			break
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Field is a key-value pair added to an entry.
+type Field = zap.Field
+
+// String returns a field with a string value.
+func String(key, value string) Field {
+	return zap.String(key, value)
+}
+
+// Strings returns a field with a slice of strings.
+func Strings(key string, values []string) Field {
+	return zap.Strings(key, values)
+}
+
+// Int returns a field with an int value.
+func Int(key string, value int) Field {
+	return zap.Int(key, value)
+}
+
+// Int64 returns a field with an int64 value.
+func Int64(key string, value int64) Field {
+	return zap.Int64(key, value)
+}
+
+// Uint64 returns a field with an uint64 value.
+func Uint64(key string, value uint64) Field {
+	return zap.Uint64(key, value)
+}
+
+// Float64 returns a field with a float64 value.
+func Float64(key string, value float64) Field {
+	return zap.Float64(key, value)
+}
+
+// Bool returns a field with a bool value.
+func Bool(key string, value bool) Field {
+	return zap.Bool(key, value)
+}
+
+// Duration returns a field with a duration, encoded as configured by the
+// encoder.
+func Duration(key string, value time.Duration) Field {
+	return zap.Duration(key, value)
+}
+
+// Time returns a field with a time, encoded as configured by the encoder.
+func Time(key string, value time.Time) Field {
+	return zap.Time(key, value)
+}
+
+// Stringer returns a field with the String of value, called lazily when the
+// entry is encoded.
+func Stringer(key string, value fmt.Stringer) Field {
+	return zap.Stringer(key, value)
+}
+
+// Err returns an error field under the error key. A nil err is skipped.
+func Err(err error) Field {
+	return zap.Error(err)
+}
+
+// NamedErr returns an error field under key. A nil err is skipped.
+func NamedErr(key string, err error) Field {
+	return zap.NamedError(key, err)
+}
+
+// Any returns a field with value, using the most efficient encoding for its
+// type and falling back to reflection.
+func Any(key string, value any) Field {
+	return zap.Any(key, value)
+}
+
+// Lazy returns a field whose value is computed by fn only when an entry carrying
+// it is actually written, i.e. once it passed the level, sampling and rate
+// limiting checks. fn runs at most once per entry, however many sinks encode
+// the field, so expensive values such as serialized payloads cost nothing on
+// disabled levels:
+//
+//	logger.Debug("request", logging.Lazy(func() logging.Field {
+//		return logging.String("payload", dump(req))
+//	}))
+//
+// Fields added through With are encoded right away, so fn runs when With is
+// called.
+//
+// Parameters:
+//   - fn: Function building the field
+//
+// Returns:
+//   - A field resolved to the result of fn when encoded
+func Lazy(fn func() Field) Field {
+	return zap.Inline(&lazyField{fn: fn})
+}
+
+// lazyField is an inline zapcore.ObjectMarshaler resolving its field on first use.
+type lazyField struct {
+	once  sync.Once
+	fn    func() Field
+	field Field
+}
+
+// MarshalLogObject adds the resolved field to enc.
+func (l *lazyField) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	l.once.Do(func() {
+		l.field = l.fn()
+	})
+	l.field.AddTo(enc)
+
+	return nil
+}
+
+// ContextField returns a field carrying ctx so the OpenTelemetry core can correlate
+// the entry with the span active in ctx. The field is skipped by every other
+// encoder, so it never shows up in the local output.
+//
+// Parameters:
+//   - ctx: Context holding the active span
+//
+// Returns:
+//   - A zap.Field consumed only by the OpenTelemetry core
+func ContextField(ctx context.Context) zap.Field {
+	return zap.Field{Key: "context", Type: zapcore.SkipType, Interface: ctx}
+}
+
+// TraceFields returns the trace_id and span_id fields of the span active in ctx,
+// followed by a ContextField, so the entry is correlated with the trace both in the
+// local output and in the OTLP export. It returns nil when ctx carries no valid span.
+//
+// Parameters:
+//   - ctx: Context holding the active span
+//
+// Returns:
+//   - The trace correlation fields, or nil
+func TraceFields(ctx context.Context) []zap.Field {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return nil
+	}
+
+	return []zap.Field{
+		zap.String("trace_id", spanCtx.TraceID().String()),
+		zap.String("span_id", spanCtx.SpanID().String()),
+		ContextField(ctx),
+	}
+}
+
+// KeyValueFields converts the alternating keys and values of key-value based
+// loggers (logr, go-kit, franz-go) into Zap fields. zap.Field items are kept as
+// is, and a context.Context value is replaced by its TraceFields. A trailing key
+// without value is kept with a nil value.
+//
+// Parameters:
+//   - keyvals: Alternating keys and values
+//
+// Returns:
+//   - The corresponding Zap fields
+func KeyValueFields(keyvals []any) []zap.Field {
+	fields := make([]zap.Field, 0, (len(keyvals)+1)/2)
+
+	for i := 0; i < len(keyvals); {
+		if f, ok := keyvals[i].(zap.Field); ok {
+			fields = append(fields, f)
+			i++
+			continue
+		}
+
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+
+		if i+1 == len(keyvals) {
+			fields = append(fields, zap.Any(key, nil))
+			break
+		}
+
+		switch value := keyvals[i+1].(type) {
+		case context.Context:
+			fields = append(fields, TraceFields(value)...)
+		default:
+			fields = append(fields, zap.Any(key, value))
+		}
+
+		i += 2
+	}
+
+	return fields
+}
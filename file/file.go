@@ -0,0 +1,129 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package file provides a file sink for the logging framework. Entries are written
+// to disk and the file is rotated based on its size and age, keeping a bounded
+// number of (optionally compressed) backups.
+package file
+
+import (
+	"errors"
+
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/goxkit/logging/internal/checked"
+	zapInstance "github.com/goxkit/logging/zap"
+)
+
+// Config describes the file sink and its rotation policy.
+type Config struct {
+	// Path is the file to write logs to. Backups are kept in the same directory.
+	Path string
+	// MaxSizeMB is the maximum size in megabytes of the file before it gets rotated.
+	// Defaults to 100 megabytes.
+	MaxSizeMB int
+	// MaxAgeDays is the maximum number of days to retain old log files.
+	// Zero means files are not removed based on age.
+	MaxAgeDays int
+	// MaxBackups is the maximum number of old log files to retain.
+	// Zero means all backups are retained (subject to MaxAgeDays).
+	MaxBackups int
+	// Compress determines if the rotated log files should be gzip compressed.
+	Compress bool
+	// LocalTime uses the local time in backup file names instead of UTC.
+	LocalTime bool
+	// Encoder selects the file format. Defaults to JSON.
+	Encoder zapInstance.Encoder
+	// EncoderOptions customizes the encoder. Loggers created with
+	// logging.WithFile default to their own encoder options.
+	EncoderOptions zapInstance.EncoderOptions
+	// Level, when set, is the minimum level written to the file by loggers
+	// created with logging.WithFile. Defaults to the levels of the logger.
+	Level zapcore.LevelEnabler
+	// Buffer, when set, buffers the writes to the file. Loggers created with
+	// logging.WithBufferedOutput default to their own buffer.
+	Buffer *zapInstance.Buffer
+}
+
+// core is the file core, closing the file on Close.
+type core struct {
+	zapcore.Core
+	close func() error
+}
+
+// NewWriter creates a zapcore.WriteSyncer that writes to the file described by cfg,
+// rotating it according to the configured policy and buffering the writes when
+// cfg.Buffer is set.
+//
+// Parameters:
+//   - cfg: File sink configuration
+//
+// Returns:
+//   - A WriteSyncer backed by a rotating file
+func NewWriter(cfg *Config) zapcore.WriteSyncer {
+	ws, _ := NewWriteCloser(cfg)
+	return ws
+}
+
+// NewWriteCloser creates the writer of NewWriter along with the function
+// flushing and closing its file, for owners that release the file on shutdown.
+// The file is reopened by the writes made after closing it.
+//
+// Parameters:
+//   - cfg: File sink configuration
+//
+// Returns:
+//   - A WriteSyncer backed by a rotating file
+//   - The function flushing and closing the file
+func NewWriteCloser(cfg *Config) (zapcore.WriteSyncer, func() error) {
+	rotated := &lumberjack.Logger{
+		Filename:   cfg.Path,
+		MaxSize:    cfg.MaxSizeMB,
+		MaxAge:     cfg.MaxAgeDays,
+		MaxBackups: cfg.MaxBackups,
+		LocalTime:  cfg.LocalTime,
+		Compress:   cfg.Compress,
+	}
+	ws := zapcore.AddSync(rotated)
+
+	if cfg.Buffer != nil {
+		buffered := cfg.Buffer.NewWriteSyncer(ws)
+		return buffered, func() error {
+			return errors.Join(buffered.Stop(), rotated.Close())
+		}
+	}
+
+	return ws, rotated.Close
+}
+
+// NewCore creates a zapcore.Core that writes entries at or above level to the
+// rotating file described by cfg.
+//
+// Parameters:
+//   - cfg: File sink configuration
+//   - level: Minimum level written to the file
+//
+// Returns:
+//   - A zapcore.Core ready to be teed into a logger; its Close method flushes
+//     and closes the file
+func NewCore(cfg *Config, level zapcore.LevelEnabler) zapcore.Core {
+	encoder := cfg.Encoder
+	if encoder == "" {
+		encoder = zapInstance.JSONEncoder
+	}
+
+	ws, closeFile := NewWriteCloser(cfg)
+
+	return &core{
+		Core:  checked.NewCore(zapInstance.NewEncoderWithOptions(encoder, cfg.EncoderOptions), ws, level),
+		close: closeFile,
+	}
+}
+
+// Close flushes and closes the file. The file is reopened by the entries
+// written afterwards.
+func (c *core) Close() error {
+	return c.close()
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"runtime"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// FingerprintKey is the key of the fingerprint field added by
+// WithErrorFingerprint.
+const FingerprintKey = "error.fingerprint"
+
+var (
+	// quotedPattern matches the quoted values of error messages.
+	quotedPattern = regexp.MustCompile(`"[^"]*"|'[^']*'|` + "`[^`]*`")
+	// uuidPattern matches UUIDs.
+	uuidPattern = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	// hexPattern matches hexadecimal values, e.g. addresses and hashes.
+	hexPattern = regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b|\b[0-9a-f]*[0-9][0-9a-f]*[a-f][0-9a-f]*\b|\b[0-9a-f]*[a-f][0-9a-f]*[0-9][0-9a-f]*\b`)
+	// numberPattern matches numbers, e.g. IDs, ports and durations.
+	numberPattern = regexp.MustCompile(`\d+(?:\.\d+)?`)
+)
+
+// fingerprintProcessor adds the fingerprint of the first error of the entries.
+type fingerprintProcessor struct{}
+
+// WithErrorFingerprint adds an error.fingerprint field to the entries holding
+// an error, either a zap.Error or an ErrorField, so the backends group the
+// recurring errors without fuzzy matching. See Fingerprint.
+func WithErrorFingerprint() Option {
+	return func(o *options) {
+		o.processors = append(o.processors, fingerprintProcessor{})
+	}
+}
+
+// Fingerprint returns a stable fingerprint of err: a hash of the type of its
+// root cause, its message with the variable parts (quoted values, UUIDs,
+// hexadecimal values and numbers) replaced by placeholders, and the top frame of
+// the stack trace it carries, if any. Occurrences of the same error from the
+// same place share the fingerprint.
+//
+// Parameters:
+//   - err: The error to fingerprint
+//
+// Returns:
+//   - The fingerprint as 16 hexadecimal characters, or an empty string when err is nil
+func Fingerprint(err error) string {
+	return fingerprint(err, "")
+}
+
+// fingerprint returns the fingerprint of err, using frame as the top frame
+// when err carries no stack trace.
+func fingerprint(err error, frame string) string {
+	if err == nil {
+		return ""
+	}
+
+	root := err
+	for next := errors.Unwrap(root); next != nil; next = errors.Unwrap(root) {
+		root = next
+	}
+
+	if pcs := deepestStack(err, unwrapChain(err)); len(pcs) > 0 {
+		top, _ := runtime.CallersFrames(pcs).Next()
+		frame = top.Function
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%T\n%s\n%s", root, normalizeMessage(err.Error()), frame)
+
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// normalizeMessage replaces the variable parts of an error message by
+// placeholders.
+func normalizeMessage(msg string) string {
+	msg = quotedPattern.ReplaceAllString(msg, "<str>")
+	msg = uuidPattern.ReplaceAllString(msg, "<uuid>")
+	msg = hexPattern.ReplaceAllString(msg, "<hex>")
+	return numberPattern.ReplaceAllString(msg, "<num>")
+}
+
+// Process adds the fingerprint of the first error of r, using the caller of the
+// entry as the top frame when the error carries no stack trace.
+func (fingerprintProcessor) Process(r Record) (Record, bool) {
+	for _, f := range r.Fields {
+		var err error
+		switch f.Type {
+		case zapcore.ErrorType:
+			err, _ = f.Interface.(error)
+		case zapcore.ObjectMarshalerType:
+			if e, ok := f.Interface.(errorObject); ok {
+				err = e.err
+			}
+		}
+
+		if err == nil {
+			continue
+		}
+
+		fp := fingerprint(err, r.Entry.Caller.Function)
+		r.Fields = append(r.Fields[:len(r.Fields):len(r.Fields)], zap.String(FingerprintKey, fp))
+		break
+	}
+
+	return r, true
+}
@@ -0,0 +1,355 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package fluentd provides a sink speaking the Fluentd forward protocol, so
+// deployments aggregating logs with Fluentd or Fluent Bit receive entries
+// directly instead of scraping stdout. Entries are sent as msgpack events over
+// TCP, TLS or a Unix socket, optionally waiting for the acknowledgment of the
+// aggregator (at-least-once delivery).
+package fluentd
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+	"github.com/goxkit/logging/internal/pool"
+)
+
+const (
+	// DefaultTag is the tag of the events when Config.Tag is empty.
+	DefaultTag = "app"
+	// DefaultTimeout bounds dialing, each write and each acknowledgment.
+	DefaultTimeout = 5 * time.Second
+
+	// eventTimeExt is the msgpack extension type of the forward protocol
+	// EventTime, carrying nanosecond timestamps.
+	eventTimeExt = 0
+)
+
+// ErrAckMismatch is returned when the aggregator acknowledges another chunk
+// than the one sent.
+var ErrAckMismatch = errors.New("fluentd acknowledged an unexpected chunk")
+
+// Config describes the Fluentd forward sink.
+type Config struct {
+	// Network is "tcp" or "unix". Defaults to "tcp".
+	Network string
+	// Address is the forward input address, e.g. "fluentd:24224" or a socket path.
+	Address string
+	// TLSConfig, when set with the "tcp" network, dials the aggregator over TLS.
+	TLSConfig *tls.Config
+	// Tag routes the events in the aggregator. The logger name, when set, is
+	// appended as a suffix, e.g. "app.http". Defaults to DefaultTag.
+	Tag string
+	// RequireAck waits for the aggregator to acknowledge every event, resending
+	// it once over a new connection when the acknowledgment does not arrive.
+	RequireAck bool
+	// Timeout bounds dialing, each write and each acknowledgment. Defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+}
+
+// conn is the connection to the aggregator, shared by every core derived through With.
+type conn struct {
+	mu  sync.Mutex
+	cfg *Config
+	c   net.Conn
+	// closed rejects the writes once Close was called.
+	closed bool
+}
+
+// core is a zapcore.Core sending forward protocol events to Fluentd.
+type core struct {
+	zapcore.LevelEnabler
+	conn   *conn
+	fields []zapcore.Field
+}
+
+// NewCore connects to the forward input described by cfg and returns a core
+// sending entries at or above level to it.
+//
+// Parameters:
+//   - cfg: Fluentd sink configuration
+//   - level: Minimum level sent to Fluentd
+//
+// Returns:
+//   - A zapcore.Core ready to be teed into a logger; its Close method closes
+//     the connection
+//   - An error if the connection to the aggregator fails
+func NewCore(cfg Config, level zapcore.LevelEnabler) (zapcore.Core, error) {
+	if cfg.Network == "" {
+		cfg.Network = "tcp"
+	}
+	if cfg.Tag == "" {
+		cfg.Tag = DefaultTag
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	c := &conn{cfg: &cfg}
+	if err := c.dial(); err != nil {
+		return nil, err
+	}
+
+	return &core{LevelEnabler: level, conn: c}, nil
+}
+
+// With adds structured context to the core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	merged = append(merged, c.fields...)
+	merged = append(merged, fields...)
+
+	return &core{LevelEnabler: c.LevelEnabler, conn: c.conn, fields: merged}
+}
+
+// Check adds the core to the checked entry when the level is enabled.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write encodes the entry as a forward protocol event and sends it to the
+// aggregator.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	var chunk string
+	if c.conn.cfg.RequireAck {
+		chunk = chunkID()
+	}
+
+	all := pool.Concat(c.fields, fields)
+	msg, err := c.conn.encode(ent, *all, chunk)
+	pool.PutFields(all)
+	if err != nil {
+		return err
+	}
+	defer msg.Free()
+
+	return c.conn.write(msg.Bytes(), chunk)
+}
+
+// Sync is a no-op, events are sent unbuffered.
+func (c *core) Sync() error {
+	return nil
+}
+
+// Close closes the connection to the aggregator, shared with the With
+// children. Entries written afterwards fail with net.ErrClosed.
+func (c *core) Close() error {
+	c.conn.mu.Lock()
+	defer c.conn.mu.Unlock()
+
+	c.conn.closed = true
+	if c.conn.c == nil {
+		return nil
+	}
+
+	err := c.conn.c.Close()
+	c.conn.c = nil
+
+	return err
+}
+
+// encode builds the message mode event of an entry:
+// [tag, time, record] or [tag, time, record, {"chunk": id}], in a pooled
+// buffer freed by the caller.
+func (c *conn) encode(ent zapcore.Entry, fields []zapcore.Field, chunk string) (*buffer.Buffer, error) {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	record := enc.Fields
+	record["message"] = ent.Message
+	record["level"] = ent.Level.String()
+	if ent.LoggerName != "" {
+		record["logger"] = ent.LoggerName
+	}
+	if ent.Caller.Defined {
+		record["caller"] = ent.Caller.TrimmedPath()
+	}
+	if ent.Stack != "" {
+		record["stacktrace"] = ent.Stack
+	}
+	normalize(record)
+
+	tag := c.cfg.Tag
+	if ent.LoggerName != "" {
+		tag += "." + ent.LoggerName
+	}
+
+	buf := pool.GetBuffer()
+	e := msgpack.NewEncoder(buf)
+	e.SetSortMapKeys(true)
+
+	if err := encodeEvent(e, tag, ent.Time, record, chunk); err != nil {
+		buf.Free()
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// encodeEvent encodes the elements of an event.
+func encodeEvent(e *msgpack.Encoder, tag string, t time.Time, record map[string]any, chunk string) error {
+	length := 3
+	if chunk != "" {
+		length = 4
+	}
+
+	if err := e.EncodeArrayLen(length); err != nil {
+		return err
+	}
+	if err := e.EncodeString(tag); err != nil {
+		return err
+	}
+	if err := encodeEventTime(e, t); err != nil {
+		return err
+	}
+	if err := e.Encode(record); err != nil {
+		return err
+	}
+	if chunk != "" {
+		return e.Encode(map[string]string{"chunk": chunk})
+	}
+
+	return nil
+}
+
+// dial opens the connection to the aggregator.
+func (c *conn) dial() error {
+	dialer := &net.Dialer{Timeout: c.cfg.Timeout}
+
+	var (
+		nc  net.Conn
+		err error
+	)
+
+	if c.cfg.TLSConfig != nil && c.cfg.Network == "tcp" {
+		nc, err = tls.DialWithDialer(dialer, "tcp", c.cfg.Address, c.cfg.TLSConfig)
+	} else {
+		nc, err = dialer.Dial(c.cfg.Network, c.cfg.Address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to fluentd: %w", err)
+	}
+
+	c.c = nc
+
+	return nil
+}
+
+// write sends an event, reconnecting once when the connection was lost or the
+// acknowledgment did not arrive.
+func (c *conn) write(msg []byte, chunk string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return net.ErrClosed
+	}
+
+	if c.c != nil {
+		if err := c.send(msg, chunk); err == nil {
+			return nil
+		}
+
+		_ = c.c.Close()
+		c.c = nil
+	}
+
+	if err := c.dial(); err != nil {
+		return err
+	}
+
+	if err := c.send(msg, chunk); err != nil {
+		_ = c.c.Close()
+		c.c = nil
+		return err
+	}
+
+	return nil
+}
+
+// send writes an event and, with a chunk ID, waits for its acknowledgment.
+// Callers hold mu.
+func (c *conn) send(msg []byte, chunk string) error {
+	_ = c.c.SetWriteDeadline(time.Now().Add(c.cfg.Timeout))
+
+	if _, err := c.c.Write(msg); err != nil {
+		return err
+	}
+
+	if chunk == "" {
+		return nil
+	}
+
+	_ = c.c.SetReadDeadline(time.Now().Add(c.cfg.Timeout))
+
+	resp, err := msgpack.NewDecoder(c.c).DecodeMap()
+	if err != nil {
+		return fmt.Errorf("failed to read fluentd ack: %w", err)
+	}
+
+	if ack, _ := resp["ack"].(string); ack != chunk {
+		return ErrAckMismatch
+	}
+
+	return nil
+}
+
+// encodeEventTime encodes t as a forward protocol EventTime: big-endian
+// seconds and nanoseconds in a msgpack extension.
+func encodeEventTime(e *msgpack.Encoder, t time.Time) error {
+	if err := e.EncodeExtHeader(eventTimeExt, 8); err != nil {
+		return err
+	}
+
+	var b [8]byte
+	binary.BigEndian.PutUint32(b[:4], uint32(t.Unix()))
+	binary.BigEndian.PutUint32(b[4:], uint32(t.Nanosecond()))
+
+	_, err := e.Writer().Write(b[:])
+
+	return err
+}
+
+// normalize converts the values msgpack would encode as extensions, unknown
+// to Fluentd, to strings.
+func normalize(record map[string]any) {
+	for key, value := range record {
+		switch v := value.(type) {
+		case time.Time:
+			record[key] = v.Format(time.RFC3339Nano)
+		case time.Duration:
+			record[key] = v.String()
+		case map[string]any:
+			normalize(v)
+		}
+	}
+}
+
+// chunkID returns a random chunk ID identifying an event to acknowledge.
+func chunkID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+
+	return base64.StdEncoding.EncodeToString(b[:])
+}
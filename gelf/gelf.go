@@ -0,0 +1,427 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package gelf provides a Graylog Extended Log Format (GELF 1.1) sink for the
+// logging framework. Entries are sent to Graylog over UDP, with compression and
+// chunking of large messages, or over TCP (optionally TLS), with Zap fields
+// mapped to GELF additional fields.
+package gelf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+	"github.com/goxkit/logging/internal/pool"
+)
+
+// Compression selects how UDP messages are compressed.
+type Compression int
+
+const (
+	// Gzip compresses UDP messages with gzip.
+	Gzip Compression = iota
+	// Zlib compresses UDP messages with zlib.
+	Zlib
+	// NoCompression sends UDP messages uncompressed.
+	NoCompression
+)
+
+const (
+	// DefaultChunkSize is the maximum UDP datagram size, fitting the usual MTU.
+	DefaultChunkSize = 1420
+	// DefaultTimeout bounds dialing and writing to Graylog.
+	DefaultTimeout = 5 * time.Second
+
+	// maxChunks is the maximum number of chunks of a GELF message.
+	maxChunks = 128
+	// chunkHeaderSize is the size of the magic bytes, message ID, sequence
+	// number and sequence count prefixing every chunk.
+	chunkHeaderSize = 12
+)
+
+// ErrMessageTooLarge is returned when a UDP message needs more than 128 chunks.
+var ErrMessageTooLarge = errors.New("gelf message exceeds 128 chunks")
+
+// chunkMagic prefixes every chunk of a chunked GELF message.
+var chunkMagic = []byte{0x1e, 0x0f}
+
+// Config describes the GELF sink.
+type Config struct {
+	// Network is "udp" or "tcp". Defaults to "udp".
+	Network string
+	// Address is the Graylog GELF input address (host:port).
+	Address string
+	// TLSConfig, when set with the "tcp" network, dials Graylog over TLS.
+	TLSConfig *tls.Config
+	// Host is the GELF host field. Defaults to os.Hostname.
+	Host string
+	// Compression of UDP messages. Defaults to Gzip. TCP messages are never compressed.
+	Compression Compression
+	// ChunkSize is the maximum UDP datagram size. Defaults to DefaultChunkSize.
+	ChunkSize int
+	// Timeout bounds dialing and each write. Defaults to DefaultTimeout.
+	Timeout time.Duration
+}
+
+// conn is the connection to Graylog, shared by every core derived through With.
+type conn struct {
+	mu  sync.Mutex
+	cfg *Config
+	c   net.Conn
+	// closed rejects the writes once Close was called.
+	closed bool
+}
+
+// core is a zapcore.Core writing GELF messages to Graylog.
+type core struct {
+	zapcore.LevelEnabler
+	conn   *conn
+	fields []zapcore.Field
+}
+
+// NewCore connects to the Graylog input described by cfg and returns a core
+// writing entries at or above level to it.
+//
+// Parameters:
+//   - cfg: GELF sink configuration
+//   - level: Minimum level sent to Graylog
+//
+// Returns:
+//   - A zapcore.Core ready to be teed into a logger; its Close method closes
+//     the connection
+//   - An error if the connection to Graylog fails
+func NewCore(cfg Config, level zapcore.LevelEnabler) (zapcore.Core, error) {
+	if cfg.Network == "" {
+		cfg.Network = "udp"
+	}
+	if cfg.Host == "" {
+		cfg.Host, _ = os.Hostname()
+	}
+	if cfg.ChunkSize <= chunkHeaderSize {
+		cfg.ChunkSize = DefaultChunkSize
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	c := &conn{cfg: &cfg}
+	if err := c.dial(); err != nil {
+		return nil, err
+	}
+
+	return &core{LevelEnabler: level, conn: c}, nil
+}
+
+// With adds structured context to the core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	merged = append(merged, c.fields...)
+	merged = append(merged, fields...)
+
+	return &core{LevelEnabler: c.LevelEnabler, conn: c.conn, fields: merged}
+}
+
+// Check adds the core to the checked entry when the level is enabled.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write encodes the entry as a GELF message and sends it to Graylog.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	all := pool.Concat(c.fields, fields)
+	msg, err := c.conn.encode(ent, *all)
+	pool.PutFields(all)
+	if err != nil {
+		return err
+	}
+
+	return c.conn.write(msg)
+}
+
+// Sync is a no-op, messages are sent unbuffered.
+func (c *core) Sync() error {
+	return nil
+}
+
+// Close closes the connection to Graylog, shared with the With children.
+// Entries written afterwards fail with net.ErrClosed.
+func (c *core) Close() error {
+	c.conn.mu.Lock()
+	defer c.conn.mu.Unlock()
+
+	c.conn.closed = true
+	if c.conn.c == nil {
+		return nil
+	}
+
+	err := c.conn.c.Close()
+	c.conn.c = nil
+
+	return err
+}
+
+// encode builds the GELF 1.1 JSON payload of an entry.
+func (c *conn) encode(ent zapcore.Entry, fields []zapcore.Field) ([]byte, error) {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	msg := make(map[string]any, len(enc.Fields)+8)
+	flatten("", enc.Fields, msg)
+
+	msg["version"] = "1.1"
+	msg["host"] = c.cfg.Host
+	msg["short_message"] = ent.Message
+	msg["timestamp"] = float64(ent.Time.UnixNano()) / float64(time.Second)
+	msg["level"] = severity(ent.Level)
+
+	if ent.Stack != "" {
+		msg["full_message"] = ent.Message + "\n" + ent.Stack
+	}
+	if ent.LoggerName != "" {
+		msg["_logger"] = ent.LoggerName
+	}
+	if ent.Caller.Defined {
+		msg["_file"] = ent.Caller.File
+		msg["_line"] = ent.Caller.Line
+	}
+
+	return json.Marshal(msg)
+}
+
+// dial opens the connection to Graylog.
+func (c *conn) dial() error {
+	dialer := &net.Dialer{Timeout: c.cfg.Timeout}
+
+	var (
+		nc  net.Conn
+		err error
+	)
+
+	if c.cfg.TLSConfig != nil && c.cfg.Network == "tcp" {
+		nc, err = tls.DialWithDialer(dialer, "tcp", c.cfg.Address, c.cfg.TLSConfig)
+	} else {
+		nc, err = dialer.Dial(c.cfg.Network, c.cfg.Address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to graylog: %w", err)
+	}
+
+	c.c = nc
+
+	return nil
+}
+
+// write sends a message, reconnecting once when the connection was lost.
+func (c *conn) write(msg []byte) error {
+	packets, err := c.packets(msg)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return net.ErrClosed
+	}
+
+	if c.c != nil {
+		if err := c.send(packets); err == nil {
+			return nil
+		}
+
+		_ = c.c.Close()
+		c.c = nil
+	}
+
+	if err := c.dial(); err != nil {
+		return err
+	}
+
+	return c.send(packets)
+}
+
+// send writes the packets of a message. Callers hold mu.
+func (c *conn) send(packets [][]byte) error {
+	_ = c.c.SetWriteDeadline(time.Now().Add(c.cfg.Timeout))
+
+	for _, p := range packets {
+		if _, err := c.c.Write(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// packets frames a message for the transport: null-byte delimited over TCP,
+// compressed and chunked over UDP.
+func (c *conn) packets(msg []byte) ([][]byte, error) {
+	if c.cfg.Network != "udp" {
+		return [][]byte{append(msg, 0)}, nil
+	}
+
+	payload, err := compress(msg, c.cfg.Compression)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) <= c.cfg.ChunkSize {
+		return [][]byte{payload}, nil
+	}
+
+	return chunk(payload, c.cfg.ChunkSize)
+}
+
+// compress applies the configured compression to a UDP message.
+func compress(msg []byte, compression Compression) ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		w   io.WriteCloser
+	)
+
+	switch compression {
+	case NoCompression:
+		return msg, nil
+	case Zlib:
+		w = zlib.NewWriter(&buf)
+	default:
+		w = gzip.NewWriter(&buf)
+	}
+
+	if _, err := w.Write(msg); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// chunk splits a UDP payload into GELF chunks sharing a random message ID.
+func chunk(payload []byte, chunkSize int) ([][]byte, error) {
+	dataSize := chunkSize - chunkHeaderSize
+	count := (len(payload) + dataSize - 1) / dataSize
+	if count > maxChunks {
+		return nil, ErrMessageTooLarge
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		end := min((i+1)*dataSize, len(payload))
+
+		p := make([]byte, 0, chunkHeaderSize+end-i*dataSize)
+		p = append(p, chunkMagic...)
+		p = append(p, id...)
+		p = append(p, byte(i), byte(count))
+		p = append(p, payload[i*dataSize:end]...)
+
+		chunks = append(chunks, p)
+	}
+
+	return chunks, nil
+}
+
+// flatten adds the fields as GELF additional fields. Nested objects are
+// flattened with dotted names and arrays are encoded as JSON strings, since
+// additional fields only hold scalar values.
+func flatten(prefix string, value any, msg map[string]any) {
+	if m, ok := value.(map[string]any); ok {
+		for k, v := range m {
+			name := k
+			if prefix != "" {
+				name = prefix + "." + k
+			}
+			flatten(name, v, msg)
+		}
+		return
+	}
+
+	name := fieldName(prefix)
+	if name == "" {
+		return
+	}
+
+	switch v := value.(type) {
+	case string, bool, nil:
+		msg["_"+name] = v
+	case fmt.Stringer:
+		msg["_"+name] = v.String()
+	case []any:
+		raw, _ := json.Marshal(v)
+		msg["_"+name] = string(raw)
+	default:
+		msg["_"+name] = v
+	}
+}
+
+// fieldName sanitizes an additional field name to the GELF allowed characters
+// (letters, digits, underscores, dashes and dots). The reserved "id" name is
+// renamed to "field_id".
+func fieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '_', r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+
+	if name == "id" {
+		return "field_id"
+	}
+
+	return name
+}
+
+// severity maps a Zap level to its syslog severity, used as the GELF level.
+func severity(level zapcore.Level) int {
+	switch level {
+	case zapcore.DebugLevel:
+		return 7
+	case zapcore.InfoLevel:
+		return 6
+	case zapcore.WarnLevel:
+		return 4
+	case zapcore.ErrorLevel:
+		return 3
+	case zapcore.DPanicLevel:
+		return 2
+	case zapcore.PanicLevel:
+		return 1
+	case zapcore.FatalLevel:
+		return 0
+	default:
+		return 5
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+type (
+	// defaultLogger is the logger used by L and the package-level functions.
+	// skipped logs the same entries with the caller skip of those functions,
+	// and explicit reports whether it was set through SetDefault.
+	defaultLogger struct {
+		logger   Logger
+		skipped  Logger
+		explicit bool
+	}
+)
+
+// std is the package-level default logger, nil until a logger is created or
+// SetDefault is called.
+var std atomic.Pointer[defaultLogger]
+
+// nop is returned by L before any logger is available.
+var nop Logger = newLogger(zap.NewNop(), nil)
+
+// SetDefault sets the logger used by L and the package-level Debug, Info,
+// Warn, Error and Fatal functions, and replaces the Zap global loggers
+// (zap.L and zap.S) with it, so libraries logging through Zap share its
+// outputs. Until SetDefault is called, the last logger created by New or
+// NewLogger is used.
+//
+// Parameters:
+//   - l: The logger to use by default
+func SetDefault(l Logger) {
+	std.Store(newDefaultLogger(l, true))
+
+	if z := l.With(); z != nil {
+		zap.ReplaceGlobals(z)
+	}
+}
+
+// L returns the default logger: the one set by SetDefault or, failing that, the
+// last logger created by New or NewLogger.
+//
+// Returns:
+//   - The default Logger, which discards every entry before a logger is created
+func L() Logger {
+	if d := std.Load(); d != nil {
+		return d.logger
+	}
+
+	return nop
+}
+
+// Debug logs a message at Debug level through the default logger.
+//
+// Parameters:
+//   - msg: The log message
+//   - fields: Structured context of the entry
+func Debug(msg string, fields ...Field) {
+	caller().Debug(msg, fields...)
+}
+
+// Info logs a message at Info level through the default logger.
+//
+// Parameters:
+//   - msg: The log message
+//   - fields: Structured context of the entry
+func Info(msg string, fields ...Field) {
+	caller().Info(msg, fields...)
+}
+
+// Warn logs a message at Warn level through the default logger.
+//
+// Parameters:
+//   - msg: The log message
+//   - fields: Structured context of the entry
+func Warn(msg string, fields ...Field) {
+	caller().Warn(msg, fields...)
+}
+
+// Error logs a message at Error level through the default logger.
+//
+// Parameters:
+//   - msg: The log message
+//   - fields: Structured context of the entry
+func Error(msg string, fields ...Field) {
+	caller().Error(msg, fields...)
+}
+
+// Fatal logs a message at Fatal level through the default logger, then calls
+// os.Exit(1).
+//
+// Parameters:
+//   - msg: The log message
+//   - fields: Structured context of the entry
+func Fatal(msg string, fields ...Field) {
+	caller().Fatal(msg, fields...)
+}
+
+// setDefaultFromRoot makes l the default logger, unless one was set through
+// SetDefault.
+func setDefaultFromRoot(l *logger) {
+	d := newDefaultLogger(l, false)
+
+	for {
+		cur := std.Load()
+		if cur != nil && cur.explicit {
+			return
+		}
+		if std.CompareAndSwap(cur, d) {
+			return
+		}
+	}
+}
+
+// newDefaultLogger wraps l, reporting the caller of the package-level functions
+// rather than the functions themselves when l is built by this package.
+func newDefaultLogger(l Logger, explicit bool) *defaultLogger {
+	skipped := l
+	if ll, ok := l.(*logger); ok {
+		c := *ll
+		c.Logger = ll.Logger.WithOptions(zap.AddCallerSkip(1))
+		skipped = &c
+	}
+
+	return &defaultLogger{logger: l, skipped: skipped, explicit: explicit}
+}
+
+// caller returns the default logger used by the package-level functions.
+func caller() Logger {
+	if d := std.Load(); d != nil {
+		return d.skipped
+	}
+
+	return nop
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	kitlog "github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// goKitPackage prefixes the functions of the go-kit log packages, whose
+// wrappers (log.With, level.Info, level.NewFilter) sit between the call site
+// and the adapter.
+const goKitPackage = "github.com/go-kit/log"
+
+// maxGoKitFrames bounds the go-kit wrapper frames skipped to report the caller.
+const maxGoKitFrames = 4
+
+// goKitLogger implements the go-kit log.Logger interface on top of a zap.Logger.
+type goKitLogger struct {
+	// loggers[n] reports the caller n go-kit frames above the adapter.
+	loggers [maxGoKitFrames + 1]*zap.Logger
+}
+
+// NewGoKitLogger returns a go-kit log.Logger writing into the given logger, so
+// services built on go-kit can keep their call sites while exporting through
+// the Zap/OTLP pipeline.
+//
+// The key-value pairs become Zap fields, with the following conventions:
+//   - The "msg" or "message" key becomes the entry message
+//   - A go-kit level (level.Info(logger).Log(...)) selects the Zap level;
+//     entries without level are written at Info
+//   - A context.Context value adds the trace correlation fields of its span
+//
+// Parameters:
+//   - l: The logger entries are routed to
+//
+// Returns:
+//   - A go-kit log.Logger backed by the logger
+func NewGoKitLogger(l Logger) kitlog.Logger {
+	z := l.With()
+	if z == nil {
+		z = zap.NewNop()
+	}
+
+	g := &goKitLogger{}
+	for n := range g.loggers {
+		// Skip the Log method of the adapter and n go-kit wrappers.
+		g.loggers[n] = z.WithOptions(zap.AddCallerSkip(1 + n))
+	}
+
+	return g
+}
+
+// Log writes the key-value pairs as a single entry.
+func (g *goKitLogger) Log(keyvals ...any) error {
+	lvl := zapcore.InfoLevel
+	msg := ""
+	rest := make([]any, 0, len(keyvals))
+
+	for i := 0; i < len(keyvals); i += 2 {
+		if i+1 == len(keyvals) {
+			rest = append(rest, keyvals[i])
+			break
+		}
+
+		key, value := keyvals[i], keyvals[i+1]
+
+		if key == level.Key() {
+			if v, ok := value.(level.Value); ok {
+				lvl = goKitLevel(v)
+				continue
+			}
+		}
+
+		if (key == "msg" || key == "message") && msg == "" {
+			msg = fmt.Sprint(value)
+			continue
+		}
+
+		rest = append(rest, key, value)
+	}
+
+	if ce := g.loggers[goKitFrames()].Check(lvl, msg); ce != nil {
+		ce.Write(KeyValueFields(rest)...)
+	}
+
+	return nil
+}
+
+// goKitFrames counts the go-kit wrapper frames calling Log.
+func goKitFrames() int {
+	var pcs [maxGoKitFrames]uintptr
+	// Skip runtime.Callers, goKitFrames and Log.
+	n := runtime.Callers(3, pcs[:])
+
+	frames := runtime.CallersFrames(pcs[:n])
+	count := 0
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, goKitPackage) {
+			break
+		}
+
+		count++
+		if !more {
+			break
+		}
+	}
+
+	return count
+}
+
+// goKitLevel maps a go-kit level to a Zap level.
+func goKitLevel(v level.Value) zapcore.Level {
+	switch v {
+	case level.DebugValue():
+		return zapcore.DebugLevel
+	case level.WarnValue():
+		return zapcore.WarnLevel
+	case level.ErrorValue():
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/grpclog"
+)
+
+// GRPCLoggerName is the name of the logger receiving the internal gRPC logs,
+// e.g. LOG_LEVELS="grpc=warn" silences its informational chatter.
+const GRPCLoggerName = "grpc"
+
+// grpcVerbosityEnvKey is the gRPC variable setting the verbosity of V checks.
+const grpcVerbosityEnvKey = "GRPC_GO_LOG_VERBOSITY_LEVEL"
+
+// grpcLogger implements grpclog.LoggerV2 and grpclog.DepthLoggerV2 on top of a
+// zap.Logger.
+type grpcLogger struct {
+	logger    *zap.Logger
+	verbosity int
+}
+
+// RedirectGRPCLogs installs a grpclog.LoggerV2 writing into the given logger, so
+// the internal gRPC client and server logs (connection resets, resolver errors)
+// are structured and exported instead of written to stderr.
+//
+// The entries are written by a sublogger named "grpc", whose level can be set
+// independently through LOG_LEVELS or SetNamedLevel. The GRPC_GO_LOG_VERBOSITY_LEVEL
+// variable keeps controlling the verbose logs. As required by grpclog, it must
+// be called before any gRPC activity.
+//
+// Parameters:
+//   - l: The logger the gRPC logs are routed to
+func RedirectGRPCLogs(l Logger) {
+	z := l.With()
+	if z == nil {
+		z = zap.NewNop()
+	}
+
+	verbosity, _ := strconv.Atoi(os.Getenv(grpcVerbosityEnvKey))
+
+	grpclog.SetLoggerV2(&grpcLogger{logger: z.Named(GRPCLoggerName), verbosity: verbosity})
+}
+
+// Info logs at Info level.
+func (g *grpcLogger) Info(args ...any) {
+	g.write(zapcore.InfoLevel, 0, fmt.Sprint(args...))
+}
+
+// Infoln logs at Info level.
+func (g *grpcLogger) Infoln(args ...any) {
+	g.write(zapcore.InfoLevel, 0, sprintln(args...))
+}
+
+// Infof logs at Info level.
+func (g *grpcLogger) Infof(format string, args ...any) {
+	g.write(zapcore.InfoLevel, 0, fmt.Sprintf(format, args...))
+}
+
+// Warning logs at Warn level.
+func (g *grpcLogger) Warning(args ...any) {
+	g.write(zapcore.WarnLevel, 0, fmt.Sprint(args...))
+}
+
+// Warningln logs at Warn level.
+func (g *grpcLogger) Warningln(args ...any) {
+	g.write(zapcore.WarnLevel, 0, sprintln(args...))
+}
+
+// Warningf logs at Warn level.
+func (g *grpcLogger) Warningf(format string, args ...any) {
+	g.write(zapcore.WarnLevel, 0, fmt.Sprintf(format, args...))
+}
+
+// Error logs at Error level.
+func (g *grpcLogger) Error(args ...any) {
+	g.write(zapcore.ErrorLevel, 0, fmt.Sprint(args...))
+}
+
+// Errorln logs at Error level.
+func (g *grpcLogger) Errorln(args ...any) {
+	g.write(zapcore.ErrorLevel, 0, sprintln(args...))
+}
+
+// Errorf logs at Error level.
+func (g *grpcLogger) Errorf(format string, args ...any) {
+	g.write(zapcore.ErrorLevel, 0, fmt.Sprintf(format, args...))
+}
+
+// Fatal logs at Fatal level and exits.
+func (g *grpcLogger) Fatal(args ...any) {
+	g.write(zapcore.FatalLevel, 0, fmt.Sprint(args...))
+}
+
+// Fatalln logs at Fatal level and exits.
+func (g *grpcLogger) Fatalln(args ...any) {
+	g.write(zapcore.FatalLevel, 0, sprintln(args...))
+}
+
+// Fatalf logs at Fatal level and exits.
+func (g *grpcLogger) Fatalf(format string, args ...any) {
+	g.write(zapcore.FatalLevel, 0, fmt.Sprintf(format, args...))
+}
+
+// V reports whether the verbosity level is enabled.
+func (g *grpcLogger) V(l int) bool {
+	return l <= g.verbosity
+}
+
+// InfoDepth logs at Info level, reporting the caller depth frames above.
+func (g *grpcLogger) InfoDepth(depth int, args ...any) {
+	g.write(zapcore.InfoLevel, depth, sprintln(args...))
+}
+
+// WarningDepth logs at Warn level, reporting the caller depth frames above.
+func (g *grpcLogger) WarningDepth(depth int, args ...any) {
+	g.write(zapcore.WarnLevel, depth, sprintln(args...))
+}
+
+// ErrorDepth logs at Error level, reporting the caller depth frames above.
+func (g *grpcLogger) ErrorDepth(depth int, args ...any) {
+	g.write(zapcore.ErrorLevel, depth, sprintln(args...))
+}
+
+// FatalDepth logs at Fatal level and exits, reporting the caller depth frames above.
+func (g *grpcLogger) FatalDepth(depth int, args ...any) {
+	g.write(zapcore.FatalLevel, depth, sprintln(args...))
+}
+
+// write logs msg. As in grpclog, depth 0 reports the caller of the grpclog
+// function calling the logger, and each unit skips one more frame.
+func (g *grpcLogger) write(lvl zapcore.Level, depth int, msg string) {
+	if !g.logger.Core().Enabled(lvl) {
+		return
+	}
+
+	// Skip write, the logger method and the grpclog function.
+	logger := g.logger.WithOptions(zap.AddCallerSkip(depth + 3))
+
+	if ce := logger.Check(lvl, msg); ce != nil {
+		ce.Write()
+	}
+}
+
+// sprintln formats args with spaces between operands, as the *ln variants of
+// grpclog, without the trailing newline.
+func sprintln(args ...any) string {
+	return strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/goxkit/logging/otlp"
+)
+
+// Healthy reports whether the logs of l are being exported to the collector,
+// as described by otlp.HealthChecker.Healthy: the exporter is available, the
+// gRPC connection is not failing and the last export succeeded. Loggers that do
+// not export logs are always healthy.
+//
+// Parameters:
+//   - ctx: Context bounding the wait for a connecting gRPC connection
+//   - l: The logger to check
+//
+// Returns:
+//   - nil when healthy, or otlp.ErrUnhealthy wrapped with the reason
+func Healthy(ctx context.Context, l Logger) error {
+	ll, ok := l.(*logger)
+	if !ok || ll.provider == nil {
+		return nil
+	}
+
+	h := otlp.HealthCheckerFor(ll.provider)
+	if h == nil {
+		return nil
+	}
+
+	return h.Healthy(ctx)
+}
+
+// HealthHandler returns an http.Handler reporting the health of the log export
+// pipeline of l, for readiness probes that should reflect a broken pipeline: it
+// responds 200 OK when Healthy returns nil and 503 Service Unavailable with the
+// reason otherwise.
+//
+// Parameters:
+//   - l: The logger to check
+//
+// Returns:
+//   - An http.Handler to be mounted on a health route
+func HealthHandler(l Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := Healthy(r.Context(), l); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("ok\n"))
+	})
+}
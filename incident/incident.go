@@ -0,0 +1,269 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package incident provides an alerting hook for the logging framework. It
+// watches the rate of error entries and opens an incident through PagerDuty or
+// Opsgenie when a threshold is crossed within a window, resolving it
+// automatically once the rate recovers.
+package incident
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/goxkit/configs"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+)
+
+const (
+	// DefaultThreshold is the number of entries within the window that opens an incident.
+	DefaultThreshold = 10
+	// DefaultWindow is the window over which entries are counted.
+	DefaultWindow = time.Minute
+	// DefaultTimeout bounds each notifier call.
+	DefaultTimeout = 10 * time.Second
+)
+
+// ErrMissingNotifier is returned when no notifier is configured.
+var ErrMissingNotifier = errors.New("incident notifier is required")
+
+// Incident describes an error burst.
+type Incident struct {
+	// Key identifies the incident across its trigger and resolve calls.
+	Key string
+	// Summary is a one-line description of the burst.
+	Summary string
+	// Service and Environment identify the source of the burst.
+	Service     string
+	Environment string
+	// Count is the number of entries within the window when the incident opened.
+	Count int
+	// Window is the window over which the entries were counted.
+	Window time.Duration
+	// Started is the time the incident opened.
+	Started time.Time
+	// LastMessage and LastLogger describe the entry that crossed the threshold.
+	LastMessage string
+	LastLogger  string
+}
+
+// Notifier opens and resolves incidents on an incident management service.
+type Notifier interface {
+	// Trigger opens the incident.
+	Trigger(ctx context.Context, inc Incident) error
+	// Resolve closes the incident opened with the same key.
+	Resolve(ctx context.Context, inc Incident) error
+}
+
+// Config describes the alerting hook.
+type Config struct {
+	// Notifier opens and resolves the incidents, e.g. a PagerDuty or an Opsgenie.
+	Notifier Notifier
+	// Threshold is the number of entries within Window that opens an incident.
+	// Defaults to DefaultThreshold.
+	Threshold int
+	// Window is the sliding window over which entries are counted. Defaults to DefaultWindow.
+	Window time.Duration
+	// Service and Environment are reported in the incidents.
+	Service     string
+	Environment string
+	// Timeout bounds each notifier call. Defaults to DefaultTimeout.
+	Timeout time.Duration
+}
+
+// notification is a pending notifier call.
+type notification struct {
+	call     func(context.Context, Incident) error
+	incident Incident
+}
+
+// monitor counts the entries and tracks the open incident.
+type monitor struct {
+	cfg *Config
+
+	mu    sync.Mutex
+	times []time.Time // ring of the last Threshold entry times
+	next  int
+	open  *Incident
+
+	// notifications keeps the trigger and resolve calls in order.
+	notifications chan notification
+}
+
+// core is a zapcore.Core feeding entries to the monitor.
+type core struct {
+	zapcore.LevelEnabler
+	monitor *monitor
+}
+
+// NewConfig returns a Config populated from the application configs: the
+// service name and environment.
+//
+// Parameters:
+//   - cfgs: Application configurations
+//   - notifier: Notifier opening and resolving the incidents
+//
+// Returns:
+//   - The alerting hook configuration
+func NewConfig(cfgs *configs.Configs, notifier Notifier) Config {
+	return Config{
+		Notifier:    notifier,
+		Service:     cfgs.AppConfigs.Name,
+		Environment: cfgs.AppConfigs.Environment.String(),
+	}
+}
+
+// NewCore returns a core counting entries at or above level and opening an
+// incident when Threshold of them are logged within Window. The incident is
+// resolved once fewer than Threshold entries fall within the window. The level
+// is typically zapcore.ErrorLevel.
+//
+// Parameters:
+//   - cfg: Alerting hook configuration
+//   - level: Minimum level counted
+//
+// Returns:
+//   - A zapcore.Core ready to be teed into a logger
+//   - ErrMissingNotifier if no notifier is configured
+func NewCore(cfg Config, level zapcore.LevelEnabler) (zapcore.Core, error) {
+	if cfg.Notifier == nil {
+		return nil, ErrMissingNotifier
+	}
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = DefaultThreshold
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultWindow
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	m := &monitor{
+		cfg:           &cfg,
+		times:         make([]time.Time, cfg.Threshold),
+		notifications: make(chan notification, 16),
+	}
+	go m.watch()
+	go m.notify()
+
+	return &core{LevelEnabler: level, monitor: m}, nil
+}
+
+// With returns the core itself: fields do not affect the counting.
+func (c *core) With([]zapcore.Field) zapcore.Core {
+	return c
+}
+
+// Check adds the core to the checked entry when the level is enabled.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write counts the entry, opening an incident when the threshold is crossed.
+func (c *core) Write(ent zapcore.Entry, _ []zapcore.Field) error {
+	c.monitor.record(ent)
+	return nil
+}
+
+// Sync is a no-op: notifications are sent as soon as the state changes.
+func (c *core) Sync() error {
+	return nil
+}
+
+// record adds an entry to the window and triggers an incident on a burst.
+func (m *monitor) record(ent zapcore.Entry) {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.times[m.next] = now
+	m.next = (m.next + 1) % len(m.times)
+
+	if m.open != nil || !m.burst(now) {
+		return
+	}
+
+	m.open = &Incident{
+		Key:         m.cfg.Service + "-" + strconv.FormatInt(now.UnixNano(), 36),
+		Summary:     fmt.Sprintf("%d error logs within %s in %s", m.cfg.Threshold, m.cfg.Window, m.source()),
+		Service:     m.cfg.Service,
+		Environment: m.cfg.Environment,
+		Count:       m.cfg.Threshold,
+		Window:      m.cfg.Window,
+		Started:     now,
+		LastMessage: ent.Message,
+		LastLogger:  ent.LoggerName,
+	}
+
+	m.enqueue(m.cfg.Notifier.Trigger, *m.open)
+}
+
+// burst reports whether Threshold entries fall within the window: the oldest
+// of the last Threshold entries is recent enough. It must be called with mu held.
+func (m *monitor) burst(now time.Time) bool {
+	oldest := m.times[m.next]
+	return !oldest.IsZero() && now.Sub(oldest) < m.cfg.Window
+}
+
+// watch resolves the open incident once the rate recovers.
+func (m *monitor) watch() {
+	ticker := time.NewTicker(max(m.cfg.Window/10, time.Second))
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		m.mu.Lock()
+		if m.open != nil && !m.burst(now) {
+			m.enqueue(m.cfg.Notifier.Resolve, *m.open)
+			m.open = nil
+		}
+		m.mu.Unlock()
+	}
+}
+
+// enqueue schedules a notifier call. It must be called with mu held so that
+// the calls are queued in the order of the state changes.
+func (m *monitor) enqueue(call func(context.Context, Incident) error, inc Incident) {
+	select {
+	case m.notifications <- notification{call: call, incident: inc}:
+	default:
+		otel.Handle(fmt.Errorf("incident notification queue full, dropping %s", inc.Key))
+	}
+}
+
+// notify calls the notifier in order, reporting failures through otel.Handle.
+func (m *monitor) notify() {
+	for n := range m.notifications {
+		ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Timeout)
+		if err := n.call(ctx, n.incident); err != nil {
+			otel.Handle(err)
+		}
+		cancel()
+	}
+}
+
+// source returns the service and environment as "service (environment)".
+func (m *monitor) source() string {
+	source := m.cfg.Service
+	if source == "" {
+		source = "service"
+	}
+	if m.cfg.Environment != "" {
+		source += " (" + m.cfg.Environment + ")"
+	}
+
+	return source
+}
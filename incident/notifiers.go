@@ -0,0 +1,167 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package incident
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// PagerDutyURL is the PagerDuty Events API v2 endpoint.
+	PagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+	// OpsgenieURL is the Opsgenie Alert API endpoint; EU accounts use
+	// "https://api.eu.opsgenie.com/v2/alerts".
+	OpsgenieURL = "https://api.opsgenie.com/v2/alerts"
+)
+
+// PagerDuty opens and resolves incidents through the PagerDuty Events API v2.
+type PagerDuty struct {
+	// RoutingKey is the integration key of the PagerDuty service.
+	RoutingKey string
+	// URL overrides PagerDutyURL.
+	URL string
+	// Client sends the requests. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// Trigger sends a trigger event deduplicated on the incident key.
+func (p *PagerDuty) Trigger(ctx context.Context, inc Incident) error {
+	return p.enqueue(ctx, map[string]any{
+		"routing_key":  p.RoutingKey,
+		"event_action": "trigger",
+		"dedup_key":    inc.Key,
+		"payload": map[string]any{
+			"summary":   inc.Summary,
+			"source":    inc.Service,
+			"severity":  "error",
+			"timestamp": inc.Started.UTC().Format(time.RFC3339),
+			"component": inc.LastLogger,
+			"custom_details": map[string]any{
+				"environment":  inc.Environment,
+				"count":        inc.Count,
+				"window":       inc.Window.String(),
+				"last_message": inc.LastMessage,
+			},
+		},
+	})
+}
+
+// Resolve sends a resolve event for the incident key.
+func (p *PagerDuty) Resolve(ctx context.Context, inc Incident) error {
+	return p.enqueue(ctx, map[string]any{
+		"routing_key":  p.RoutingKey,
+		"event_action": "resolve",
+		"dedup_key":    inc.Key,
+	})
+}
+
+// enqueue posts an event to the Events API.
+func (p *PagerDuty) enqueue(ctx context.Context, event map[string]any) error {
+	endpoint := p.URL
+	if endpoint == "" {
+		endpoint = PagerDutyURL
+	}
+
+	return post(ctx, p.Client, endpoint, nil, event)
+}
+
+// Opsgenie opens and closes alerts through the Opsgenie Alert API.
+type Opsgenie struct {
+	// APIKey is the key of an Opsgenie API integration.
+	APIKey string
+	// URL overrides OpsgenieURL.
+	URL string
+	// Priority is the priority of the alerts, "P1" to "P5". Defaults to "P2".
+	Priority string
+	// Client sends the requests. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// Trigger creates an alert aliased with the incident key.
+func (o *Opsgenie) Trigger(ctx context.Context, inc Incident) error {
+	priority := o.Priority
+	if priority == "" {
+		priority = "P2"
+	}
+
+	return post(ctx, o.Client, o.url(), o.header(), map[string]any{
+		"message":     inc.Summary,
+		"alias":       inc.Key,
+		"description": inc.LastMessage,
+		"source":      inc.Service,
+		"priority":    priority,
+		"details": map[string]string{
+			"environment": inc.Environment,
+			"count":       fmt.Sprint(inc.Count),
+			"window":      inc.Window.String(),
+			"logger":      inc.LastLogger,
+		},
+	})
+}
+
+// Resolve closes the alert aliased with the incident key.
+func (o *Opsgenie) Resolve(ctx context.Context, inc Incident) error {
+	endpoint := o.url() + "/" + url.PathEscape(inc.Key) + "/close?identifierType=alias"
+
+	return post(ctx, o.Client, endpoint, o.header(), map[string]any{
+		"source": inc.Service,
+		"note":   "Error rate recovered",
+	})
+}
+
+func (o *Opsgenie) url() string {
+	if o.URL != "" {
+		return o.URL
+	}
+
+	return OpsgenieURL
+}
+
+func (o *Opsgenie) header() http.Header {
+	return http.Header{"Authorization": {"GenieKey " + o.APIKey}}
+}
+
+// post sends a JSON request and checks the response status.
+func post(ctx context.Context, client *http.Client, endpoint string, header http.Header, payload any) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to notify incident: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("failed to notify incident: %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package batch provides the batching shared by the HTTP-based sinks: entries
+// are buffered and sent by a background goroutine once a batch is full or the
+// flush interval elapses.
+package batch
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultSize is the number of items per batch when Config.Size is zero.
+	DefaultSize = 100
+	// DefaultInterval is the flush interval when Config.Interval is zero.
+	DefaultInterval = 5 * time.Second
+	// pendingBatches bounds the buffered items to this many batches.
+	pendingBatches = 10
+)
+
+// Config describes a Batcher.
+type Config struct {
+	// Size is the maximum number of items per batch. Defaults to DefaultSize.
+	Size int
+	// Interval is the maximum delay before buffered items are sent. Defaults to
+	// DefaultInterval.
+	Interval time.Duration
+	// OnError is called with the errors of the background flushes.
+	OnError func(error)
+}
+
+// Batcher buffers items and hands them in batches to a send function.
+type Batcher[T any] struct {
+	cfg  Config
+	send func([]T) error
+
+	mu      sync.Mutex
+	items   []T
+	sending sync.Mutex
+
+	full    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+	dropped atomic.Uint64
+}
+
+// New creates a Batcher and starts its background flusher.
+//
+// Parameters:
+//   - cfg: Batch size, interval and error callback
+//   - send: Sends one batch; it is never called concurrently
+//
+// Returns:
+//   - The running Batcher; Close stops it
+func New[T any](cfg Config, send func([]T) error) *Batcher[T] {
+	if cfg.Size <= 0 {
+		cfg.Size = DefaultSize
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+
+	b := &Batcher[T]{
+		cfg:  cfg,
+		send: send,
+		full: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	go b.run()
+
+	return b
+}
+
+// Add buffers an item. Items are dropped when the buffer already holds ten
+// batches, which happens when the destination cannot keep up.
+func (b *Batcher[T]) Add(item T) {
+	b.mu.Lock()
+	if len(b.items) >= b.cfg.Size*pendingBatches {
+		b.mu.Unlock()
+		b.dropped.Add(1)
+		return
+	}
+
+	b.items = append(b.items, item)
+	full := len(b.items) >= b.cfg.Size
+	b.mu.Unlock()
+
+	if full {
+		select {
+		case b.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Dropped returns the number of items dropped because the buffer was full.
+func (b *Batcher[T]) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+// Flush sends every buffered item synchronously.
+func (b *Batcher[T]) Flush() error {
+	b.sending.Lock()
+	defer b.sending.Unlock()
+
+	for {
+		b.mu.Lock()
+		n := min(len(b.items), b.cfg.Size)
+		batch := b.items[:n:n]
+		b.items = b.items[n:]
+		b.mu.Unlock()
+
+		if n == 0 {
+			return nil
+		}
+
+		if err := b.send(batch); err != nil {
+			return err
+		}
+	}
+}
+
+// Close stops the background flusher and sends the buffered items.
+func (b *Batcher[T]) Close() error {
+	b.once.Do(func() { close(b.stop) })
+	<-b.done
+
+	return b.Flush()
+}
+
+// run flushes when a batch is full or the interval elapses.
+func (b *Batcher[T]) run() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-b.full:
+		case <-ticker.C:
+		}
+
+		if err := b.Flush(); err != nil && b.cfg.OnError != nil {
+			b.cfg.OnError(err)
+		}
+	}
+}
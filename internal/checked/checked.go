@@ -0,0 +1,148 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package checked writes entries through the Check method of a core, as the
+// wrapping cores do so the levels and sampling of the wrapped outputs apply,
+// while returning the write errors zapcore.CheckedEntry would only report to
+// its ErrorOutput.
+//
+// The cores of this module add themselves to a CheckedEntry with AddCore.
+// While Write checks an entry, AddCore collects them instead, and Write calls
+// their Write method itself, keeping the identity of their errors for
+// errors.Is and errors.As.
+package checked
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// collector is the ErrorOutput of the CheckedEntry of Write, gathering
+	// the cores of this module accepting the entry.
+	collector struct {
+		cores []zapcore.Core
+		// err holds the errors of the other cores, which zapcore.CheckedEntry
+		// only reports as text.
+		err error
+	}
+
+	// ioCore is a core of zapcore.NewCore adding itself with AddCore.
+	ioCore struct {
+		zapcore.Core
+	}
+)
+
+var (
+	// collectors holds the collectors, sparing an allocation per entry.
+	collectors = sync.Pool{New: func() any { return &collector{} }}
+
+	// nopCore starts the CheckedEntry of Write, which carries the collector.
+	nopCore = zapcore.NewNopCore()
+)
+
+// Write hands an entry to the outputs of core that accept it.
+//
+// Parameters:
+//   - core: The wrapped core
+//   - ent: The entry
+//   - fields: The fields of the entry
+//
+// Returns:
+//   - The errors of the outputs, joined
+func Write(core zapcore.Core, ent zapcore.Entry, fields []zapcore.Field) error {
+	c := collectors.Get().(*collector)
+
+	ce := (*zapcore.CheckedEntry)(nil).AddCore(ent, nopCore)
+	ce.ErrorOutput = c
+	ce = core.Check(ent, ce)
+
+	var err error
+	for _, accepted := range c.cores {
+		if writeErr := accepted.Write(ent, fields); writeErr != nil {
+			err = errors.Join(err, writeErr)
+		}
+	}
+
+	// The cores from outside this module were added to ce.
+	ce.Write(fields...)
+	if c.err != nil {
+		err = errors.Join(err, c.err)
+	}
+
+	clear(c.cores)
+	c.cores, c.err = c.cores[:0], nil
+	collectors.Put(c)
+
+	return err
+}
+
+// AddCore adds core to ce, as ce.AddCore does. It is called by the Check
+// methods of the cores of this module, so Write gets their errors.
+//
+// Parameters:
+//   - ce: The CheckedEntry, or nil
+//   - ent: The entry
+//   - core: The core accepting the entry
+//
+// Returns:
+//   - The CheckedEntry the entry is written through
+func AddCore(ce *zapcore.CheckedEntry, ent zapcore.Entry, core zapcore.Core) *zapcore.CheckedEntry {
+	if ce != nil {
+		if c, ok := ce.ErrorOutput.(*collector); ok {
+			c.cores = append(c.cores, core)
+			return ce
+		}
+	}
+
+	return ce.AddCore(ent, core)
+}
+
+// NewCore creates the core of zapcore.NewCore, whose write errors Write
+// returns.
+//
+// Parameters:
+//   - enc: The encoder of the entries
+//   - ws: The output
+//   - enab: The levels written
+//
+// Returns:
+//   - The core
+func NewCore(enc zapcore.Encoder, ws zapcore.WriteSyncer, enab zapcore.LevelEnabler) zapcore.Core {
+	return ioCore{Core: zapcore.NewCore(enc, ws, enab)}
+}
+
+// Level returns the minimum level of the core.
+func (c ioCore) Level() zapcore.Level {
+	return zapcore.LevelOf(c.Core)
+}
+
+// With returns a core carrying the fields.
+func (c ioCore) With(fields []zapcore.Field) zapcore.Core {
+	return ioCore{Core: c.Core.With(fields)}
+}
+
+// Check adds the core when it accepts the level of the entry.
+func (c ioCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return AddCore(ce, ent, c.Core)
+	}
+
+	return ce
+}
+
+// Write records the write error reported by the CheckedEntry of Write for a
+// core from outside this module.
+func (c *collector) Write(p []byte) (int, error) {
+	c.err = errors.Join(c.err, errors.New(strings.TrimSuffix(string(p), "\n")))
+	return len(p), nil
+}
+
+// Sync is a no-op.
+func (c *collector) Sync() error {
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package pool provides the sync.Pool-backed field slices and byte buffers
+// reused by the cores on the write path, so services logging tens of
+// thousands of entries per second do not allocate them for every entry.
+package pool
+
+import (
+	"sync"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// fieldsCapacity is the initial capacity of the pooled field slices.
+	fieldsCapacity = 16
+	// maxFieldsCapacity keeps the slices grown by unusually large entries out
+	// of the pool, so they do not pin memory.
+	maxFieldsCapacity = 1024
+)
+
+var (
+	// fields holds *[]zapcore.Field, pointers avoiding an allocation on Put.
+	fields = sync.Pool{New: func() any {
+		s := make([]zapcore.Field, 0, fieldsCapacity)
+		return &s
+	}}
+
+	// buffers holds the byte buffers, shared with the Zap encoders.
+	buffers = buffer.NewPool()
+)
+
+// GetFields returns an empty field slice from the pool. Callers append to
+// *p and hand it back with PutFields once the fields are no longer used.
+func GetFields() *[]zapcore.Field {
+	return fields.Get().(*[]zapcore.Field)
+}
+
+// PutFields returns a slice obtained from GetFields to the pool, clearing it
+// so the pool does not retain the field values.
+func PutFields(p *[]zapcore.Field) {
+	if p == nil || cap(*p) > maxFieldsCapacity {
+		return
+	}
+
+	clear(*p)
+	*p = (*p)[:0]
+	fields.Put(p)
+}
+
+// Concat returns a pooled slice holding a followed by b.
+func Concat(a, b []zapcore.Field) *[]zapcore.Field {
+	p := GetFields()
+	*p = append(*p, a...)
+	*p = append(*p, b...)
+
+	return p
+}
+
+// GetBuffer returns an empty byte buffer from the pool, returned with its
+// Free method.
+func GetBuffer() *buffer.Buffer {
+	return buffers.Get()
+}
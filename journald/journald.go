@@ -0,0 +1,215 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package journald provides a systemd-journald sink for the logging framework.
+// Entries are sent through the native journal protocol, with Zap fields mapped
+// to journal fields so they can be filtered with journalctl
+// (e.g. journalctl USER_ID=42).
+package journald
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/go-systemd/v22/journal"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+)
+
+// ErrUnavailable is returned when the journald socket cannot be reached.
+var ErrUnavailable = errors.New("journald socket is not available")
+
+// reserved are the journal fields set by the core itself, which entry fields
+// are not allowed to override.
+var reserved = map[string]bool{
+	"MESSAGE":           true,
+	"PRIORITY":          true,
+	"SYSLOG_IDENTIFIER": true,
+	"LOGGER_NAME":       true,
+	"CODE_FILE":         true,
+	"CODE_LINE":         true,
+	"CODE_FUNC":         true,
+	"STACKTRACE":        true,
+}
+
+// send is the journal transport, replaceable for testing.
+var send = journal.Send
+
+// Config describes the journald sink.
+type Config struct {
+	// Identifier is the SYSLOG_IDENTIFIER of the entries. Defaults to the
+	// process name.
+	Identifier string
+	// FieldPrefix is prepended to every field name, e.g. "APP_" turns the
+	// "user_id" field into APP_USER_ID.
+	FieldPrefix string
+}
+
+// core is a zapcore.Core writing entries to the systemd journal.
+type core struct {
+	zapcore.LevelEnabler
+	cfg    *Config
+	fields []zapcore.Field
+}
+
+// NewCore returns a core writing entries at or above level to journald.
+//
+// Parameters:
+//   - cfg: Journald sink configuration
+//   - level: Minimum level sent to the journal
+//
+// Returns:
+//   - A zapcore.Core ready to be teed into a logger
+//   - ErrUnavailable if the process cannot reach the journal
+func NewCore(cfg Config, level zapcore.LevelEnabler) (zapcore.Core, error) {
+	if !journal.Enabled() {
+		return nil, ErrUnavailable
+	}
+
+	if cfg.Identifier == "" {
+		cfg.Identifier = identifier()
+	}
+
+	return &core{LevelEnabler: level, cfg: &cfg}, nil
+}
+
+// With adds structured context to the core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	merged = append(merged, c.fields...)
+	merged = append(merged, fields...)
+
+	return &core{LevelEnabler: c.LevelEnabler, cfg: c.cfg, fields: merged}
+}
+
+// Check adds the core to the checked entry when the level is enabled.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write sends the entry to the journal.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	vars := make(map[string]string, len(enc.Fields)+6)
+	c.flatten("", enc.Fields, vars)
+
+	vars["SYSLOG_IDENTIFIER"] = c.cfg.Identifier
+	if ent.LoggerName != "" {
+		vars["LOGGER_NAME"] = ent.LoggerName
+	}
+	if ent.Caller.Defined {
+		vars["CODE_FILE"] = ent.Caller.File
+		vars["CODE_LINE"] = strconv.Itoa(ent.Caller.Line)
+		if ent.Caller.Function != "" {
+			vars["CODE_FUNC"] = ent.Caller.Function
+		}
+	}
+	if ent.Stack != "" {
+		vars["STACKTRACE"] = ent.Stack
+	}
+
+	return send(ent.Message, priority(ent.Level), vars)
+}
+
+// Sync is a no-op, entries are sent unbuffered.
+func (c *core) Sync() error {
+	return nil
+}
+
+// flatten converts nested values into underscore-joined journal fields.
+func (c *core) flatten(prefix string, value any, vars map[string]string) {
+	if m, ok := value.(map[string]any); ok {
+		for k, v := range m {
+			name := k
+			if prefix != "" {
+				name = prefix + "_" + k
+			}
+			c.flatten(name, v, vars)
+		}
+		return
+	}
+
+	name := fieldName(c.cfg.FieldPrefix + prefix)
+	if name == "" || reserved[name] {
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		vars[name] = v
+	case fmt.Stringer:
+		vars[name] = v.String()
+	default:
+		if raw, err := json.Marshal(v); err == nil {
+			vars[name] = strings.Trim(string(raw), `"`)
+		} else {
+			vars[name] = fmt.Sprint(v)
+		}
+	}
+}
+
+// fieldName converts a Zap field key into a valid journal field name:
+// uppercase letters, digits and underscores, not starting with an underscore.
+func fieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+
+	return strings.TrimLeft(name, "_")
+}
+
+// priority maps a Zap level to its journal priority.
+func priority(level zapcore.Level) journal.Priority {
+	switch level {
+	case zapcore.DebugLevel:
+		return journal.PriDebug
+	case zapcore.InfoLevel:
+		return journal.PriInfo
+	case zapcore.WarnLevel:
+		return journal.PriWarning
+	case zapcore.ErrorLevel:
+		return journal.PriErr
+	case zapcore.DPanicLevel:
+		return journal.PriCrit
+	case zapcore.PanicLevel:
+		return journal.PriAlert
+	case zapcore.FatalLevel:
+		return journal.PriEmerg
+	default:
+		return journal.PriNotice
+	}
+}
+
+// identifier returns the executable name.
+func identifier() string {
+	name := os.Args[0]
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return name
+}
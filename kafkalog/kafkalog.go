@@ -0,0 +1,168 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package kafkalog provides logger adapters for the Kafka clients sarama,
+// kafka-go and franz-go, so broker connection noise and consumer-group
+// rebalances are written as structured entries of the logging pipeline instead
+// of the clients' own stdout formats.
+//
+// Every adapter writes through a sublogger named "kafka", whose verbosity can be
+// tuned on its own, e.g. LOG_LEVELS="kafka=warn".
+package kafkalog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging"
+)
+
+// LoggerName is the name of the sublogger used by the adapters.
+const LoggerName = "kafka"
+
+// PrintLogger implements the Print-style logger interfaces of sarama
+// (sarama.StdLogger) and kafka-go (kafka.Logger), writing every message at a
+// fixed level.
+type PrintLogger struct {
+	logger *zap.Logger
+	level  zapcore.Level
+}
+
+// FranzLogger implements the franz-go kgo.Logger interface.
+type FranzLogger struct {
+	logger *zap.Logger
+}
+
+// NewSaramaLogger returns a sarama.StdLogger writing at Info level, to be set as
+// sarama.Logger (and sarama.DebugLogger for the verbose logs).
+//
+// Parameters:
+//   - l: The logger entries are routed to
+//
+// Returns:
+//   - A logger implementing sarama.StdLogger
+func NewSaramaLogger(l logging.Logger) *PrintLogger {
+	return newPrintLogger(l, zapcore.InfoLevel, "sarama")
+}
+
+// NewKafkaGoLogger returns a kafka.Logger writing at Debug level, to be set as
+// the Logger of the kafka-go Reader and Writer configs.
+//
+// Parameters:
+//   - l: The logger entries are routed to
+//
+// Returns:
+//   - A logger implementing kafka.Logger
+func NewKafkaGoLogger(l logging.Logger) *PrintLogger {
+	return newPrintLogger(l, zapcore.DebugLevel, "kafka-go")
+}
+
+// NewKafkaGoErrorLogger returns a kafka.Logger writing at Error level, to be set
+// as the ErrorLogger of the kafka-go Reader and Writer configs.
+//
+// Parameters:
+//   - l: The logger entries are routed to
+//
+// Returns:
+//   - A logger implementing kafka.Logger
+func NewKafkaGoErrorLogger(l logging.Logger) *PrintLogger {
+	return newPrintLogger(l, zapcore.ErrorLevel, "kafka-go")
+}
+
+// NewFranzLogger returns a kgo.Logger, to be passed with kgo.WithLogger. The
+// franz-go levels map to the Zap levels and the key-value pairs become fields.
+//
+// Parameters:
+//   - l: The logger entries are routed to
+//
+// Returns:
+//   - A logger implementing kgo.Logger
+func NewFranzLogger(l logging.Logger) *FranzLogger {
+	return &FranzLogger{logger: sublogger(l, 1).With(zap.String("kafka.client", "franz-go"))}
+}
+
+// newPrintLogger builds a PrintLogger for the given client.
+func newPrintLogger(l logging.Logger, level zapcore.Level, client string) *PrintLogger {
+	return &PrintLogger{
+		logger: sublogger(l, 2).With(zap.String("kafka.client", client)),
+		level:  level,
+	}
+}
+
+// sublogger returns the "kafka" sublogger, skipping the adapter frames when
+// reporting the caller.
+func sublogger(l logging.Logger, skip int) *zap.Logger {
+	z := l.With()
+	if z == nil {
+		z = zap.NewNop()
+	}
+
+	return z.Named(LoggerName).WithOptions(zap.AddCallerSkip(skip))
+}
+
+// Print logs the operands formatted as fmt.Sprint.
+func (p *PrintLogger) Print(v ...any) {
+	p.write(fmt.Sprint(v...))
+}
+
+// Printf logs the operands formatted as fmt.Sprintf.
+func (p *PrintLogger) Printf(format string, v ...any) {
+	p.write(fmt.Sprintf(format, v...))
+}
+
+// Println logs the operands formatted as fmt.Sprintln.
+func (p *PrintLogger) Println(v ...any) {
+	p.write(fmt.Sprintln(v...))
+}
+
+// write logs msg without the trailing newline added by the clients.
+func (p *PrintLogger) write(msg string) {
+	if ce := p.logger.Check(p.level, strings.TrimRight(msg, "\n")); ce != nil {
+		ce.Write()
+	}
+}
+
+// Level returns the most verbose franz-go level enabled by the logger.
+func (f *FranzLogger) Level() kgo.LogLevel {
+	core := f.logger.Core()
+
+	switch {
+	case core.Enabled(zapcore.DebugLevel):
+		return kgo.LogLevelDebug
+	case core.Enabled(zapcore.InfoLevel):
+		return kgo.LogLevelInfo
+	case core.Enabled(zapcore.WarnLevel):
+		return kgo.LogLevelWarn
+	case core.Enabled(zapcore.ErrorLevel):
+		return kgo.LogLevelError
+	default:
+		return kgo.LogLevelNone
+	}
+}
+
+// Log writes msg at the Zap level matching the franz-go level.
+func (f *FranzLogger) Log(level kgo.LogLevel, msg string, keyvals ...any) {
+	var lvl zapcore.Level
+
+	switch level {
+	case kgo.LogLevelDebug:
+		lvl = zapcore.DebugLevel
+	case kgo.LogLevelInfo:
+		lvl = zapcore.InfoLevel
+	case kgo.LogLevelWarn:
+		lvl = zapcore.WarnLevel
+	case kgo.LogLevelError:
+		lvl = zapcore.ErrorLevel
+	default:
+		return
+	}
+
+	if ce := f.logger.Check(lvl, msg); ce != nil {
+		ce.Write(logging.KeyValueFields(keyvals)...)
+	}
+}
@@ -0,0 +1,371 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package kinesislog provides a sink batching entries to an Amazon Kinesis
+// data stream (PutRecords) or an Amazon Data Firehose delivery stream
+// (PutRecordBatch). Entries are sent as JSON lines, split into requests within
+// the record count and byte limits of the service, and the records the service
+// fails to ingest, e.g. when throttled, are retried.
+package kinesislog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/firehose"
+	firehosetypes "github.com/aws/aws-sdk-go-v2/service/firehose/types"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	kinesistypes "github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/batch"
+	"github.com/goxkit/logging/internal/checked"
+	zapInstance "github.com/goxkit/logging/zap"
+)
+
+const (
+	// DefaultTimeout bounds each request.
+	DefaultTimeout = 10 * time.Second
+
+	// maxAttempts is the number of times a batch is sent while the service
+	// fails to ingest some of its records.
+	maxAttempts = 3
+	// retryDelay is the delay before resending the failed records, multiplied
+	// by the attempt number.
+	retryDelay = 100 * time.Millisecond
+)
+
+var (
+	// ErrMissingConfig is returned when neither a Kinesis nor a Firehose client,
+	// or no stream name, is set.
+	ErrMissingConfig = errors.New("kinesis or firehose client and stream name are required")
+
+	// ErrRecordTooLarge is reported when an entry exceeds the maximum record
+	// size of the service and is dropped.
+	ErrRecordTooLarge = errors.New("log entry exceeds the maximum kinesis record size")
+)
+
+type (
+	// KinesisAPI is the part of *kinesis.Client used by the sink.
+	KinesisAPI interface {
+		PutRecords(ctx context.Context, in *kinesis.PutRecordsInput, opts ...func(*kinesis.Options)) (*kinesis.PutRecordsOutput, error)
+	}
+
+	// FirehoseAPI is the part of *firehose.Client used by the sink.
+	FirehoseAPI interface {
+		PutRecordBatch(ctx context.Context, in *firehose.PutRecordBatchInput, opts ...func(*firehose.Options)) (*firehose.PutRecordBatchOutput, error)
+	}
+
+	// Config describes the Kinesis sink. Exactly one of Kinesis and Firehose
+	// is expected; Kinesis takes precedence.
+	Config struct {
+		// Kinesis, when set, sends the entries to the data stream Stream.
+		Kinesis KinesisAPI
+		// Firehose, when set, sends the entries to the delivery stream Stream.
+		Firehose FirehoseAPI
+		// Stream is the name of the data stream or delivery stream.
+		Stream string
+		// PartitionKeyField names the field whose value is the partition key
+		// of the Kinesis records, e.g. "tenant_id", keeping the entries of a
+		// key ordered in a shard. Entries without it get a random key,
+		// spreading them across shards. Ignored with Firehose.
+		PartitionKeyField string
+		// BatchSize is the maximum number of records per request. Defaults to,
+		// and is capped at, 500, the service limit.
+		BatchSize int
+		// FlushInterval is the maximum delay before buffered records are sent.
+		// Defaults to 5s.
+		FlushInterval time.Duration
+		// Timeout bounds each request. Defaults to DefaultTimeout.
+		Timeout time.Duration
+		// EncoderOptions customizes the JSON encoder of the records.
+		EncoderOptions zapInstance.EncoderOptions
+	}
+
+	// limits are the quotas of a PutRecords or PutRecordBatch request.
+	limits struct {
+		records       int
+		recordBytes   int
+		requestBytes  int
+		countKeyBytes bool
+	}
+
+	// record is an encoded entry and its partition key.
+	record struct {
+		data []byte
+		key  string
+	}
+
+	// core is a zapcore.Core batching entries to Kinesis or Firehose.
+	core struct {
+		zapcore.LevelEnabler
+		cfg     *Config
+		enc     zapcore.Encoder
+		key     string
+		batcher *batch.Batcher[record]
+	}
+)
+
+var (
+	// kinesisLimits are the PutRecords quotas: the partition key counts
+	// towards the record and request sizes.
+	kinesisLimits = limits{records: 500, recordBytes: 1 << 20, requestBytes: 5 << 20, countKeyBytes: true}
+	// firehoseLimits are the PutRecordBatch quotas.
+	firehoseLimits = limits{records: 500, recordBytes: 1000 << 10, requestBytes: 4 << 20}
+)
+
+// NewCore returns a core sending entries at or above level to the Kinesis data
+// stream or Firehose delivery stream of cfg.
+//
+// Parameters:
+//   - cfg: Kinesis sink configuration
+//   - level: Minimum level sent to the stream
+//
+// Returns:
+//   - A zapcore.Core ready to be teed into a logger; Sync sends the buffered records
+//   - ErrMissingConfig if no client or stream name is set
+func NewCore(cfg Config, level zapcore.LevelEnabler) (zapcore.Core, error) {
+	if (cfg.Kinesis == nil && cfg.Firehose == nil) || cfg.Stream == "" {
+		return nil, ErrMissingConfig
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	lim := firehoseLimits
+	if cfg.Kinesis != nil {
+		lim = kinesisLimits
+	}
+	if cfg.BatchSize <= 0 || cfg.BatchSize > lim.records {
+		cfg.BatchSize = lim.records
+	}
+
+	c := &core{
+		LevelEnabler: level,
+		cfg:          &cfg,
+		enc:          zapInstance.NewEncoderWithOptions(zapInstance.JSONEncoder, cfg.EncoderOptions),
+	}
+	c.batcher = batch.New(batch.Config{
+		Size:     cfg.BatchSize,
+		Interval: cfg.FlushInterval,
+		OnError:  otel.Handle,
+	}, func(records []record) error {
+		return c.send(records, lim)
+	})
+
+	return c, nil
+}
+
+// With adds structured context to the core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.enc = c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(clone.enc)
+		if c.isKey(f) {
+			clone.key = fieldValue(f)
+		}
+	}
+
+	return &clone
+}
+
+// Check adds the core to the checked entry when the level is enabled.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write encodes the entry and buffers it for the next request.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	data := append([]byte(nil), buf.Bytes()...)
+	buf.Free()
+
+	c.batcher.Add(record{data: data, key: c.partitionKey(fields)})
+
+	return nil
+}
+
+// Sync sends the buffered records.
+func (c *core) Sync() error {
+	return c.batcher.Flush()
+}
+
+// Close stops the background flusher and sends the buffered entries.
+func (c *core) Close() error {
+	return c.batcher.Close()
+}
+
+// isKey reports whether f is the partition key field.
+func (c *core) isKey(f zapcore.Field) bool {
+	return c.cfg.PartitionKeyField != "" && f.Key == c.cfg.PartitionKeyField
+}
+
+// partitionKey returns the value of the partition key field of the entry, or
+// of its context, or a random key.
+func (c *core) partitionKey(fields []zapcore.Field) string {
+	if c.cfg.Kinesis == nil {
+		return ""
+	}
+
+	if key := c.fieldKey(fields); key != "" {
+		return key
+	}
+	if c.key != "" {
+		return c.key
+	}
+
+	return uuid.NewString()
+}
+
+// fieldKey returns the value of the partition key field among fields.
+func (c *core) fieldKey(fields []zapcore.Field) string {
+	for i := len(fields) - 1; i >= 0; i-- {
+		if c.isKey(fields[i]) {
+			return fieldValue(fields[i])
+		}
+	}
+
+	return ""
+}
+
+// fieldValue returns the string form of the value of f, as a partition key.
+func fieldValue(f zapcore.Field) string {
+	if f.Type == zapcore.StringType {
+		return truncateKey(f.String)
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+
+	return truncateKey(fmt.Sprint(enc.Fields[f.Key]))
+}
+
+// truncateKey cuts a partition key to the 256 characters Kinesis accepts.
+func truncateKey(key string) string {
+	if r := []rune(key); len(r) > 256 {
+		return string(r[:256])
+	}
+
+	return key
+}
+
+// send splits records into requests within the limits, dropping the records
+// too large for the service, and sends them.
+func (c *core) send(records []record, lim limits) error {
+	var (
+		errs    []error
+		request []record
+		size    int
+	)
+
+	for _, r := range records {
+		n := len(r.data)
+		if lim.countKeyBytes {
+			n += len(r.key)
+		}
+
+		if n > lim.recordBytes {
+			errs = append(errs, ErrRecordTooLarge)
+			continue
+		}
+
+		if len(request) == lim.records || size+n > lim.requestBytes {
+			errs = append(errs, c.put(request))
+			request, size = nil, 0
+		}
+
+		request = append(request, r)
+		size += n
+	}
+
+	if len(request) > 0 {
+		errs = append(errs, c.put(request))
+	}
+
+	return errors.Join(errs...)
+}
+
+// put sends a request, resending the records the service failed to ingest.
+func (c *core) put(records []record) error {
+	for attempt := 1; ; attempt++ {
+		failed, err := c.putOnce(records)
+		if err != nil {
+			return err
+		}
+		if len(failed) == 0 {
+			return nil
+		}
+		if attempt == maxAttempts {
+			return fmt.Errorf("kinesis failed to ingest %d log records", len(failed))
+		}
+
+		records = failed
+		time.Sleep(time.Duration(attempt) * retryDelay)
+	}
+}
+
+// putOnce sends a request, returning the records the service failed to ingest.
+func (c *core) putOnce(records []record) ([]record, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+
+	var failed []record
+
+	if c.cfg.Kinesis != nil {
+		entries := make([]kinesistypes.PutRecordsRequestEntry, len(records))
+		for i, r := range records {
+			entries[i] = kinesistypes.PutRecordsRequestEntry{Data: r.data, PartitionKey: aws.String(r.key)}
+		}
+
+		out, err := c.cfg.Kinesis.PutRecords(ctx, &kinesis.PutRecordsInput{
+			StreamName: aws.String(c.cfg.Stream),
+			Records:    entries,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to send logs to kinesis: %w", err)
+		}
+
+		for i, result := range out.Records {
+			if result.ErrorCode != nil && i < len(records) {
+				failed = append(failed, records[i])
+			}
+		}
+
+		return failed, nil
+	}
+
+	entries := make([]firehosetypes.Record, len(records))
+	for i, r := range records {
+		entries[i] = firehosetypes.Record{Data: r.data}
+	}
+
+	out, err := c.cfg.Firehose.PutRecordBatch(ctx, &firehose.PutRecordBatchInput{
+		DeliveryStreamName: aws.String(c.cfg.Stream),
+		Records:            entries,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send logs to firehose: %w", err)
+	}
+
+	for i, result := range out.RequestResponses {
+		if result.ErrorCode != nil && i < len(records) {
+			failed = append(failed, records[i])
+		}
+	}
+
+	return failed, nil
+}
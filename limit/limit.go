@@ -0,0 +1,220 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package limit provides size limits for the logging framework. Messages,
+// field values and whole entries larger than the configured maximums are
+// truncated and marked with a truncated=true field, so a single giant payload
+// cannot blow up the exporter or the collector.
+package limit
+
+import (
+	"encoding/json"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+)
+
+// TruncatedKey is the field added to entries whose message or fields were truncated.
+const TruncatedKey = "truncated"
+
+// Config describes the size limits, in bytes. Zero disables a limit.
+type Config struct {
+	// MaxMessageSize is the maximum length of the entry message.
+	MaxMessageSize int
+	// MaxFieldSize is the maximum size of a field value: the length of strings
+	// and the JSON size of objects, arrays and reflected values.
+	MaxFieldSize int
+	// MaxEntrySize is the maximum size of the message and fields of an entry.
+	// Fields beyond it are truncated or dropped, last first; fields added
+	// through With are not counted.
+	MaxEntrySize int
+}
+
+// DefaultConfig returns a Config with a 8 KiB message, 16 KiB field and 64 KiB
+// entry limits, below the default limits of common collectors.
+func DefaultConfig() Config {
+	return Config{
+		MaxMessageSize: 8 << 10,
+		MaxFieldSize:   16 << 10,
+		MaxEntrySize:   64 << 10,
+	}
+}
+
+// core truncates oversized entries before handing them to the wrapped core.
+type core struct {
+	zapcore.Core
+	cfg Config
+}
+
+// NewCore wraps c so entries exceeding the limits of cfg are truncated before
+// reaching it.
+//
+// Parameters:
+//   - c: The core receiving the truncated entries
+//   - cfg: Size limits
+//
+// Returns:
+//   - A zapcore.Core applying the limits
+func NewCore(c zapcore.Core, cfg Config) zapcore.Core {
+	return &core{Core: c, cfg: cfg}
+}
+
+// With truncates the oversized field values before adding them to the wrapped core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	if c.cfg.MaxFieldSize > 0 {
+		fields, _ = c.limitFields(fields, 0)
+	}
+
+	return &core{Core: c.Core.With(fields), cfg: c.cfg}
+}
+
+// Check registers this core for the entry when the wrapped core accepts its
+// level, so the entry can be truncated in Write.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Core.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write truncates the message and fields and writes the entry to the wrapped core.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	truncated := false
+
+	if c.cfg.MaxMessageSize > 0 && len(ent.Message) > c.cfg.MaxMessageSize {
+		ent.Message = truncate(ent.Message, c.cfg.MaxMessageSize)
+		truncated = true
+	}
+
+	budget := 0
+	if c.cfg.MaxEntrySize > 0 {
+		budget = max(c.cfg.MaxEntrySize-len(ent.Message), 1)
+	}
+
+	if c.cfg.MaxFieldSize > 0 || budget > 0 {
+		var cut bool
+		fields, cut = c.limitFields(fields, budget)
+		truncated = truncated || cut
+	}
+
+	if truncated {
+		fields = append(fields[:len(fields):len(fields)], zap.Bool(TruncatedKey, true))
+	}
+
+	return checked.Write(c.Core, ent, fields)
+}
+
+// limitFields truncates the field values larger than MaxFieldSize and, when
+// budget is positive, truncates or drops the fields exceeding it. The input
+// slice is never modified.
+func (c *core) limitFields(fields []zapcore.Field, budget int) ([]zapcore.Field, bool) {
+	var out []zapcore.Field
+
+	used := 0
+	for i, f := range fields {
+		limit := c.cfg.MaxFieldSize
+		if budget > 0 {
+			remaining := budget - used - len(f.Key)
+			if remaining <= 0 {
+				if out == nil {
+					out = append(make([]zapcore.Field, 0, i), fields[:i]...)
+				}
+				return out, true
+			}
+			if limit <= 0 || remaining < limit {
+				limit = remaining
+			}
+		}
+
+		limited, size, changed := limitField(f, limit)
+		used += len(f.Key) + size
+
+		if changed && out == nil {
+			out = append(make([]zapcore.Field, 0, len(fields)), fields[:i]...)
+		}
+		if out != nil {
+			out = append(out, limited)
+		}
+	}
+
+	if out == nil {
+		return fields, false
+	}
+
+	return out, true
+}
+
+// limitField truncates the value of f to limit bytes. It returns the field,
+// the size of its value and whether it was truncated.
+func limitField(f zapcore.Field, limit int) (zapcore.Field, int, bool) {
+	switch f.Type {
+	case zapcore.StringType:
+		if len(f.String) > limit {
+			return zap.String(f.Key, truncate(f.String, limit)), limit, true
+		}
+		return f, len(f.String), false
+	case zapcore.ByteStringType, zapcore.BinaryType:
+		b, _ := f.Interface.([]byte)
+		if len(b) > limit {
+			if f.Type == zapcore.BinaryType {
+				return zap.Binary(f.Key, b[:limit]), limit, true
+			}
+			return zap.String(f.Key, truncate(string(b), limit)), limit, true
+		}
+		return f, len(b), false
+	case zapcore.StringerType, zapcore.ErrorType:
+		var text string
+		switch v := f.Interface.(type) {
+		case error:
+			text = v.Error()
+		case interface{ String() string }:
+			text = v.String()
+		}
+		if len(text) > limit {
+			return zap.String(f.Key, truncate(text, limit)), limit, true
+		}
+		return f, len(text), false
+	case zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType,
+		zapcore.InlineMarshalerType, zapcore.ReflectType:
+		enc := zapcore.NewMapObjectEncoder()
+		f.AddTo(enc)
+
+		var captured any = enc.Fields
+		if f.Type != zapcore.InlineMarshalerType {
+			captured = enc.Fields[f.Key]
+		}
+
+		raw, err := json.Marshal(captured)
+		if err != nil || len(raw) <= limit {
+			return f, len(raw), false
+		}
+
+		key := f.Key
+		if key == "" {
+			key = "inline"
+		}
+
+		return zap.String(key, truncate(string(raw), limit)), limit, true
+	default:
+		// Numbers, booleans, times and durations have a small bounded size.
+		return f, 8, false
+	}
+}
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n]
+}
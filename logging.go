@@ -13,12 +13,18 @@
 package logging
 
 import (
+	"context"
+	"errors"
+	"net/http"
+
 	"github.com/goxkit/configs"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	"github.com/goxkit/logging/noop"
 	"github.com/goxkit/logging/otlp"
+	zapInstance "github.com/goxkit/logging/zap"
 )
 
 type (
@@ -32,24 +38,68 @@ type (
 
 		// Debug logs a message at Debug level with optional structured fields.
 		// Debug logs are typically used for verbose information useful during development.
-		Debug(msg string, fields ...zap.Field)
+		Debug(msg string, fields ...Field)
 
 		// Info logs a message at Info level with optional structured fields.
 		// Info logs are used for general operational information about the application.
-		Info(msg string, fields ...zap.Field)
+		Info(msg string, fields ...Field)
 
 		// Warn logs a message at Warn level with optional structured fields.
 		// Warn logs indicate potential issues that don't prevent the application from working.
-		Warn(msg string, fields ...zap.Field)
+		Warn(msg string, fields ...Field)
 
 		// Error logs a message at Error level with optional structured fields.
 		// Error logs indicate issues that may require attention but don't stop the application.
-		Error(msg string, fields ...zap.Field)
+		Error(msg string, fields ...Field)
 
 		// Fatal logs a message at Fatal level with optional structured fields,
 		// then calls os.Exit(1), terminating the application immediately.
 		// Use Fatal sparingly, only for errors that truly require immediate shutdown.
-		Fatal(msg string, fields ...zap.Field)
+		Fatal(msg string, fields ...Field)
+
+		// Check returns a CheckedEntry when an entry at lvl would be written,
+		// and nil otherwise. Building the fields after the check keeps the
+		// entries below the minimum level free of allocations on hot paths:
+		//
+		//	if ce := logger.Check(zapcore.DebugLevel, "cache miss"); ce != nil {
+		//		ce.Write(logging.String("key", key))
+		//	}
+		Check(lvl zapcore.Level, msg string) *zapcore.CheckedEntry
+
+		// Enabled reports whether an entry at lvl would be written by at least
+		// one output, to guard work that is not a field, such as computing the
+		// arguments of several entries.
+		Enabled(lvl zapcore.Level) bool
+
+		// Sugar returns a zap.SugaredLogger sharing the outputs, level and
+		// context of the logger, offering printf-style (Infof, Errorf) and
+		// loosely-typed key-value (Infow, Errorw) methods for code migrating
+		// from printf-style loggers.
+		Sugar() *zap.SugaredLogger
+
+		// AtomicLevel returns the minimum level of the logger, which can be changed
+		// at runtime. The change applies to the local output and the OTLP export alike.
+		AtomicLevel() zap.AtomicLevel
+
+		// Sync flushes any buffered log entries, including the OTLP log records
+		// pending in the batch processor.
+		Sync() error
+
+		// Shutdown flushes pending log entries and stops the OTLP export pipeline.
+		// It should be called on graceful shutdown; entries logged afterwards are
+		// no longer exported.
+		Shutdown(ctx context.Context) error
+	}
+
+	// logger is the Logger implementation returned by the constructors of this package.
+	// It wraps the zap.Logger together with the levels controlling its cores,
+	// the OpenTelemetry logger provider, when one is in use, and the options its
+	// pipeline was built from, when created by New.
+	logger struct {
+		*zap.Logger
+		levels   *zapInstance.Levels
+		provider *sdklog.LoggerProvider
+		reload   *reloadState
 	}
 )
 
@@ -59,16 +109,116 @@ type (
 // create a no-operation logger that still provides the Logger interface
 // but with minimal functionality.
 //
+// The startup of the OTLP export is bounded by ctx, or by
+// otlp.DefaultStartupTimeout when ctx has no deadline, so an unreachable
+// collector does not hang the startup.
+//
 // Parameters:
+//   - ctx: Context bounding the startup
 //   - cfgs: Application configurations including logging settings
+//   - opts: Additional zap options, such as zap.Hooks, zap.Fields,
+//     zap.WrapCore or zap.ErrorOutput, for advanced Zap customization
 //
 // Returns:
 //   - A configured Logger implementation
 //   - An error if logger initialization fails
-func NewLogger(cfgs *configs.Configs) (Logger, error) {
+func NewLogger(ctx context.Context, cfgs *configs.Configs, opts ...zap.Option) (Logger, error) {
+	var (
+		z   *zap.Logger
+		err error
+	)
+
 	if cfgs.OTLPConfigs.Enabled {
-		return otlp.Install(cfgs)
+		z, err = otlp.Install(ctx, cfgs, opts...)
+	} else {
+		z, err = noop.Install(cfgs, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	provider, _ := cfgs.LoggerProvider.(*sdklog.LoggerProvider)
+
+	l := newLogger(z, provider)
+	setRoot(l)
+
+	return l, nil
+}
+
+// LevelHandler returns an http.Handler that reports (GET) and changes (PUT) the
+// minimum level of the logger at runtime, allowing operators to switch a running
+// service from INFO to DEBUG without a restart.
+//
+// The handler follows zap's level handler protocol:
+//
+//	curl -X GET localhost:8080/log/level
+//	curl -X PUT localhost:8080/log/level -d '{"level":"debug"}'
+//
+// Parameters:
+//   - l: The logger whose level is exposed
+//
+// Returns:
+//   - An http.Handler to be mounted on an admin route
+func LevelHandler(l Logger) http.Handler {
+	return l.AtomicLevel()
+}
+
+// newLogger wraps a zap.Logger built by this package into a Logger. Loggers not
+// built by the zap subpackage get detached levels initialized to their current
+// level. The provider may be nil when logs are not exported.
+func newLogger(z *zap.Logger, provider *sdklog.LoggerProvider) *logger {
+	levels, ok := zapInstance.LevelsOf(z.Core())
+	if !ok {
+		levels = zapInstance.NewLevels(zap.NewAtomicLevelAt(z.Level()), nil)
+	}
+
+	return &logger{Logger: z, levels: levels, provider: provider}
+}
+
+// AtomicLevel returns the atomic level controlling the logger.
+func (l *logger) AtomicLevel() zap.AtomicLevel {
+	return l.levels.Global()
+}
+
+// Enabled reports whether the cores of the logger write entries at lvl.
+func (l *logger) Enabled(lvl zapcore.Level) bool {
+	return l.Core().Enabled(lvl)
+}
+
+// Sync flushes the Zap cores and forces the export of the OTLP log records
+// pending in the batch processor.
+func (l *logger) Sync() error {
+	err := l.Logger.Sync()
+
+	if l.provider != nil {
+		err = errors.Join(err, l.provider.ForceFlush(context.Background()))
+	}
+
+	return err
+}
+
+// Shutdown flushes the Zap cores, closes the pipeline and its outputs, including
+// the cores added with WithCores, so the background writers and flushers stop
+// and the files and connections are closed, then shuts the OTLP logger provider down, which exports every
+// pending log record before returning or until ctx is done.
+func (l *logger) Shutdown(ctx context.Context) error {
+	// Syncing stdout fails on pipes and terminals; that is not a shutdown failure.
+	_ = l.Logger.Sync()
+
+	var errs []error
+	if err := zapInstance.Close(l.Core()); err != nil && !errors.Is(err, zapInstance.ErrNotReloadable) {
+		errs = append(errs, err)
+	}
+
+	if r := l.reload; r != nil {
+		r.mu.Lock()
+		errs = append(errs, r.outputs.close(), closeCores(r.options.cores))
+		r.mu.Unlock()
+	}
+
+	if l.provider != nil {
+		errs = append(errs, l.provider.Shutdown(ctx))
 	}
 
-	return noop.Install(cfgs)
+	return errors.Join(errs...)
 }
@@ -0,0 +1,85 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"github.com/go-logr/logr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// logrSink implements logr.LogSink on top of a zap.Logger.
+type logrSink struct {
+	logger *zap.Logger
+}
+
+// NewLogr returns a logr.Logger writing into the given logger, for
+// controller-runtime operators and client-go based tools.
+//
+// Verbosity levels follow the zapr convention: V(0) is Info, V(1) is Debug and
+// higher verbosities map to the levels below Debug, so they are only written
+// when the logger level is lowered accordingly. Passing a context.Context as a
+// value (e.g. log.Info("reconciled", "ctx", ctx)) adds the trace correlation
+// fields of its active span.
+//
+// Parameters:
+//   - l: The logger entries are routed to
+//
+// Returns:
+//   - A logr.Logger backed by the logger
+func NewLogr(l Logger) logr.Logger {
+	z := l.With()
+	if z == nil {
+		z = zap.NewNop()
+	}
+
+	// Skip the logr.Logger method calling the sink.
+	return logr.New(&logrSink{logger: z.WithOptions(zap.AddCallerSkip(1))})
+}
+
+// Init applies the call depth requested by logr.
+func (s *logrSink) Init(info logr.RuntimeInfo) {
+	s.logger = s.logger.WithOptions(zap.AddCallerSkip(info.CallDepth))
+}
+
+// Enabled reports whether the verbosity level is enabled.
+func (s *logrSink) Enabled(level int) bool {
+	return s.logger.Core().Enabled(logrLevel(level))
+}
+
+// Info writes an entry at the Zap level matching the verbosity.
+func (s *logrSink) Info(level int, msg string, keysAndValues ...any) {
+	if ce := s.logger.Check(logrLevel(level), msg); ce != nil {
+		ce.Write(KeyValueFields(keysAndValues)...)
+	}
+}
+
+// Error writes an entry at Error level with the error field.
+func (s *logrSink) Error(err error, msg string, keysAndValues ...any) {
+	if ce := s.logger.Check(zapcore.ErrorLevel, msg); ce != nil {
+		ce.Write(append(KeyValueFields(keysAndValues), zap.Error(err))...)
+	}
+}
+
+// WithValues returns a sink adding the key-value pairs to every entry.
+func (s *logrSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return &logrSink{logger: s.logger.With(KeyValueFields(keysAndValues)...)}
+}
+
+// WithName returns a sink appending name to the logger name.
+func (s *logrSink) WithName(name string) logr.LogSink {
+	return &logrSink{logger: s.logger.Named(name)}
+}
+
+// WithCallDepth returns a sink skipping depth additional stack frames when
+// reporting the caller.
+func (s *logrSink) WithCallDepth(depth int) logr.LogSink {
+	return &logrSink{logger: s.logger.WithOptions(zap.AddCallerSkip(depth))}
+}
+
+// logrLevel maps a logr verbosity to a Zap level.
+func logrLevel(level int) zapcore.Level {
+	return zapcore.Level(-level)
+}
@@ -0,0 +1,297 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logtest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging"
+)
+
+// Matcher selects captured entries. Matchers are composed by passing several
+// of them, which must all match, or through AnyOf and Not.
+type Matcher interface {
+	// Match reports whether the entry matches, and otherwise why not.
+	Match(e logging.LoggedEntry) (bool, string)
+	// String describes the matcher in failure messages.
+	String() string
+}
+
+// matcherFunc is a Matcher built from a function and its description.
+type matcherFunc struct {
+	desc  string
+	match func(e logging.LoggedEntry) (bool, string)
+}
+
+// Match calls the function of the matcher.
+func (m matcherFunc) Match(e logging.LoggedEntry) (bool, string) {
+	return m.match(e)
+}
+
+// String returns the description of the matcher.
+func (m matcherFunc) String() string {
+	return m.desc
+}
+
+// Level matches the entries logged at level.
+func Level(level zapcore.Level) Matcher {
+	return matcherFunc{
+		desc: "level " + level.String(),
+		match: func(e logging.LoggedEntry) (bool, string) {
+			if e.Level == level {
+				return true, ""
+			}
+			return false, fmt.Sprintf("level is %s, want %s", e.Level, level)
+		},
+	}
+}
+
+// Msg matches the entries whose message is msg.
+func Msg(msg string) Matcher {
+	return matcherFunc{
+		desc: fmt.Sprintf("message %q", msg),
+		match: func(e logging.LoggedEntry) (bool, string) {
+			if e.Message == msg {
+				return true, ""
+			}
+			return false, fmt.Sprintf("message is %q, want %q", e.Message, msg)
+		},
+	}
+}
+
+// MsgContains matches the entries whose message contains substr.
+func MsgContains(substr string) Matcher {
+	return matcherFunc{
+		desc: fmt.Sprintf("message containing %q", substr),
+		match: func(e logging.LoggedEntry) (bool, string) {
+			if strings.Contains(e.Message, substr) {
+				return true, ""
+			}
+			return false, fmt.Sprintf("message %q does not contain %q", e.Message, substr)
+		},
+	}
+}
+
+// LoggerName matches the entries of the named logger.
+func LoggerName(name string) Matcher {
+	return matcherFunc{
+		desc: fmt.Sprintf("logger %q", name),
+		match: func(e logging.LoggedEntry) (bool, string) {
+			if e.LoggerName == name {
+				return true, ""
+			}
+			return false, fmt.Sprintf("logger is %q, want %q", e.LoggerName, name)
+		},
+	}
+}
+
+// Field matches the entries carrying the field key with value, including the
+// fields added through With. Numbers match whatever their type, e.g.
+// Field("order_id", 42) matches logging.Int64("order_id", 42).
+func Field(key string, value any) Matcher {
+	return matcherFunc{
+		desc: fmt.Sprintf("field %s=%v", key, value),
+		match: func(e logging.LoggedEntry) (bool, string) {
+			got, ok := e.ContextMap()[key]
+			if !ok {
+				return false, fmt.Sprintf("field %s is missing", key)
+			}
+			if equalValues(got, value) {
+				return true, ""
+			}
+			return false, fmt.Sprintf("field %s is %v, want %v", key, got, value)
+		},
+	}
+}
+
+// HasField matches the entries carrying the field key, whatever its value.
+func HasField(key string) Matcher {
+	return matcherFunc{
+		desc: "field " + key,
+		match: func(e logging.LoggedEntry) (bool, string) {
+			if _, ok := e.ContextMap()[key]; ok {
+				return true, ""
+			}
+			return false, fmt.Sprintf("field %s is missing", key)
+		},
+	}
+}
+
+// Not matches the entries m does not match.
+func Not(m Matcher) Matcher {
+	return matcherFunc{
+		desc: "not " + m.String(),
+		match: func(e logging.LoggedEntry) (bool, string) {
+			if ok, _ := m.Match(e); ok {
+				return false, "matches " + m.String()
+			}
+			return true, ""
+		},
+	}
+}
+
+// AnyOf matches the entries matched by at least one of matchers.
+func AnyOf(matchers ...Matcher) Matcher {
+	descs := make([]string, len(matchers))
+	for i, m := range matchers {
+		descs[i] = m.String()
+	}
+
+	return matcherFunc{
+		desc: "any of (" + strings.Join(descs, ", ") + ")",
+		match: func(e logging.LoggedEntry) (bool, string) {
+			reasons := make([]string, 0, len(matchers))
+			for _, m := range matchers {
+				ok, reason := m.Match(e)
+				if ok {
+					return true, ""
+				}
+				reasons = append(reasons, reason)
+			}
+			return false, strings.Join(reasons, " and ")
+		},
+	}
+}
+
+// Matching returns the entries of logs matched by every matcher.
+//
+// Parameters:
+//   - logs: The entries captured by logging.NewObservedLogger
+//   - matchers: The matchers the entries must all match
+//
+// Returns:
+//   - The matching entries, in order
+func Matching(logs *logging.ObservedLogs, matchers ...Matcher) []logging.LoggedEntry {
+	var matched []logging.LoggedEntry
+	for _, e := range logs.All() {
+		if ok, _ := matchAll(e, matchers); ok {
+			matched = append(matched, e)
+		}
+	}
+
+	return matched
+}
+
+// AssertLogged fails t unless an entry of logs is matched by every matcher,
+// reporting for each captured entry why it does not match:
+//
+//	logtest.AssertLogged(t, logs, logtest.Level(zapcore.ErrorLevel), logtest.MsgContains("payment"), logtest.Field("order_id", 42))
+//
+// Parameters:
+//   - t: The test
+//   - logs: The entries captured by logging.NewObservedLogger
+//   - matchers: The matchers an entry must all match
+//
+// Returns:
+//   - Whether a matching entry was logged
+func AssertLogged(t testing.TB, logs *logging.ObservedLogs, matchers ...Matcher) bool {
+	t.Helper()
+
+	entries := logs.All()
+
+	var b strings.Builder
+	for i, e := range entries {
+		ok, reason := matchAll(e, matchers)
+		if ok {
+			return true
+		}
+		fmt.Fprintf(&b, "\n  #%d %s %q: %s", i, e.Level, e.Message, reason)
+	}
+
+	if len(entries) == 0 {
+		b.WriteString("\n  no entry was logged")
+	}
+
+	t.Errorf("logtest: no entry matches %s, captured entries:%s", describe(matchers), b.String())
+
+	return false
+}
+
+// AssertNotLogged fails t when an entry of logs is matched by every matcher.
+//
+// Parameters:
+//   - t: The test
+//   - logs: The entries captured by logging.NewObservedLogger
+//   - matchers: The matchers an entry must all match to fail the test
+//
+// Returns:
+//   - Whether no matching entry was logged
+func AssertNotLogged(t testing.TB, logs *logging.ObservedLogs, matchers ...Matcher) bool {
+	t.Helper()
+
+	matched := Matching(logs, matchers...)
+	if len(matched) == 0 {
+		return true
+	}
+
+	var b strings.Builder
+	for _, e := range matched {
+		fmt.Fprintf(&b, "\n  %s %q %v", e.Level, e.Message, e.ContextMap())
+	}
+
+	t.Errorf("logtest: %d entries match %s:%s", len(matched), describe(matchers), b.String())
+
+	return false
+}
+
+// matchAll reports whether e is matched by every matcher, and otherwise the
+// reasons of the mismatches.
+func matchAll(e logging.LoggedEntry, matchers []Matcher) (bool, string) {
+	var reasons []string
+	for _, m := range matchers {
+		if ok, reason := m.Match(e); !ok {
+			reasons = append(reasons, reason)
+		}
+	}
+
+	return len(reasons) == 0, strings.Join(reasons, "; ")
+}
+
+// describe joins the descriptions of matchers.
+func describe(matchers []Matcher) string {
+	if len(matchers) == 0 {
+		return "anything"
+	}
+
+	descs := make([]string, len(matchers))
+	for i, m := range matchers {
+		descs[i] = m.String()
+	}
+
+	return strings.Join(descs, ", ")
+}
+
+// equalValues compares a captured field value with an expected one, numbers
+// by value whatever their type.
+func equalValues(got, want any) bool {
+	if reflect.DeepEqual(got, want) {
+		return true
+	}
+
+	g, gok := number(got)
+	w, wok := number(want)
+
+	return gok && wok && g == w
+}
+
+// number converts the numeric values to float64.
+func number(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
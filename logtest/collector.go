@@ -0,0 +1,225 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logtest
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goxkit/configs"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+)
+
+type (
+	// Collector is an in-process OTLP/gRPC logs receiver, recording the export
+	// requests it receives, so tests cover the whole export path (resource
+	// attributes, severity mapping, batching) without running a collector.
+	Collector struct {
+		collogspb.UnimplementedLogsServiceServer
+
+		server   *grpc.Server
+		listener net.Listener
+
+		mu       sync.Mutex
+		requests []*collogspb.ExportLogsServiceRequest
+		err      error
+		received chan struct{}
+	}
+
+	// Record is a log record received by the Collector, with the resource and
+	// instrumentation scope it was exported with.
+	Record struct {
+		*logspb.LogRecord
+		Resource *resourcepb.Resource
+		Scope    *commonpb.InstrumentationScope
+	}
+)
+
+// NewCollector starts a Collector listening on a random local port, stopped
+// when the test completes:
+//
+//	collector := logtest.NewCollector(t)
+//	logger, err := logging.NewLogger(ctx, collector.Configs(cfgs))
+//	logger.Info("order created")
+//	_ = logger.Sync()
+//	records := collector.WaitForRecords(t, 1, 5*time.Second)
+//
+// Parameters:
+//   - t: The test
+//
+// Returns:
+//   - The running Collector
+func NewCollector(t testing.TB) *Collector {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("logtest: collector listen: %v", err)
+	}
+
+	c := &Collector{
+		server:   grpc.NewServer(),
+		listener: listener,
+		received: make(chan struct{}, 1),
+	}
+	collogspb.RegisterLogsServiceServer(c.server, c)
+
+	go func() {
+		_ = c.server.Serve(listener)
+	}()
+
+	t.Cleanup(c.Stop)
+
+	return c
+}
+
+// Endpoint returns the host:port address of the Collector.
+func (c *Collector) Endpoint() string {
+	return c.listener.Addr().String()
+}
+
+// Configs points the OTLP settings of cfgs at the Collector, without TLS, for
+// otlp.Install or logging.NewLogger. Missing AppConfigs and OTLPConfigs are
+// created.
+//
+// Parameters:
+//   - cfgs: The configurations to update; nil creates new ones
+//
+// Returns:
+//   - cfgs, pointing at the Collector
+func (c *Collector) Configs(cfgs *configs.Configs) *configs.Configs {
+	if cfgs == nil {
+		cfgs = &configs.Configs{}
+	}
+	if cfgs.AppConfigs == nil {
+		cfgs.AppConfigs = &configs.AppConfigs{}
+	}
+	if cfgs.OTLPConfigs == nil {
+		cfgs.OTLPConfigs = &configs.OTLPConfigs{}
+	}
+
+	cfgs.OTLPConfigs.Enabled = true
+	cfgs.OTLPConfigs.Endpoint = c.Endpoint()
+	cfgs.OTLPConfigs.ExporterTLSEnabled = false
+
+	return cfgs
+}
+
+// Export records an export request, or fails it with the error set by FailWith.
+func (c *Collector) Export(_ context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	c.requests = append(c.requests, req)
+
+	select {
+	case c.received <- struct{}{}:
+	default:
+	}
+
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+// FailWith fails the next export requests with err, e.g. a status.Error with
+// codes.Unavailable to exercise retries, until it is called with nil.
+func (c *Collector) FailWith(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.err = err
+}
+
+// Requests returns the export requests received so far.
+func (c *Collector) Requests() []*collogspb.ExportLogsServiceRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]*collogspb.ExportLogsServiceRequest(nil), c.requests...)
+}
+
+// Records returns the log records received so far, in order.
+func (c *Collector) Records() []Record {
+	var records []Record
+	for _, req := range c.Requests() {
+		for _, rl := range req.GetResourceLogs() {
+			for _, sl := range rl.GetScopeLogs() {
+				for _, lr := range sl.GetLogRecords() {
+					records = append(records, Record{LogRecord: lr, Resource: rl.GetResource(), Scope: sl.GetScope()})
+				}
+			}
+		}
+	}
+
+	return records
+}
+
+// WaitForRecords waits until at least n log records were received, failing the
+// test after timeout. Flush the logger first, with logging.Logger.Sync or the
+// ForceFlush method of the provider, the records are otherwise exported at the
+// next batch interval.
+//
+// Parameters:
+//   - t: The test
+//   - n: The number of records to wait for
+//   - timeout: The maximum wait
+//
+// Returns:
+//   - The records received
+func (c *Collector) WaitForRecords(t testing.TB, n int, timeout time.Duration) []Record {
+	t.Helper()
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		if records := c.Records(); len(records) >= n {
+			return records
+		}
+
+		select {
+		case <-c.received:
+		case <-deadline.C:
+			t.Fatalf("logtest: received %d log records, want %d", len(c.Records()), n)
+			return nil
+		}
+	}
+}
+
+// Stop stops the Collector, closing the connections of the exporters.
+func (c *Collector) Stop() {
+	c.server.Stop()
+}
+
+// Attribute returns the value of the attribute key of the record.
+func (r Record) Attribute(key string) (*commonpb.AnyValue, bool) {
+	return findAttribute(r.GetAttributes(), key)
+}
+
+// ResourceAttribute returns the value of the resource attribute key of the record.
+func (r Record) ResourceAttribute(key string) (*commonpb.AnyValue, bool) {
+	return findAttribute(r.Resource.GetAttributes(), key)
+}
+
+// findAttribute returns the value of key among attrs.
+func findAttribute(attrs []*commonpb.KeyValue, key string) (*commonpb.AnyValue, bool) {
+	for _, kv := range attrs {
+		if kv.GetKey() == key {
+			return kv.GetValue(), true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package logtest provides test helpers. AssertLogged checks the entries
+// captured by an observed logger with composable matchers. Collector is an
+// in-process OTLP/gRPC receiver exercising the whole export path. AssertGolden
+// treats the emitted
+// entries as a contract: it compares the entries captured by an observed logger
+// with a golden file, after normalizing their volatile values, and rewrites
+// the file when the tests run with LOGTEST_UPDATE set, or with the
+// -logtest.update flag in the packages importing logtest:
+//
+//	func TestCreateOrder(t *testing.T) {
+//		logger, logs := logging.NewObservedLogger()
+//		NewOrderService(logger).Create(ctx, order)
+//		logtest.AssertGolden(t, logs, "testdata/create_order.golden")
+//	}
+//
+//	LOGTEST_UPDATE=1 go test ./...
+//	go test ./orders -logtest.update
+//
+// The flag is namespaced so it does not clash with the -update flag of other
+// golden file helpers; the variable also works with ./..., whose packages not
+// importing logtest would reject the flag.
+package logtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging"
+)
+
+// Placeholders replacing the volatile values.
+const (
+	TimePlaceholder     = "<time>"
+	DurationPlaceholder = "<duration>"
+	IDPlaceholder       = "<id>"
+)
+
+// UpdateEnvKey is the variable rewriting the golden files when set to a
+// non-empty value.
+const UpdateEnvKey = "LOGTEST_UPDATE"
+
+// update rewrites the golden files instead of comparing them.
+var update = flag.Bool("logtest.update", false, "rewrite the golden files of logtest.AssertGolden")
+
+// defaultIDFields are the fields normalized as IDs by default.
+var defaultIDFields = []string{"trace_id", "span_id", "request_id", "dd.trace_id", "dd.span_id"}
+
+// idPattern matches UUIDs and the hexadecimal trace and span IDs in string
+// values.
+var idPattern = regexp.MustCompile(`(?i)\b(?:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|[0-9a-f]{32}|[0-9a-f]{16})\b`)
+
+type (
+	// Option configures AssertGolden.
+	Option func(*options)
+
+	// options holds the normalization settings.
+	options struct {
+		volatile map[string]string
+	}
+)
+
+// WithVolatileFields replaces the values of the given fields with "<key>",
+// e.g. for user IDs generated by the test.
+func WithVolatileFields(keys ...string) Option {
+	return func(o *options) {
+		for _, k := range keys {
+			o.volatile[k] = "<" + k + ">"
+		}
+	}
+}
+
+// Update reports whether the tests run with LOGTEST_UPDATE or -logtest.update,
+// for helpers of the test package maintaining their own golden files.
+func Update() bool {
+	return *update || os.Getenv(UpdateEnvKey) != ""
+}
+
+// AssertGolden compares the entries of logs with the golden file at path,
+// failing t with a diff when they differ. Entries are rendered one JSON object
+// per line with their level, logger name, message and fields, sorted by key.
+// Entry timestamps are left out, time and duration fields, the trace, span and
+// request IDs, and the UUIDs and hexadecimal IDs found in string values are
+// replaced by placeholders. When Update reports true, the file is rewritten
+// instead.
+//
+// Parameters:
+//   - t: The test
+//   - logs: The entries captured by logging.NewObservedLogger
+//   - path: The golden file, conventionally under testdata
+//   - opts: Options customizing the normalization
+func AssertGolden(t testing.TB, logs *logging.ObservedLogs, path string, opts ...Option) {
+	t.Helper()
+
+	got, err := Render(logs.All(), opts...)
+	if err != nil {
+		t.Fatalf("logtest: render entries: %v", err)
+	}
+
+	if Update() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("logtest: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("logtest: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("logtest: %v (run the tests with LOGTEST_UPDATE=1 to create it)", err)
+	}
+
+	if !bytes.Equal(want, got) {
+		t.Errorf("logtest: entries differ from %s (run the tests with LOGTEST_UPDATE=1 to accept them):\n%s", path, diff(string(want), string(got)))
+	}
+}
+
+// Render renders entries as normalized JSON lines, as stored in golden files.
+//
+// Parameters:
+//   - entries: The captured entries
+//   - opts: Options customizing the normalization
+//
+// Returns:
+//   - The rendered entries
+//   - An error if a field cannot be marshaled
+func Render(entries []logging.LoggedEntry, opts ...Option) ([]byte, error) {
+	o := &options{volatile: map[string]string{}}
+	for _, k := range defaultIDFields {
+		o.volatile[k] = IDPlaceholder
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	for _, e := range entries {
+		fields := zapcore.NewMapObjectEncoder()
+		for _, f := range e.Context {
+			switch f.Type {
+			case zapcore.TimeType, zapcore.TimeFullType:
+				fields.AddString(f.Key, TimePlaceholder)
+			case zapcore.DurationType:
+				fields.AddString(f.Key, DurationPlaceholder)
+			default:
+				f.AddTo(fields)
+			}
+		}
+
+		for key, value := range fields.Fields {
+			if placeholder, ok := o.volatile[key]; ok {
+				fields.Fields[key] = placeholder
+				continue
+			}
+			fields.Fields[key] = normalize(value)
+		}
+
+		line := map[string]any{"level": e.Level.String(), "msg": e.Message}
+		if e.LoggerName != "" {
+			line["logger"] = e.LoggerName
+		}
+		if len(fields.Fields) > 0 {
+			line["fields"] = fields.Fields
+		}
+
+		// encoding/json sorts the map keys, keeping the lines stable.
+		if err := enc.Encode(line); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// normalize replaces the volatile values nested in value.
+func normalize(value any) any {
+	switch v := value.(type) {
+	case string:
+		return idPattern.ReplaceAllString(v, IDPlaceholder)
+	case map[string]any:
+		for k, nested := range v {
+			v[k] = normalize(nested)
+		}
+		return v
+	case []any:
+		for i, nested := range v {
+			v[i] = normalize(nested)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// diff renders the lines of want and got differing, prefixed with - and +.
+func diff(want, got string) string {
+	wl := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	gl := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	var b strings.Builder
+	for i := 0; i < max(len(wl), len(gl)); i++ {
+		switch {
+		case i >= len(wl):
+			fmt.Fprintf(&b, "line %d:\n+ %s\n", i+1, gl[i])
+		case i >= len(gl):
+			fmt.Fprintf(&b, "line %d:\n- %s\n", i+1, wl[i])
+		case wl[i] != gl[i]:
+			fmt.Fprintf(&b, "line %d:\n- %s\n+ %s\n", i+1, wl[i], gl[i])
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package chilog adapts the httplog middlewares to chi routers, logging the
+// route patterns chi matches, e.g. "/orders/{id}", instead of raw paths.
+package chilog
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/goxkit/logging"
+	"github.com/goxkit/logging/middleware/httplog"
+)
+
+// RoutePattern returns the chi route pattern matching r, for
+// httplog.WithRoutePattern. Middlewares run before chi routes the request, so
+// the pattern is resolved by matching the request against the routes of the
+// router serving it.
+//
+// Parameters:
+//   - r: The request served by a chi router
+//
+// Returns:
+//   - The route pattern, or an empty string outside chi or without match
+func RoutePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || rctx.Routes == nil {
+		return ""
+	}
+
+	match := chi.NewRouteContext()
+	if !rctx.Routes.Match(match, r.Method, r.URL.Path) {
+		return ""
+	}
+
+	return match.RoutePattern()
+}
+
+// RequestLogger creates a chi middleware building a child of logger per
+// request, carrying its request ID, chi route pattern, client IP and user
+// agent, and storing it in the request context for logging.FromContext:
+//
+//	router := chi.NewRouter()
+//	router.Use(chilog.RequestLogger(logger))
+//
+// Parameters:
+//   - logger: The logger the request-scoped loggers derive from
+//   - opts: Options of httplog.RequestLogger
+//
+// Returns:
+//   - A middleware wrapping an http.Handler
+func RequestLogger(logger logging.Logger, opts ...httplog.Option) func(http.Handler) http.Handler {
+	return httplog.RequestLogger(logger, append([]httplog.Option{httplog.WithRoutePattern(RoutePattern)}, opts...)...)
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package echolog provides access-logging and recovery middlewares for Echo
+// logging through the goxkit Logger. The fields follow the schema of the
+// httplog middlewares, adding the route pattern matched by Echo, so dashboards
+// and queries work across frameworks.
+package echolog
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/goxkit/logging"
+)
+
+// DefaultRequestIDHeader is the header the request ID is read from.
+const DefaultRequestIDHeader = echo.HeaderXRequestID
+
+type (
+	// Option configures the middlewares created by New and Recovery.
+	Option func(*options)
+
+	// options holds the middleware settings.
+	options struct {
+		skipPaths       map[string]struct{}
+		requestIDHeader string
+	}
+)
+
+// WithSkipPaths disables access logging for the given paths, e.g. "/healthz".
+func WithSkipPaths(paths ...string) Option {
+	return func(o *options) {
+		for _, p := range paths {
+			o.skipPaths[p] = struct{}{}
+		}
+	}
+}
+
+// WithRequestIDHeader sets the header the request ID is read from.
+// Defaults to X-Request-ID.
+func WithRequestIDHeader(header string) Option {
+	return func(o *options) {
+		o.requestIDHeader = header
+	}
+}
+
+// newOptions applies opts to the default settings.
+func newOptions(opts []Option) *options {
+	o := &options{
+		skipPaths:       map[string]struct{}{},
+		requestIDHeader: DefaultRequestIDHeader,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// New creates an access-logging middleware. Requests are logged once the
+// handler returns with their method, path, route, status, latency, response
+// size, client IP, request ID, handler error and the trace correlation fields,
+// at Info level for successful responses, Warn for 4xx and Error for 5xx.
+// Handler errors are passed to the echo.HTTPErrorHandler first, so the logged
+// status is the one sent to the client.
+//
+//	e := echo.New()
+//	e.Use(echolog.New(logger), echolog.Recovery(logger))
+//
+// Parameters:
+//   - logger: The logger used to write the access log
+//   - opts: Options customizing the middleware
+//
+// Returns:
+//   - An echo.MiddlewareFunc to install with Use
+func New(logger logging.Logger, opts ...Option) echo.MiddlewareFunc {
+	o := newOptions(opts)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if _, skip := o.skipPaths[req.URL.Path]; skip {
+				return next(c)
+			}
+
+			start := time.Now()
+
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+
+			res := c.Response()
+			fields := []zap.Field{
+				zap.String("method", req.Method),
+				zap.String("path", req.URL.Path),
+				zap.Int("status", res.Status),
+				zap.Duration("latency", time.Since(start)),
+				zap.Int64("bytes", res.Size),
+			}
+
+			if route := c.Path(); route != "" {
+				fields = append(fields, zap.String("route", route))
+			}
+			if ip := c.RealIP(); ip != "" {
+				fields = append(fields, zap.String("remote_ip", ip))
+			}
+			if err != nil {
+				fields = append(fields, zap.Error(err))
+			}
+
+			fields = append(fields, o.requestFields(c)...)
+
+			switch {
+			case res.Status >= http.StatusInternalServerError:
+				logger.Error("http request", fields...)
+			case res.Status >= http.StatusBadRequest:
+				logger.Warn("http request", fields...)
+			default:
+				logger.Info("http request", fields...)
+			}
+
+			return err
+		}
+	}
+}
+
+// Recovery creates a middleware that recovers from the panics of the handler,
+// logs them at Error level with the stack of the panic (panic_stack), the
+// request method, path, route and ID and the trace correlation fields, and
+// returns them as errors, answered 500 Internal Server Error by the
+// echo.HTTPErrorHandler. http.ErrAbortHandler panics are propagated, as
+// net/http expects.
+//
+// Install it after New, so recovered requests are logged with their 500 status.
+//
+// Parameters:
+//   - logger: The logger used to write the panics
+//   - opts: Options customizing the middleware; WithRequestIDHeader applies
+//
+// Returns:
+//   - An echo.MiddlewareFunc to install with Use
+func Recovery(logger logging.Logger, opts ...Option) echo.MiddlewareFunc {
+	o := newOptions(opts)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				req := c.Request()
+				fields := []zap.Field{
+					zap.String("panic", fmt.Sprint(recovered)),
+					zap.String("method", req.Method),
+					zap.String("path", req.URL.Path),
+					zap.StackSkip("panic_stack", 2),
+				}
+
+				if route := c.Path(); route != "" {
+					fields = append(fields, zap.String("route", route))
+				}
+
+				if e, ok := recovered.(error); ok {
+					err = e
+					fields = append(fields, zap.Error(e))
+				} else {
+					err = fmt.Errorf("%v", recovered)
+				}
+
+				fields = append(fields, o.requestFields(c)...)
+
+				logger.Error("http handler panic", fields...)
+			}()
+
+			return next(c)
+		}
+	}
+}
+
+// requestFields returns the request ID and trace correlation fields of c.
+func (o *options) requestFields(c echo.Context) []zap.Field {
+	req := c.Request()
+
+	var fields []zap.Field
+	requestID := req.Header.Get(o.requestIDHeader)
+	if requestID == "" {
+		requestID = c.Response().Header().Get(o.requestIDHeader)
+	}
+	if requestID != "" {
+		fields = append(fields, zap.String("request_id", requestID))
+	}
+
+	return append(fields, logging.TraceFields(req.Context())...)
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package fiberlog provides access-logging and recovery handlers for Fiber
+// logging through the goxkit Logger, with the field schema of the httplog
+// middlewares. Fiber runs on fasthttp, whose requests carry no
+// context.Context: the request-scoped logger and the trace context are
+// carried by the user context of the fiber.Ctx instead, retrieved with
+// FromCtx or logging.FromContext(c.UserContext()).
+package fiberlog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/goxkit/logging"
+)
+
+// DefaultRequestIDHeader is the header the request ID is read from.
+const DefaultRequestIDHeader = fiber.HeaderXRequestID
+
+type (
+	// Option configures the handlers created by New and Recovery.
+	Option func(*options)
+
+	// options holds the handler settings.
+	options struct {
+		skipPaths       map[string]struct{}
+		requestIDHeader string
+	}
+
+	// headerCarrier adapts the fasthttp request headers to the OpenTelemetry
+	// propagators.
+	headerCarrier struct {
+		c *fiber.Ctx
+	}
+)
+
+// WithSkipPaths disables access logging for the given paths, e.g. "/healthz".
+// Their requests still get a request-scoped logger.
+func WithSkipPaths(paths ...string) Option {
+	return func(o *options) {
+		for _, p := range paths {
+			o.skipPaths[p] = struct{}{}
+		}
+	}
+}
+
+// WithRequestIDHeader sets the header the request ID is read from.
+// Defaults to X-Request-ID.
+func WithRequestIDHeader(header string) Option {
+	return func(o *options) {
+		o.requestIDHeader = header
+	}
+}
+
+// newOptions applies opts to the default settings.
+func newOptions(opts []Option) *options {
+	o := &options{
+		skipPaths:       map[string]struct{}{},
+		requestIDHeader: DefaultRequestIDHeader,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// New creates an access-logging handler. Before calling the next handlers, it
+// extracts the trace context of the request headers when no middleware such as
+// otelfiber did, and stores in the user context a child of logger carrying the
+// request ID, client IP, user agent and trace correlation fields. Requests are
+// then logged with their method, path, route, status, latency, response size,
+// client IP, request ID, handler error and trace fields, at Info level for
+// successful responses, Warn for 4xx and Error for 5xx. Handler errors are
+// passed to the fiber.ErrorHandler of the app first, so the logged status is
+// the one sent to the client.
+//
+//	app := fiber.New()
+//	app.Use(fiberlog.New(logger), fiberlog.Recovery(logger))
+//
+// Parameters:
+//   - logger: The logger used to write the access log
+//   - opts: Options customizing the handler
+//
+// Returns:
+//   - A fiber.Handler to install with Use
+func New(logger logging.Logger, opts ...Option) fiber.Handler {
+	o := newOptions(opts)
+
+	return func(c *fiber.Ctx) error {
+		ctx := c.UserContext()
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier{c: c})
+		}
+
+		scoped := o.requestFields(c, ctx)
+		if ip := c.IP(); ip != "" {
+			scoped = append(scoped, zap.String("remote_ip", ip))
+		}
+		if ua := c.Get(fiber.HeaderUserAgent); ua != "" {
+			scoped = append(scoped, zap.String("user_agent", strings.Clone(ua)))
+		}
+		c.SetUserContext(logging.ToContext(ctx, logging.WithFields(logger, scoped...)))
+
+		// The path is copied, fasthttp reuses its buffers once the handler returns.
+		path := string(c.Request().URI().Path())
+		if _, skip := o.skipPaths[path]; skip {
+			return c.Next()
+		}
+
+		start := time.Now()
+
+		err := c.Next()
+		if err != nil {
+			if herr := c.App().ErrorHandler(c, err); herr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		fields := []zap.Field{
+			zap.String("method", c.Method()),
+			zap.String("path", path),
+			zap.Int("status", status),
+			zap.Duration("latency", time.Since(start)),
+			zap.Int("bytes", len(c.Response().Body())),
+		}
+
+		if route := c.Route().Path; route != "" {
+			fields = append(fields, zap.String("route", route))
+		}
+		if ip := c.IP(); ip != "" {
+			fields = append(fields, zap.String("remote_ip", ip))
+		}
+		if err != nil {
+			fields = append(fields, zap.Error(err))
+		}
+
+		fields = append(fields, o.requestFields(c, c.UserContext())...)
+
+		switch {
+		case status >= http.StatusInternalServerError:
+			logger.Error("http request", fields...)
+		case status >= http.StatusBadRequest:
+			logger.Warn("http request", fields...)
+		default:
+			logger.Info("http request", fields...)
+		}
+
+		// The error was handled, returning it would send a second response.
+		return nil
+	}
+}
+
+// Recovery creates a handler that recovers from the panics of the next
+// handlers, logs them at Error level with the stack of the panic
+// (panic_stack), the request method, path, route and ID and the trace
+// correlation fields, and returns fiber.ErrInternalServerError, answered 500
+// Internal Server Error by the fiber.ErrorHandler.
+//
+// Install it after New, so recovered requests are logged with their 500 status.
+//
+// Parameters:
+//   - logger: The logger used to write the panics
+//   - opts: Options customizing the handler; WithRequestIDHeader applies
+//
+// Returns:
+//   - A fiber.Handler to install with Use
+func Recovery(logger logging.Logger, opts ...Option) fiber.Handler {
+	o := newOptions(opts)
+
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			fields := []zap.Field{
+				zap.String("panic", fmt.Sprint(recovered)),
+				zap.String("method", c.Method()),
+				zap.String("path", string(c.Request().URI().Path())),
+				zap.StackSkip("panic_stack", 2),
+			}
+
+			if route := c.Route().Path; route != "" {
+				fields = append(fields, zap.String("route", route))
+			}
+
+			if e, ok := recovered.(error); ok {
+				fields = append(fields, zap.Error(e))
+			}
+
+			fields = append(fields, o.requestFields(c, c.UserContext())...)
+
+			logger.Error("http handler panic", fields...)
+
+			// The panic is not exposed to the client.
+			err = fiber.ErrInternalServerError
+		}()
+
+		return c.Next()
+	}
+}
+
+// FromCtx returns the request-scoped logger stored by New in the user context
+// of c, falling back to the default logger.
+//
+// Parameters:
+//   - c: The Fiber context of the request
+//
+// Returns:
+//   - The request-scoped Logger
+func FromCtx(c *fiber.Ctx) logging.Logger {
+	return logging.FromContext(c.UserContext())
+}
+
+// requestFields returns the request ID of c and the trace correlation fields
+// of ctx. The ID is copied, the loggers carrying it may outlive the request.
+func (o *options) requestFields(c *fiber.Ctx, ctx context.Context) []zap.Field {
+	var fields []zap.Field
+	if requestID := c.Get(o.requestIDHeader); requestID != "" {
+		fields = append(fields, zap.String("request_id", strings.Clone(requestID)))
+	}
+
+	return append(fields, logging.TraceFields(ctx)...)
+}
+
+// Get returns the value of the request header key.
+func (h headerCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+// Set sets the request header key.
+func (h headerCarrier) Set(key, value string) {
+	h.c.Request().Header.Set(key, value)
+}
+
+// Keys lists the request header keys.
+func (h headerCarrier) Keys() []string {
+	var keys []string
+	h.c.Request().Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+
+	return keys
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package ginlog provides access-logging and recovery middlewares for Gin,
+// replacing gin.Logger and gin.Recovery, which write plain text to a writer,
+// with entries logged through the goxkit Logger. The fields follow the schema
+// of the httplog middlewares, adding the route pattern matched by Gin.
+package ginlog
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/goxkit/logging"
+)
+
+// DefaultRequestIDHeader is the header the request ID is read from.
+const DefaultRequestIDHeader = "X-Request-ID"
+
+type (
+	// Option configures the middlewares created by New and Recovery.
+	Option func(*options)
+
+	// options holds the middleware settings.
+	options struct {
+		skipPaths       map[string]struct{}
+		requestIDHeader string
+	}
+)
+
+// WithSkipPaths disables access logging for the given paths, e.g. "/healthz".
+func WithSkipPaths(paths ...string) Option {
+	return func(o *options) {
+		for _, p := range paths {
+			o.skipPaths[p] = struct{}{}
+		}
+	}
+}
+
+// WithRequestIDHeader sets the header the request ID is read from.
+// Defaults to X-Request-ID.
+func WithRequestIDHeader(header string) Option {
+	return func(o *options) {
+		o.requestIDHeader = header
+	}
+}
+
+// newOptions applies opts to the default settings.
+func newOptions(opts []Option) *options {
+	o := &options{
+		skipPaths:       map[string]struct{}{},
+		requestIDHeader: DefaultRequestIDHeader,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// New creates an access-logging middleware. Requests are logged once the
+// handlers return with their method, path, route, status, latency, response
+// size, client IP, request ID, the errors attached to the gin.Context and the
+// trace correlation fields, at Info level for successful responses, Warn for
+// 4xx and Error for 5xx.
+//
+//	router := gin.New()
+//	router.Use(ginlog.New(logger), ginlog.Recovery(logger))
+//
+// Parameters:
+//   - logger: The logger used to write the access log
+//   - opts: Options customizing the middleware
+//
+// Returns:
+//   - A gin.HandlerFunc to install with Use
+func New(logger logging.Logger, opts ...Option) gin.HandlerFunc {
+	o := newOptions(opts)
+
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if _, skip := o.skipPaths[path]; skip {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		fields := []zap.Field{
+			zap.String("method", c.Request.Method),
+			zap.String("path", path),
+			zap.Int("status", status),
+			zap.Duration("latency", time.Since(start)),
+			zap.Int("bytes", max(c.Writer.Size(), 0)),
+		}
+
+		if route := c.FullPath(); route != "" {
+			fields = append(fields, zap.String("route", route))
+		}
+		if ip := c.ClientIP(); ip != "" {
+			fields = append(fields, zap.String("remote_ip", ip))
+		}
+
+		fields = append(fields, o.requestFields(c)...)
+
+		if len(c.Errors) > 0 {
+			fields = append(fields, zap.Strings("errors", c.Errors.Errors()))
+		}
+
+		switch {
+		case status >= http.StatusInternalServerError:
+			logger.Error("http request", fields...)
+		case status >= http.StatusBadRequest:
+			logger.Warn("http request", fields...)
+		default:
+			logger.Info("http request", fields...)
+		}
+	}
+}
+
+// Recovery creates a middleware that recovers from the panics of the handlers,
+// logs them at Error level with the stack of the panic (panic_stack), the
+// request method, path, route and ID and the trace correlation fields, and
+// aborts the request with 500 Internal Server Error when nothing was written
+// yet. http.ErrAbortHandler panics are propagated, as net/http expects.
+//
+// Install it after New, so recovered requests are logged with their 500 status.
+//
+// Parameters:
+//   - logger: The logger used to write the panics
+//   - opts: Options customizing the middleware; WithRequestIDHeader applies
+//
+// Returns:
+//   - A gin.HandlerFunc to install with Use
+func Recovery(logger logging.Logger, opts ...Option) gin.HandlerFunc {
+	o := newOptions(opts)
+
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			fields := []zap.Field{
+				zap.String("panic", fmt.Sprint(recovered)),
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.Request.URL.Path),
+				zap.StackSkip("panic_stack", 2),
+			}
+
+			if route := c.FullPath(); route != "" {
+				fields = append(fields, zap.String("route", route))
+			}
+
+			var err error
+			if e, ok := recovered.(error); ok {
+				err = e
+				fields = append(fields, zap.Error(e))
+			} else {
+				err = errors.New(fmt.Sprint(recovered))
+			}
+
+			fields = append(fields, o.requestFields(c)...)
+
+			logger.Error("http handler panic", fields...)
+
+			_ = c.Error(err)
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}()
+
+		c.Next()
+	}
+}
+
+// requestFields returns the request ID and trace correlation fields of c.
+func (o *options) requestFields(c *gin.Context) []zap.Field {
+	var fields []zap.Field
+	if requestID := c.GetHeader(o.requestIDHeader); requestID != "" {
+		fields = append(fields, zap.String("request_id", requestID))
+	}
+
+	return append(fields, logging.TraceFields(c.Request.Context())...)
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package grpclog provides gRPC server and client interceptors that log every RPC
+// through the goxkit Logger with its method, status code, duration, peer address
+// and trace correlation fields, and optionally the payloads of selected methods.
+package grpclog
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/goxkit/logging"
+)
+
+type (
+	// Option configures the interceptors.
+	Option func(*options)
+
+	// options holds the interceptors settings.
+	options struct {
+		skipMethods map[string]struct{}
+		payloads    *payloads
+	}
+)
+
+// WithSkipMethods disables logging for the given full method names,
+// e.g. "/grpc.health.v1.Health/Check".
+func WithSkipMethods(methods ...string) Option {
+	return func(o *options) {
+		for _, m := range methods {
+			o.skipMethods[m] = struct{}{}
+		}
+	}
+}
+
+// UnaryServerInterceptor logs every unary RPC handled by the server.
+func UnaryServerInterceptor(logger logging.Logger, opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if o.skip(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		var extra []zap.Field
+		if o.payloads.enabled(info.FullMethod) {
+			extra = append(extra, o.payloads.field("grpc.request", req))
+			if err == nil {
+				extra = append(extra, o.payloads.field("grpc.response", resp))
+			}
+		}
+
+		logRPC(ctx, logger, "grpc server call", "unary", info.FullMethod, start, err, extra...)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor logs every streaming RPC handled by the server once
+// the stream is closed.
+func StreamServerInterceptor(logger logging.Logger, opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if o.skip(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		if o.payloads.enabled(info.FullMethod) {
+			ss = &payloadServerStream{ServerStream: ss, logger: logger, fullMethod: info.FullMethod, maxBytes: o.payloads.maxBytes}
+		}
+
+		start := time.Now()
+		err := handler(srv, ss)
+		logRPC(ss.Context(), logger, "grpc server call", "stream", info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+// UnaryClientInterceptor logs every unary RPC issued by the client.
+func UnaryClientInterceptor(logger logging.Logger, opts ...Option) grpc.UnaryClientInterceptor {
+	o := newOptions(opts)
+
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		callOpts ...grpc.CallOption,
+	) error {
+		if o.skip(method) {
+			return invoker(ctx, method, req, reply, cc, callOpts...)
+		}
+
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+
+		extra := []zap.Field{zap.String("peer.address", cc.Target())}
+		if o.payloads.enabled(method) {
+			extra = append(extra, o.payloads.field("grpc.request", req))
+			if err == nil {
+				extra = append(extra, o.payloads.field("grpc.response", reply))
+			}
+		}
+
+		logRPC(ctx, logger, "grpc client call", "unary", method, start, err, extra...)
+
+		return err
+	}
+}
+
+// StreamClientInterceptor logs the establishment of every streaming RPC issued
+// by the client.
+func StreamClientInterceptor(logger logging.Logger, opts ...Option) grpc.StreamClientInterceptor {
+	o := newOptions(opts)
+
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		callOpts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		if o.skip(method) {
+			return streamer(ctx, desc, cc, method, callOpts...)
+		}
+
+		start := time.Now()
+		stream, err := streamer(ctx, desc, cc, method, callOpts...)
+		logRPC(ctx, logger, "grpc client call", "stream", method, start, err, zap.String("peer.address", cc.Target()))
+
+		if err == nil && o.payloads.enabled(method) {
+			stream = &payloadClientStream{ClientStream: stream, logger: logger, fullMethod: method, maxBytes: o.payloads.maxBytes}
+		}
+
+		return stream, err
+	}
+}
+
+// newOptions applies the options over the defaults.
+func newOptions(opts []Option) *options {
+	o := &options{skipMethods: map[string]struct{}{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// skip reports whether fullMethod must not be logged.
+func (o *options) skip(fullMethod string) bool {
+	_, ok := o.skipMethods[fullMethod]
+	return ok
+}
+
+// logRPC writes the log entry of a finished RPC, at a level derived from its code.
+func logRPC(
+	ctx context.Context,
+	logger logging.Logger,
+	msg, kind, fullMethod string,
+	start time.Time,
+	err error,
+	extra ...zap.Field,
+) {
+	code := status.Code(err)
+	service, method := splitMethod(fullMethod)
+
+	fields := []zap.Field{
+		zap.String("grpc.service", service),
+		zap.String("grpc.method", method),
+		zap.String("grpc.kind", kind),
+		zap.String("grpc.code", code.String()),
+		zap.Duration("latency", time.Since(start)),
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields = append(fields, zap.String("peer.address", p.Addr.String()))
+	}
+
+	fields = append(fields, extra...)
+	fields = append(fields, logging.TraceFields(ctx)...)
+
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+
+	switch levelFor(code) {
+	case zapcore.ErrorLevel:
+		logger.Error(msg, fields...)
+	case zapcore.WarnLevel:
+		logger.Warn(msg, fields...)
+	default:
+		logger.Info(msg, fields...)
+	}
+}
+
+// levelFor maps a gRPC status code to the level of its log entry: client-side
+// errors are logged as warnings and server-side errors as errors.
+func levelFor(code codes.Code) zapcore.Level {
+	switch code {
+	case codes.OK:
+		return zapcore.InfoLevel
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
+		codes.PermissionDenied, codes.Unauthenticated, codes.FailedPrecondition,
+		codes.OutOfRange, codes.ResourceExhausted, codes.Aborted:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
+
+// splitMethod splits a full method name ("/package.Service/Method") into its
+// service and method parts.
+func splitMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "", service
+	}
+
+	return service, method
+}
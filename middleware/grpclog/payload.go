@@ -0,0 +1,205 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package grpclog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goxkit/configs"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/goxkit/logging"
+)
+
+// DefaultPayloadMaxBytes is the size payloads are truncated at when
+// PayloadConfig.MaxBytes is zero.
+const DefaultPayloadMaxBytes = 4096
+
+type (
+	// PayloadConfig describes the logging of the request and response payloads.
+	PayloadConfig struct {
+		// Methods are the full method names whose payloads are logged, e.g.
+		// "/orders.v1.Orders/Create". "/orders.v1.Orders/*" matches every
+		// method of a service and "*" every method.
+		Methods []string
+		// MaxBytes truncates the JSON payloads. Defaults to DefaultPayloadMaxBytes.
+		MaxBytes int
+		// Environment is the deployment environment, e.g. "development".
+		// Defaults to the GO_ENV variable.
+		Environment string
+		// AllowProduction logs the payloads in every environment. By default
+		// they are only logged in the local, development, qa and staging
+		// environments, as they may carry personal data.
+		AllowProduction bool
+	}
+
+	// payloads holds the payload logging settings.
+	payloads struct {
+		methods  map[string]struct{}
+		maxBytes int
+	}
+
+	// payloadServerStream logs the messages of a server stream.
+	payloadServerStream struct {
+		grpc.ServerStream
+		logger     logging.Logger
+		fullMethod string
+		maxBytes   int
+	}
+
+	// payloadClientStream logs the messages of a client stream.
+	payloadClientStream struct {
+		grpc.ClientStream
+		logger     logging.Logger
+		fullMethod string
+		maxBytes   int
+	}
+)
+
+// WithPayloads logs the request and response payloads of the methods of cfg,
+// marshaled to JSON and truncated, as the grpc.request and grpc.response
+// fields of unary RPCs, and as one Debug entry per message of streaming RPCs.
+// Payloads are only logged outside production unless cfg.AllowProduction is set.
+func WithPayloads(cfg PayloadConfig) Option {
+	return func(o *options) {
+		env := cfg.Environment
+		if env == "" {
+			env = os.Getenv(logging.EnvironmentEnvKey)
+		}
+
+		switch configs.NewEnvironment(env) {
+		case configs.LocalEnv, configs.DevelopmentEnv, configs.QaEnv, configs.StagingEnv:
+		default:
+			if !cfg.AllowProduction {
+				o.payloads = nil
+				return
+			}
+		}
+
+		if cfg.MaxBytes <= 0 {
+			cfg.MaxBytes = DefaultPayloadMaxBytes
+		}
+
+		p := &payloads{methods: map[string]struct{}{}, maxBytes: cfg.MaxBytes}
+		for _, m := range cfg.Methods {
+			p.methods[m] = struct{}{}
+		}
+		o.payloads = p
+	}
+}
+
+// enabled reports whether the payloads of fullMethod are logged.
+func (p *payloads) enabled(fullMethod string) bool {
+	if p == nil {
+		return false
+	}
+	if _, ok := p.methods["*"]; ok {
+		return true
+	}
+	if _, ok := p.methods[fullMethod]; ok {
+		return true
+	}
+
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	_, ok := p.methods["/"+service+"/*"]
+
+	return ok
+}
+
+// field returns the payload msg as a truncated JSON string field.
+func (p *payloads) field(key string, msg any) zap.Field {
+	return payloadField(key, msg, p.maxBytes)
+}
+
+// RecvMsg logs the received request messages.
+func (s *payloadServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		logMessage(s.Context(), s.logger, s.fullMethod, "request", m, s.maxBytes)
+	}
+
+	return err
+}
+
+// SendMsg logs the sent response messages.
+func (s *payloadServerStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		logMessage(s.Context(), s.logger, s.fullMethod, "response", m, s.maxBytes)
+	}
+
+	return err
+}
+
+// SendMsg logs the sent request messages.
+func (s *payloadClientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		logMessage(s.Context(), s.logger, s.fullMethod, "request", m, s.maxBytes)
+	}
+
+	return err
+}
+
+// RecvMsg logs the received response messages.
+func (s *payloadClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		logMessage(s.Context(), s.logger, s.fullMethod, "response", m, s.maxBytes)
+	}
+
+	return err
+}
+
+// logMessage writes the Debug entry of a stream message.
+func logMessage(ctx context.Context, logger logging.Logger, fullMethod, direction string, m any, maxBytes int) {
+	service, method := splitMethod(fullMethod)
+
+	fields := []zap.Field{
+		zap.String("grpc.service", service),
+		zap.String("grpc.method", method),
+		payloadField("grpc."+direction, m, maxBytes),
+	}
+	fields = append(fields, logging.TraceFields(ctx)...)
+
+	logger.Debug("grpc stream message", fields...)
+}
+
+// payloadField marshals msg to JSON, protojson for proto messages, and
+// truncates it at maxBytes without splitting a UTF-8 sequence.
+func payloadField(key string, msg any, maxBytes int) zap.Field {
+	var (
+		b   []byte
+		err error
+	)
+
+	if pm, ok := msg.(proto.Message); ok {
+		b, err = protojson.Marshal(pm)
+	} else {
+		b, err = json.Marshal(msg)
+	}
+	if err != nil {
+		return zap.String(key, fmt.Sprintf("%v", msg))
+	}
+
+	if len(b) <= maxBytes {
+		return zap.String(key, string(b))
+	}
+
+	n := maxBytes
+	for n > 0 && !utf8.RuneStart(b[n]) {
+		n--
+	}
+
+	return zap.String(key, string(b[:n])+"…(truncated)")
+}
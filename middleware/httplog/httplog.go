@@ -0,0 +1,164 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package httplog provides an HTTP access-logging middleware for net/http servers.
+// Every request is logged through the goxkit Logger with its method, path, status,
+// latency, response size, request ID and trace correlation fields.
+package httplog
+
+import (
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/goxkit/logging"
+	zapInstance "github.com/goxkit/logging/zap"
+)
+
+// DefaultRequestIDHeader is the header the request ID is read from.
+const DefaultRequestIDHeader = "X-Request-ID"
+
+type (
+	// Option configures the middleware created by New.
+	Option func(*options)
+
+	// options holds the middleware settings.
+	options struct {
+		skipPaths       map[string]struct{}
+		requestIDHeader string
+		gcpHTTPRequest  bool
+		routePattern    func(*http.Request) string
+		realIPHeader    string
+	}
+)
+
+// WithSkipPaths disables access logging for the given paths, e.g. "/healthz".
+func WithSkipPaths(paths ...string) Option {
+	return func(o *options) {
+		for _, p := range paths {
+			o.skipPaths[p] = struct{}{}
+		}
+	}
+}
+
+// WithRequestIDHeader sets the header the request ID is read from.
+// Defaults to X-Request-ID.
+func WithRequestIDHeader(header string) Option {
+	return func(o *options) {
+		o.requestIDHeader = header
+	}
+}
+
+// WithGCPHTTPRequest adds the httpRequest field of the Google Cloud Logging
+// format, rendered by Cloud Run and GKE as a request log line. Use it together
+// with the logging.GCPEncoder.
+func WithGCPHTTPRequest() Option {
+	return func(o *options) {
+		o.gcpHTTPRequest = true
+	}
+}
+
+// New creates an access-logging middleware. Requests are logged once the handler
+// returns, at Info level for successful responses, Warn for 4xx and Error for 5xx.
+//
+// Parameters:
+//   - logger: The logger used to write the access log
+//   - opts: Options customizing the middleware
+//
+// Returns:
+//   - A middleware wrapping an http.Handler
+func New(logger logging.Logger, opts ...Option) func(http.Handler) http.Handler {
+	o := &options{
+		skipPaths:       map[string]struct{}{},
+		requestIDHeader: DefaultRequestIDHeader,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, skip := o.skipPaths[r.URL.Path]; skip {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rw, r)
+
+			latency := time.Since(start)
+
+			fields := []zap.Field{
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.Int("status", rw.status),
+				zap.Duration("latency", latency),
+				zap.Int64("bytes", rw.bytes),
+			}
+
+			if o.gcpHTTPRequest {
+				fields = append(fields, zapInstance.GCPHTTPRequest(r, rw.status, rw.bytes, latency))
+			}
+
+			if requestID := r.Header.Get(o.requestIDHeader); requestID != "" {
+				fields = append(fields, zap.String("request_id", requestID))
+			}
+
+			fields = append(fields, logging.TraceFields(r.Context())...)
+
+			switch {
+			case rw.status >= http.StatusInternalServerError:
+				logger.Error("http request", fields...)
+			case rw.status >= http.StatusBadRequest:
+				logger.Warn("http request", fields...)
+			default:
+				logger.Info("http request", fields...)
+			}
+		})
+	}
+}
+
+// responseWriter records the status code and the number of bytes written.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+// WriteHeader records the status code before writing it.
+func (w *responseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the number of bytes written to the body.
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+
+	return n, err
+}
+
+// Unwrap exposes the original writer to http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush implements http.Flusher when the original writer supports it.
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		f.Flush()
+	}
+}
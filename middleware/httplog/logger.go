@@ -0,0 +1,124 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package httplog
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/goxkit/logging"
+)
+
+// WithRoutePattern sets the function resolving the route pattern of a request,
+// e.g. "GET /orders/{id}", logged as the route field by RequestLogger instead
+// of the raw path, keeping the cardinality of the field low. Defaults to
+// http.Request.Pattern, set by http.ServeMux on the requests of the handlers
+// it routes to; use ServeMuxPattern when the middleware wraps the whole mux,
+// or chilog.RoutePattern with chi.
+func WithRoutePattern(fn func(*http.Request) string) Option {
+	return func(o *options) {
+		o.routePattern = fn
+	}
+}
+
+// WithRealIPHeader reads the client IP logged by RequestLogger from a header
+// set by a trusted reverse proxy, e.g. "X-Forwarded-For" (its first address)
+// or "X-Real-IP", instead of the remote address of the connection. Only use
+// it behind a proxy overwriting the header, clients can forge it otherwise.
+func WithRealIPHeader(header string) Option {
+	return func(o *options) {
+		o.realIPHeader = header
+	}
+}
+
+// ServeMuxPattern returns a route pattern resolver for WithRoutePattern,
+// matching the requests against mux before it routes them.
+//
+// Parameters:
+//   - mux: The ServeMux wrapped by the middleware
+//
+// Returns:
+//   - A function returning the pattern of the handler mux routes a request to
+func ServeMuxPattern(mux *http.ServeMux) func(*http.Request) string {
+	return func(r *http.Request) string {
+		_, pattern := mux.Handler(r)
+		return pattern
+	}
+}
+
+// RequestLogger creates a middleware that builds a child of logger per request,
+// carrying its request ID, route pattern, client IP and user agent, and stores
+// it in the request context with logging.ToContext, so handlers get a
+// pre-enriched logger from logging.FromContext:
+//
+//	handler := httplog.RequestLogger(logger, httplog.WithRoutePattern(httplog.ServeMuxPattern(mux)))(mux)
+//
+//	// in a handler
+//	logging.FromContext(r.Context()).Info("order created")
+//
+// The request ID is read from the request header; install requestid.New
+// before this middleware to generate the missing ones.
+//
+// Parameters:
+//   - logger: The logger the request-scoped loggers derive from
+//   - opts: Options customizing the middleware; WithRequestIDHeader,
+//     WithRoutePattern and WithRealIPHeader apply
+//
+// Returns:
+//   - A middleware wrapping an http.Handler
+func RequestLogger(logger logging.Logger, opts ...Option) func(http.Handler) http.Handler {
+	o := &options{
+		skipPaths:       map[string]struct{}{},
+		requestIDHeader: DefaultRequestIDHeader,
+		routePattern:    func(r *http.Request) string { return r.Pattern },
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fields := make([]zap.Field, 0, 4)
+
+			if requestID := r.Header.Get(o.requestIDHeader); requestID != "" {
+				fields = append(fields, zap.String("request_id", requestID))
+			}
+			if route := o.routePattern(r); route != "" {
+				fields = append(fields, zap.String("route", route))
+			}
+			if ip := o.clientIP(r); ip != "" {
+				fields = append(fields, zap.String("remote_ip", ip))
+			}
+			if ua := r.UserAgent(); ua != "" {
+				fields = append(fields, zap.String("user_agent", ua))
+			}
+
+			ctx := logging.ToContext(r.Context(), logging.WithFields(logger, fields...))
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// clientIP returns the IP of the client of r, from the real IP header when
+// configured and set, or from the remote address of the connection.
+func (o *options) clientIP(r *http.Request) string {
+	if o.realIPHeader != "" {
+		if value := r.Header.Get(o.realIPHeader); value != "" {
+			first, _, _ := strings.Cut(value, ",")
+			return strings.TrimSpace(first)
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
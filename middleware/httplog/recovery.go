@@ -0,0 +1,82 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package httplog
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/goxkit/logging"
+)
+
+// Recovery creates a middleware that recovers from the panics of the handler,
+// logs them at Error level with the stack of the panic (panic_stack), the
+// request method, path and ID and the trace correlation fields, and responds
+// 500 Internal Server Error when nothing was written yet, keeping the server
+// alive. http.ErrAbortHandler panics are propagated, as net/http expects.
+//
+// Install it inside the access-logging middleware, so recovered requests are
+// logged with their 500 status:
+//
+//	handler := httplog.New(logger)(httplog.Recovery(logger)(mux))
+//
+// Parameters:
+//   - logger: The logger used to write the panics
+//   - opts: Options customizing the middleware; WithRequestIDHeader applies
+//
+// Returns:
+//   - A middleware wrapping an http.Handler
+func Recovery(logger logging.Logger, opts ...Option) func(http.Handler) http.Handler {
+	o := &options{
+		skipPaths:       map[string]struct{}{},
+		requestIDHeader: DefaultRequestIDHeader,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				fields := []zap.Field{
+					zap.String("panic", fmt.Sprint(recovered)),
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.StackSkip("panic_stack", 2),
+				}
+
+				if err, ok := recovered.(error); ok {
+					fields = append(fields, zap.Error(err))
+				}
+
+				if requestID := r.Header.Get(o.requestIDHeader); requestID != "" {
+					fields = append(fields, zap.String("request_id", requestID))
+				}
+
+				fields = append(fields, logging.TraceFields(r.Context())...)
+
+				logger.Error("http handler panic", fields...)
+
+				if !rw.wroteHeader {
+					http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+			}()
+
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
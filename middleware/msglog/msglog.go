@@ -0,0 +1,198 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package msglog provides correlation helpers for AMQP and Kafka messages.
+// Producers inject the trace context and the request ID of the current request
+// into the message headers, and consumers extract them into a context carrying
+// a consumer-scoped logger, so asynchronous handlers are correlated in the logs
+// the same way HTTP handlers are.
+package msglog
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.uber.org/zap"
+
+	"github.com/goxkit/logging"
+	"github.com/goxkit/logging/middleware/requestid"
+)
+
+// DefaultHeader is the message header carrying the request ID.
+const DefaultHeader = requestid.DefaultHeader
+
+type (
+	// Option configures Inject and NewConsumerContext.
+	Option func(*options)
+
+	// options holds the correlation settings.
+	options struct {
+		header     string
+		propagator propagation.TextMapPropagator
+		generate   bool
+	}
+
+	// AMQPHeaders adapts the headers of an AMQP message (amqp091.Table) to a
+	// propagation.TextMapCarrier, e.g. msglog.AMQPHeaders(msg.Headers). Values
+	// are read when they are strings or byte slices.
+	AMQPHeaders map[string]any
+
+	// KafkaHeaders adapts the headers of a franz-go record to a
+	// propagation.TextMapCarrier. Setting a key replaces its existing values.
+	KafkaHeaders struct {
+		Record *kgo.Record
+	}
+)
+
+// WithHeader sets the header carrying the request ID. Defaults to X-Request-ID.
+func WithHeader(header string) Option {
+	return func(o *options) {
+		o.header = header
+	}
+}
+
+// WithPropagator sets the propagator of the trace context. Defaults to the
+// global OpenTelemetry propagator.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(o *options) {
+		o.propagator = propagator
+	}
+}
+
+// WithoutGeneratedID leaves messages without request ID uncorrelated, instead
+// of generating one for them.
+func WithoutGeneratedID() Option {
+	return func(o *options) {
+		o.generate = false
+	}
+}
+
+// Inject writes the trace context and the request ID of ctx into the message
+// headers. When ctx carries no request ID, a new one is generated, so the
+// consumers of a message published outside a request are still correlated.
+//
+// Parameters:
+//   - ctx: The context of the producer
+//   - headers: The headers of the message, e.g. AMQPHeaders or KafkaHeaders
+//   - opts: Options customizing the correlation
+func Inject(ctx context.Context, headers propagation.TextMapCarrier, opts ...Option) {
+	o := newOptions(opts)
+
+	o.propagator.Inject(ctx, headers)
+
+	id := requestid.FromContext(ctx)
+	if id == "" && o.generate {
+		id = uuid.NewString()
+	}
+	if id != "" {
+		headers.Set(o.header, id)
+	}
+}
+
+// NewConsumerContext extracts the trace context and the request ID of the
+// message headers into ctx, which is returned carrying a child of logger with
+// the request_id and trace correlation fields, retrieved by the message handler
+// through logging.FromContext.
+//
+// Parameters:
+//   - ctx: The context of the consumer
+//   - logger: The logger the consumer-scoped logger derives from
+//   - headers: The headers of the message, e.g. AMQPHeaders or KafkaHeaders
+//   - opts: Options customizing the correlation
+//
+// Returns:
+//   - A context carrying the trace context, the request ID and the logger
+func NewConsumerContext(
+	ctx context.Context,
+	logger logging.Logger,
+	headers propagation.TextMapCarrier,
+	opts ...Option,
+) context.Context {
+	o := newOptions(opts)
+
+	ctx = o.propagator.Extract(ctx, headers)
+
+	fields := logging.TraceFields(ctx)
+
+	id := headers.Get(o.header)
+	if id == "" && o.generate {
+		id = uuid.NewString()
+	}
+	if id != "" {
+		ctx = requestid.NewContext(ctx, id)
+		fields = append(fields, zap.String(requestid.FieldKey, id))
+	}
+
+	return logging.ToContext(ctx, logging.WithFields(logger, fields...))
+}
+
+// Get returns the value of key, or an empty string.
+func (h AMQPHeaders) Get(key string) string {
+	switch value := h[key].(type) {
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	default:
+		return ""
+	}
+}
+
+// Set sets the value of key.
+func (h AMQPHeaders) Set(key, value string) {
+	h[key] = value
+}
+
+// Keys returns the header keys.
+func (h AMQPHeaders) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// Get returns the value of the first header named key, or an empty string.
+func (h KafkaHeaders) Get(key string) string {
+	for _, header := range h.Record.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+
+	return ""
+}
+
+// Set replaces the headers named key with a single one holding value.
+func (h KafkaHeaders) Set(key, value string) {
+	h.Record.Headers = slices.DeleteFunc(h.Record.Headers, func(header kgo.RecordHeader) bool {
+		return header.Key == key
+	})
+	h.Record.Headers = append(h.Record.Headers, kgo.RecordHeader{Key: key, Value: []byte(value)})
+}
+
+// Keys returns the header keys.
+func (h KafkaHeaders) Keys() []string {
+	keys := make([]string, 0, len(h.Record.Headers))
+	for _, header := range h.Record.Headers {
+		keys = append(keys, header.Key)
+	}
+
+	return keys
+}
+
+// newOptions applies opts over the default settings.
+func newOptions(opts []Option) *options {
+	o := &options{header: DefaultHeader, propagator: otel.GetTextMapPropagator(), generate: true}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
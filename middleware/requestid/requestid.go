@@ -0,0 +1,133 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package requestid provides a net/http middleware that reads or generates the
+// request ID of every request, returns it in the response headers and stores it
+// in the request context together with a logger carrying it, so every entry
+// logged within the request shares the same identifier, even without tracing.
+package requestid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/goxkit/logging"
+)
+
+const (
+	// DefaultHeader is the header the request ID is read from and written to.
+	DefaultHeader = "X-Request-ID"
+
+	// FieldKey is the key of the request ID field added to the logger.
+	FieldKey = "request_id"
+)
+
+type (
+	// Option configures the middleware created by New.
+	Option func(*options)
+
+	// options holds the middleware settings.
+	options struct {
+		header    string
+		generator func() string
+		trust     bool
+	}
+
+	// contextKey is the context key of the request ID.
+	contextKey struct{}
+)
+
+// WithHeader sets the header the request ID is read from and written to.
+// Defaults to X-Request-ID.
+func WithHeader(header string) Option {
+	return func(o *options) {
+		o.header = header
+	}
+}
+
+// WithGenerator sets the function generating the ID of requests without one.
+// Defaults to random UUIDs.
+func WithGenerator(generator func() string) Option {
+	return func(o *options) {
+		o.generator = generator
+	}
+}
+
+// WithoutIncoming always generates a new request ID, ignoring the one sent by
+// the client, for services exposed to untrusted callers.
+func WithoutIncoming() Option {
+	return func(o *options) {
+		o.trust = false
+	}
+}
+
+// New creates a middleware that reads the request ID from the request header,
+// or generates one, sets it on the response header, and stores it in the
+// request context along with a child of logger carrying a request_id field,
+// retrieved downstream through logging.FromContext. The request header is set
+// too, so access logs written by inner middlewares such as httplog include it.
+//
+// Parameters:
+//   - logger: The logger the request-scoped logger derives from
+//   - opts: Options customizing the middleware
+//
+// Returns:
+//   - A middleware wrapping an http.Handler
+func New(logger logging.Logger, opts ...Option) func(http.Handler) http.Handler {
+	o := &options{
+		header:    DefaultHeader,
+		generator: uuid.NewString,
+		trust:     true,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := ""
+			if o.trust {
+				id = r.Header.Get(o.header)
+			}
+			if id == "" {
+				id = o.generator()
+				r.Header.Set(o.header, id)
+			}
+
+			w.Header().Set(o.header, id)
+
+			ctx := NewContext(r.Context(), id)
+			ctx = logging.ToContext(ctx, logging.WithFields(logger, zap.String(FieldKey, id)))
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// NewContext returns a copy of ctx carrying the request ID.
+//
+// Parameters:
+//   - ctx: The parent context
+//   - id: The request ID
+//
+// Returns:
+//   - A context carrying id
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx by the middleware.
+//
+// Parameters:
+//   - ctx: The request context
+//
+// Returns:
+//   - The request ID, or an empty string when ctx carries none
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
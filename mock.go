@@ -2,13 +2,14 @@
 // MIT License
 // All rights reserved.
 
-// Package logging provides structured logging capabilities powered by Zap.
-// This file contains mock implementations for testing purposes.
 package logging
 
 import (
+	"context"
+
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // MockLogger is a mock implementation of the Logger interface
@@ -81,6 +82,70 @@ func (m *MockLogger) Error(_ string, _ ...zap.Field) {
 func (m *MockLogger) Fatal(_ string, _ ...zap.Field) {
 }
 
+// Check implements the Logger interface's Check method for the mock.
+// Nothing is ever written, so no entry is returned.
+//
+// Parameters:
+//   - lvl: The level that would be checked
+//   - msg: The message that would be logged
+//
+// Returns:
+//   - nil (since it's a mock)
+func (m *MockLogger) Check(_ zapcore.Level, _ string) *zapcore.CheckedEntry {
+	return nil
+}
+
+// Enabled implements the Logger interface's Enabled method for the mock.
+// Nothing is ever written, so no level is enabled.
+//
+// Parameters:
+//   - lvl: The level that would be checked
+//
+// Returns:
+//   - false (since it's a mock)
+func (m *MockLogger) Enabled(_ zapcore.Level) bool {
+	return false
+}
+
+// Sugar implements the Logger interface's Sugar method for the mock.
+// It returns a sugared no-op logger, so calls on it are safe and discarded.
+//
+// Returns:
+//   - A zap.SugaredLogger writing nothing
+func (m *MockLogger) Sugar() *zap.SugaredLogger {
+	return zap.NewNop().Sugar()
+}
+
+// AtomicLevel implements the Logger interface's AtomicLevel method for the mock.
+// It returns a fresh atomic level that is not connected to any output.
+//
+// Returns:
+//   - A new zap.AtomicLevel set to Info
+func (m *MockLogger) AtomicLevel() zap.AtomicLevel {
+	return zap.NewAtomicLevel()
+}
+
+// Sync implements the Logger interface's Sync method for the mock.
+// There is nothing to flush, so it always succeeds.
+//
+// Returns:
+//   - nil (since it's a mock)
+func (m *MockLogger) Sync() error {
+	return nil
+}
+
+// Shutdown implements the Logger interface's Shutdown method for the mock.
+// There is no export pipeline to stop, so it always succeeds.
+//
+// Parameters:
+//   - ctx: The context that would bound the shutdown
+//
+// Returns:
+//   - nil (since it's a mock)
+func (m *MockLogger) Shutdown(_ context.Context) error {
+	return nil
+}
+
 // NewMockLogger creates and returns a new instance of MockLogger
 // that can be used in tests to verify logging behavior without
 // producing actual log output.
@@ -0,0 +1,96 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	zapInstance "github.com/goxkit/logging/zap"
+)
+
+// root is the logger named loggers derive from: the last one created by New or
+// NewLogger.
+var root atomic.Pointer[logger]
+
+// setRoot registers l as the logger named loggers derive from and, unless one
+// was set through SetDefault, as the default logger.
+func setRoot(l *logger) {
+	root.Store(l)
+	setDefaultFromRoot(l)
+}
+
+// Named returns a sublogger of the last logger created by New or NewLogger. Its
+// name is appended to the parent name (e.g. "my-service.repository"), and its
+// minimum level can be configured independently through WithNamedLevels, the
+// LOG_LEVELS variable (e.g. LOG_LEVELS="repository=debug,http=warn") or
+// SetNamedLevel.
+//
+// Named must be called after the logger is created; before that it returns a
+// logger that discards every entry.
+//
+// Parameters:
+//   - name: The module name, e.g. "repository"
+//
+// Returns:
+//   - A Logger sharing the outputs of the root logger
+func Named(name string) Logger {
+	l := root.Load()
+	if l == nil {
+		return newLogger(zap.NewNop(), nil)
+	}
+
+	return &logger{Logger: l.Logger.Named(name), levels: l.levels, provider: l.provider, reload: l.reload}
+}
+
+// SetNamedLevel changes at runtime the minimum level of the subloggers matching
+// name, without affecting the rest of the application.
+//
+// Parameters:
+//   - name: The module name, e.g. "repository"
+//   - level: The new minimum level of the module
+func SetNamedLevel(name string, level zapcore.Level) {
+	if l := root.Load(); l != nil {
+		l.levels.Set(name, level)
+	}
+}
+
+// UnsetNamedLevel removes the level of the subloggers matching name, which fall
+// back to the global level.
+//
+// Parameters:
+//   - name: The module name, e.g. "repository"
+func UnsetNamedLevel(name string) {
+	if l := root.Load(); l != nil {
+		l.levels.Unset(name)
+	}
+}
+
+// NamedLevels returns the levels configured per module name.
+//
+// Returns:
+//   - A copy of the levels per module name, empty before a logger is created
+func NamedLevels() map[string]zapcore.Level {
+	l := root.Load()
+	if l == nil {
+		return map[string]zapcore.Level{}
+	}
+
+	return l.levels.Overrides()
+}
+
+// ParseNamedLevels parses per-module levels in the LOG_LEVELS format.
+//
+// Parameters:
+//   - raw: The comma-separated list, e.g. "repository=debug,http=warn"
+//
+// Returns:
+//   - The levels per module name
+//   - An error if an item is malformed or names an unknown level
+func ParseNamedLevels(raw string) (map[string]zapcore.Level, error) {
+	return zapInstance.ParseLevels(raw)
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package ndjson provides a newline-delimited JSON file sink, independent of
+// OTLP, for on-host debugging and agents such as Vector or Fluent Bit tailing
+// files. Each entry is written as one JSON object per line, and the file rolls
+// over at a configured size into numbered backups (app.log.1 being the most
+// recent), keeping a fixed number of them, the way those agents expect
+// rotation by rename.
+package ndjson
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+	zapInstance "github.com/goxkit/logging/zap"
+)
+
+const (
+	// DefaultMaxBytes is the size at which the file rolls over when
+	// Config.MaxBytes is zero.
+	DefaultMaxBytes = 10 * 1024 * 1024
+	// DefaultMaxBackups is the number of rolled over files kept when
+	// Config.MaxBackups is zero.
+	DefaultMaxBackups = 5
+)
+
+// ErrMissingPath is returned when the sink is configured without a file path.
+var ErrMissingPath = errors.New("ndjson file path is required")
+
+// Config describes the NDJSON file sink and its rollover policy.
+type Config struct {
+	// Path is the file to write entries to. Backups are kept next to it as
+	// Path.1, Path.2 and so on. Required.
+	Path string
+	// MaxBytes is the size at which the file rolls over. An entry is never
+	// split across files. Defaults to DefaultMaxBytes.
+	MaxBytes int64
+	// MaxBackups is the number of rolled over files kept; older files are
+	// removed. Negative values keep none. Defaults to DefaultMaxBackups.
+	MaxBackups int
+	// EncoderOptions customizes the JSON encoder.
+	EncoderOptions zapInstance.EncoderOptions
+}
+
+// Writer is a zapcore.WriteSyncer appending to a file that rolls over at a
+// configured size. It is safe for concurrent use.
+type Writer struct {
+	mu   sync.Mutex
+	cfg  Config
+	file *os.File
+	size int64
+}
+
+// NewWriter opens, or creates, the file described by cfg.
+//
+// Parameters:
+//   - cfg: NDJSON sink configuration
+//
+// Returns:
+//   - A Writer appending to the file
+//   - ErrMissingPath without path, or an error if the file cannot be opened
+func NewWriter(cfg Config) (*Writer, error) {
+	if cfg.Path == "" {
+		return nil, ErrMissingPath
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = DefaultMaxBytes
+	}
+	if cfg.MaxBackups == 0 {
+		cfg.MaxBackups = DefaultMaxBackups
+	}
+
+	w := &Writer{cfg: cfg}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// NewCore creates a zapcore.Core writing entries at or above level as JSON
+// lines to the file described by cfg.
+//
+// Parameters:
+//   - cfg: NDJSON sink configuration
+//   - level: Minimum level written to the file
+//
+// Returns:
+//   - A zapcore.Core ready to be teed into a logger
+//   - An error if the file cannot be opened
+func NewCore(cfg Config, level zapcore.LevelEnabler) (zapcore.Core, error) {
+	w, err := NewWriter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	encoder := zapInstance.NewEncoderWithOptions(zapInstance.JSONEncoder, cfg.EncoderOptions)
+
+	return checked.NewCore(encoder, w, level), nil
+}
+
+// Write appends p to the file, rolling it over first when p would make it
+// exceed the configured size.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if w.size > 0 && w.size+int64(len(p)) > w.cfg.MaxBytes {
+		if err := w.rollover(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+
+	return n, err
+}
+
+// Sync commits the file to stable storage.
+func (w *Writer) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+
+	return w.file.Sync()
+}
+
+// Close closes the file. Further writes fail.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+
+	err := w.file.Close()
+	w.file = nil
+
+	return err
+}
+
+// open opens the file for appending and reads its current size.
+func (w *Writer) open() error {
+	f, err := os.OpenFile(w.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("ndjson file: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("ndjson file: %w", err)
+	}
+
+	w.file, w.size = f, info.Size()
+
+	return nil
+}
+
+// rollover shifts the backups, removing the oldest one, renames the file to
+// the first backup and opens a new file. The file is reopened even when the
+// backups cannot be shifted, so entries keep being written.
+func (w *Writer) rollover() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	w.file = nil
+
+	var err error
+	if w.cfg.MaxBackups > 0 {
+		_ = os.Remove(w.backup(w.cfg.MaxBackups))
+		for i := w.cfg.MaxBackups - 1; i > 0 && err == nil; i-- {
+			if rerr := os.Rename(w.backup(i), w.backup(i+1)); !errors.Is(rerr, os.ErrNotExist) {
+				err = rerr
+			}
+		}
+		if err == nil {
+			err = os.Rename(w.cfg.Path, w.backup(1))
+		}
+	} else {
+		err = os.Remove(w.cfg.Path)
+	}
+
+	return errors.Join(err, w.open())
+}
+
+// backup returns the path of the i-th most recent backup.
+func (w *Writer) backup(i int) string {
+	return fmt.Sprintf("%s.%d", w.cfg.Path, i)
+}
@@ -24,12 +24,13 @@ import (
 //
 // Parameters:
 //   - cfgs: Application configurations to use and update with the logger provider
+//   - opts: Additional zap options (hooks, fields, core wrappers, ...)
 //
 // Returns:
 //   - A configured zap.Logger instance
 //   - An error if logger initialization fails
-func Install(cfgs *configs.Configs) (*zap.Logger, error) {
+func Install(cfgs *configs.Configs, opts ...zap.Option) (*zap.Logger, error) {
 	provider := sdklog.NewLoggerProvider()
 	cfgs.LoggerProvider = provider
-	return zapInstance.NewStdoutZapLogger(cfgs)
+	return zapInstance.NewStdoutZapLogger(cfgs, opts...)
 }
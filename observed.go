@@ -0,0 +1,44 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	zapInstance "github.com/goxkit/logging/zap"
+)
+
+type (
+	// ObservedLogs is a concurrency-safe collection of the entries written
+	// to an observed logger.
+	ObservedLogs = observer.ObservedLogs
+
+	// LoggedEntry is an entry captured by an observed logger, together with
+	// all of its fields, including those added through With.
+	LoggedEntry = observer.LoggedEntry
+)
+
+// NewObservedLogger creates a Logger that keeps every entry in memory instead
+// of writing it anywhere. Unlike MockLogger, it lets tests assert which
+// messages, levels and fields were actually emitted:
+//
+//	logger, logs := logging.NewObservedLogger()
+//	service.Do(logger)
+//	entries := logs.FilterMessage("order created").FilterField(zap.String("id", "42")).All()
+//
+// All levels are recorded by default; the level can be raised through
+// AtomicLevel to verify filtering.
+//
+// Returns:
+//   - A Logger recording its entries
+//   - The ObservedLogs handle holding the recorded entries
+func NewObservedLogger() (Logger, *ObservedLogs) {
+	level := zap.NewAtomicLevelAt(zapcore.DebugLevel)
+	core, logs := observer.New(level)
+
+	return &logger{Logger: zap.New(core), levels: zapInstance.NewLevels(level, nil)}, logs
+}
@@ -0,0 +1,919 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/goxkit/configs"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/metric"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/async"
+	"github.com/goxkit/logging/dedup"
+	"github.com/goxkit/logging/file"
+	"github.com/goxkit/logging/limit"
+	"github.com/goxkit/logging/otlp"
+	"github.com/goxkit/logging/redact"
+	"github.com/goxkit/logging/telemetry"
+	zapInstance "github.com/goxkit/logging/zap"
+)
+
+type (
+	// Encoder identifies the output format of the local log output.
+	Encoder = zapInstance.Encoder
+
+	// Sampling caps the number of entries logged per tick, see WithSampling.
+	Sampling = zapInstance.Sampling
+
+	// SamplingRate is the per-tick rate used for a single level.
+	SamplingRate = zapInstance.SamplingRate
+
+	// RateLimits caps the entries written per logger name, see WithRateLimits.
+	RateLimits = zapInstance.RateLimits
+
+	// RateLimit is the token bucket of a logger name.
+	RateLimit = zapInstance.RateLimit
+
+	// Entry is the entry passed to the hooks registered with WithHook.
+	Entry = zapcore.Entry
+
+	// Record is an entry and its fields, as seen by a Processor.
+	Record = zapInstance.Record
+
+	// Processor transforms or filters the records before they are encoded and
+	// exported, see WithProcessors.
+	Processor = zapInstance.Processor
+
+	// ProcessorFunc adapts a function to a Processor.
+	ProcessorFunc = zapInstance.ProcessorFunc
+
+	// Metric declares an OpenTelemetry instrument derived from the entries, see
+	// WithMetrics.
+	Metric = zapInstance.Metric
+
+	// TimeFormat identifies the encoding of the entry timestamps, see WithTimeFormat.
+	TimeFormat = zapInstance.TimeFormat
+
+	// Theme customizes the colors of the console output, see WithConsoleTheme.
+	Theme = zapInstance.Theme
+
+	// Option configures the logger built by New.
+	Option func(*options)
+
+	// options holds the settings collected from the Option values passed to New.
+	options struct {
+		level        zapcore.Level
+		encoder      Encoder
+		output       io.Writer
+		sinks        []zapInstance.Sink
+		split        *zapcore.Level
+		serviceName  string
+		namespace    string
+		environment  string
+		otlpEndpoint string
+		otlpProtocol otlp.Protocol
+		otlpTLS      *tls.Config
+		otlpHeaders  map[string]string
+		otlpGzip     bool
+		otlpFailFast bool
+		otlpWriter   io.Writer
+		otlpFile     *otlp.FileExport
+		otlpLevel    zapcore.LevelEnabler
+		onExportErr  func(error, int)
+		startup      time.Duration
+		spill        *otlp.Spill
+		deadLetter   *otlp.DeadLetter
+		resource     []attribute.KeyValue
+		fields       []zap.Field
+		goroutineID  bool
+		noCaller     bool
+		stacktrace   *zapcore.Level
+		noStacktrace bool
+		callerSkip   int
+		encoderOpts  zapInstance.EncoderOptions
+		zapOpts      []zap.Option
+		hooks        []zapInstance.Hook
+		processors   []Processor
+		meters       metric.MeterProvider
+		errorMetrics bool
+		metrics      []Metric
+		telemetry    bool
+		batch        otlp.BatchConfig
+		files        []*file.Config
+		cores        []zapcore.Core
+		configSinks  []configSink
+		namedLevels  map[string]zapcore.Level
+		sampling     *Sampling
+		async        *async.Config
+		buffer       *zapInstance.Buffer
+		redaction    *redaction
+		limits       *limit.Config
+		dedup        *dedup.Config
+		rateLimits   *RateLimits
+	}
+
+	// redaction holds the redaction rules and the environments they apply to.
+	redaction struct {
+		cfg          redact.Config
+		environments []configs.Environment
+	}
+)
+
+const (
+	// ConsoleEncoder renders human-readable lines with colored levels.
+	ConsoleEncoder = zapInstance.ConsoleEncoder
+	// JSONEncoder renders one JSON object per entry, suited for machine parsing.
+	JSONEncoder = zapInstance.JSONEncoder
+	// GCPEncoder renders JSON in the Google Cloud Logging structured format.
+	GCPEncoder = zapInstance.GCPEncoder
+	// PrettyEncoder renders console lines followed by their fields as an
+	// indented, highlighted JSON block, for local development.
+	PrettyEncoder = zapInstance.PrettyEncoder
+
+	// ISO8601TimeFormat encodes timestamps as "2006-01-02T15:04:05.000Z0700".
+	ISO8601TimeFormat = zapInstance.ISO8601TimeFormat
+	// RFC3339TimeFormat encodes timestamps as "2006-01-02T15:04:05Z07:00".
+	RFC3339TimeFormat = zapInstance.RFC3339TimeFormat
+	// RFC3339NanoTimeFormat encodes timestamps as "2006-01-02T15:04:05.999999999Z07:00".
+	RFC3339NanoTimeFormat = zapInstance.RFC3339NanoTimeFormat
+	// EpochTimeFormat encodes timestamps as seconds since the Unix epoch.
+	EpochTimeFormat = zapInstance.EpochTimeFormat
+	// EpochMillisTimeFormat encodes timestamps as milliseconds since the Unix epoch.
+	EpochMillisTimeFormat = zapInstance.EpochMillisTimeFormat
+	// EpochNanosTimeFormat encodes timestamps as nanoseconds since the Unix epoch.
+	EpochNanosTimeFormat = zapInstance.EpochNanosTimeFormat
+
+	// CounterMetric counts the matching entries, or sums their Metric.Field.
+	CounterMetric = zapInstance.CounterMetric
+	// HistogramMetric records the distribution of the Metric.Field of the matching entries.
+	HistogramMetric = zapInstance.HistogramMetric
+)
+
+// WithLevel sets the minimum level of the entries that are logged. Defaults to Info.
+func WithLevel(level zapcore.Level) Option {
+	return func(o *options) {
+		o.level = level
+	}
+}
+
+// WithEncoder forces the output format of the local log output. When not set,
+// the format is derived from the environment: JSON for production and staging,
+// console for everything else.
+func WithEncoder(encoder Encoder) Option {
+	return func(o *options) {
+		o.encoder = encoder
+	}
+}
+
+// WithOutput sets the destination of the local log output. Defaults to os.Stdout.
+func WithOutput(w io.Writer) Option {
+	return func(o *options) {
+		o.output = w
+	}
+}
+
+// WithSink adds a local output with its own encoder and minimum level, e.g. a
+// console output for humans and a JSON output for a log shipper. It can be
+// provided multiple times; the sinks replace the output of WithOutput and
+// WithEncoder. The level applies on top of the levels of the logger, and can
+// be a zapcore.Level or any zapcore.LevelEnabler.
+func WithSink(w io.Writer, encoder Encoder, level zapcore.LevelEnabler) Option {
+	return func(o *options) {
+		o.sinks = append(o.sinks, zapInstance.Sink{Encoder: encoder, Output: zapcore.AddSync(w), Level: level})
+	}
+}
+
+// WithSplitOutput writes the entries below level to stdout and the others to
+// stderr, e.g. Debug and Info to stdout and Warn and above to stderr with
+// zapcore.WarnLevel, as expected by platforms classifying logs by stream. It
+// replaces the output of WithOutput and is ignored when WithSink is provided.
+func WithSplitOutput(level zapcore.Level) Option {
+	return func(o *options) {
+		o.split = &level
+	}
+}
+
+// WithoutCaller stops reporting the file and line of the logging call on each
+// entry.
+func WithoutCaller() Option {
+	return func(o *options) {
+		o.noCaller = true
+	}
+}
+
+// WithCallerSkip skips skip additional frames when reporting the caller, so
+// wrappers around the logger report the file and line of their own callers.
+func WithCallerSkip(skip int) Option {
+	return func(o *options) {
+		o.callerSkip = skip
+	}
+}
+
+// WithFullCallerPath reports the full path of the caller file instead of its
+// package directory and name.
+func WithFullCallerPath() Option {
+	return func(o *options) {
+		o.encoderOpts.FullCaller = true
+	}
+}
+
+// WithStacktraceLevel sets the level from which a stack trace is captured on
+// each entry, e.g. Fatal in production or Warn in development. Defaults to Error.
+func WithStacktraceLevel(level zapcore.Level) Option {
+	return func(o *options) {
+		o.stacktrace = &level
+	}
+}
+
+// WithoutStacktrace stops capturing stack traces, for services logging errors
+// on hot paths where their cost matters.
+func WithoutStacktrace() Option {
+	return func(o *options) {
+		o.noStacktrace = true
+	}
+}
+
+// WithTimeFormat sets the encoding of the timestamps of the local outputs,
+// e.g. EpochMillisTimeFormat for ingestion pipelines requiring epoch values.
+// Defaults to ISO8601, or RFC3339 with nanoseconds for the GCPEncoder.
+func WithTimeFormat(format TimeFormat) Option {
+	return func(o *options) {
+		o.encoderOpts.TimeFormat = format
+	}
+}
+
+// WithTimeKey sets the name of the timestamp field of the local outputs,
+// e.g. "@timestamp". Defaults to "ts", or "time" for the GCPEncoder.
+func WithTimeKey(key string) Option {
+	return func(o *options) {
+		o.encoderOpts.TimeKey = key
+	}
+}
+
+// WithConsoleTheme customizes the colors of the ConsoleEncoder outputs: the
+// color of each level, dimmed timestamps, logger names and callers, or no
+// colors at all. Colors are also disabled when NO_COLOR is set.
+func WithConsoleTheme(theme Theme) Option {
+	return func(o *options) {
+		o.encoderOpts.Theme = &theme
+	}
+}
+
+// WithHook calls hook with every entry at or above level written by the
+// logger, after sampling, deduplication and rate limits, enabling side effects
+// such as incrementing counters or tripping circuit breakers without writing a
+// core. Hooks run synchronously on the logging goroutine, unless WithAsync is
+// used; their errors are reported to the error output of the logger. It can be
+// provided multiple times.
+func WithHook(level zapcore.Level, hook func(Entry) error) Option {
+	return func(o *options) {
+		o.hooks = append(o.hooks, zapInstance.Hook{Level: level, Func: hook})
+	}
+}
+
+// WithProcessors appends processors to the chain transforming or filtering the
+// entries, e.g. adding fields derived from others, renaming keys or dropping
+// health-check noise. They run in order, on every field including those added
+// through With, before redaction, size limits and asynchronous writes, so the
+// fields they add are redacted too. It can be provided multiple times.
+func WithProcessors(processors ...Processor) Option {
+	return func(o *options) {
+		o.processors = append(o.processors, processors...)
+	}
+}
+
+// WithMeterProvider sets the meter provider of the metrics derived from the
+// entries, such as those of WithErrorMetrics. Defaults to the global meter
+// provider.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(o *options) {
+		o.meters = provider
+	}
+}
+
+// WithErrorMetrics counts the entries logged at Error level and above in the
+// log.errors_total OpenTelemetry counter, with the logger and service
+// attributes, giving an alertable error rate. Entries are counted even when
+// sampling, deduplication or rate limits drop them.
+func WithErrorMetrics() Option {
+	return func(o *options) {
+		o.errorMetrics = true
+	}
+}
+
+// WithMetrics declares OpenTelemetry counters and histograms derived from the
+// entries matching their level, message and filter, exported through the meter
+// provider of WithMeterProvider. It can be provided multiple times.
+//
+// Example:
+//
+//	logging.WithMetrics(
+//		logging.Metric{Name: "payments.declined", Message: "payment declined"},
+//		logging.Metric{Name: "http.latency", Kind: logging.HistogramMetric, Field: "latency_ms", Unit: "ms"},
+//	)
+func WithMetrics(metrics ...Metric) Option {
+	return func(o *options) {
+		o.metrics = append(o.metrics, metrics...)
+	}
+}
+
+// WithSelfTelemetry exposes the self-telemetry of the logging pipeline as
+// OpenTelemetry metrics of the meter provider of WithMeterProvider: the entries
+// emitted per level, the entries dropped by sampling, rate limits and
+// asynchronous queues, and the OTLP export batches, failures and retries, so
+// silent log loss can be detected. See the telemetry package, which also
+// publishes them through expvar.
+func WithSelfTelemetry() Option {
+	return func(o *options) {
+		o.telemetry = true
+	}
+}
+
+// WithOTLPSpill appends the log records that cannot be exported, while the
+// collector is unreachable, to a local file of at most maxBytes (zero for
+// otlp.DefaultSpillMaxBytes, 100 MiB), and replays them in order once an export
+// succeeds, so restarts of the collector do not lose logs. Records spilled
+// before a restart of the service are replayed by the next process.
+func WithOTLPSpill(path string, maxBytes int64) Option {
+	return func(o *options) {
+		o.spill = &otlp.Spill{Path: path, MaxBytes: maxBytes}
+	}
+}
+
+// WithOTLPDeadLetter writes the log records the collector rejects, e.g. for
+// their schema or size, to an NDJSON file at path with the reason of the
+// rejection, instead of dropping them silently. Without WithOTLPSpill, the
+// records that still fail once the exporter exhausted its retries are written
+// there too. The file is bounded by otlp.DefaultDeadLetterMaxBytes, and the
+// number of dead-lettered records is counted by the self-telemetry.
+func WithOTLPDeadLetter(path string) Option {
+	return func(o *options) {
+		o.deadLetter = &otlp.DeadLetter{Path: path}
+	}
+}
+
+// WithStartupTimeout bounds the startup of the OTLP export, i.e. the creation
+// of the exporter and the detection of the resource, so an unreachable
+// collector does not hang New. Defaults to otlp.DefaultStartupTimeout.
+func WithStartupTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.startup = timeout
+	}
+}
+
+// WithOnExportError sets a callback called with the error and the number of
+// records of every failed OTLP export, so exporter problems can be surfaced in
+// the application monitoring instead of only reaching the OpenTelemetry error
+// handler. It is called from the batch processor goroutine and must not block.
+func WithOnExportError(fn func(err error, records int)) Option {
+	return func(o *options) {
+		o.onExportErr = fn
+	}
+}
+
+// WithZapOptions applies additional zap options, such as zap.Hooks,
+// zap.Fields or zap.ErrorOutput, to the logger, after those derived from the
+// other options. Loggers wrapped with zap.WrapCore can no longer be reloaded.
+func WithZapOptions(opts ...zap.Option) Option {
+	return func(o *options) {
+		o.zapOpts = append(o.zapOpts, opts...)
+	}
+}
+
+// WithServiceName sets the logger name and the service.name resource attribute.
+func WithServiceName(name string) Option {
+	return func(o *options) {
+		o.serviceName = name
+	}
+}
+
+// WithNamespace sets the service.namespace resource attribute.
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithEnvironment sets the application environment (e.g. "development", "production"),
+// used to pick the default encoder and reported as a resource attribute.
+func WithEnvironment(env string) Option {
+	return func(o *options) {
+		o.environment = env
+	}
+}
+
+// WithOTLPEndpoint enables OTLP export to the collector at the given endpoint
+// (host:port or URL). Following the configs package defaults, the connection is insecure
+// unless WithOTLPTLS is set.
+func WithOTLPEndpoint(endpoint string) Option {
+	return func(o *options) {
+		o.otlpEndpoint = endpoint
+	}
+}
+
+// WithOTLPProtocol selects the OTLP transport, otlp.GRPCProtocol or
+// otlp.HTTPProtobufProtocol. Defaults to the protocol set in the
+// OTEL_EXPORTER_OTLP_PROTOCOL environment variable, or gRPC.
+func WithOTLPProtocol(protocol otlp.Protocol) Option {
+	return func(o *options) {
+		o.otlpProtocol = protocol
+	}
+}
+
+// WithOTLPStdout replaces the OTLP exporter with one writing the records that
+// would be exported as indented JSON to w, or to the standard output when w is
+// nil, so developers can see the exact attributes, severity and resource of the
+// exported records without running a collector. No endpoint is needed.
+func WithOTLPStdout(w io.Writer) Option {
+	return func(o *options) {
+		o.otlpProtocol = otlp.StdoutProtocol
+		o.otlpWriter = w
+	}
+}
+
+// WithOTLPFile replaces the OTLP exporter with one writing the records as OTLP
+// export requests, in OTLP/JSON lines or length-prefixed protobuf, to the
+// rotating file described by cfg, for air-gapped environments where the file is
+// shipped to a collector later. No endpoint is needed.
+func WithOTLPFile(cfg otlp.FileExport) Option {
+	return func(o *options) {
+		o.otlpProtocol = otlp.FileProtocol
+		o.otlpFile = &cfg
+	}
+}
+
+// WithOTLPTLS secures the connection to the collector with the given CA bundle
+// and client certificates (mTLS), as built by otlp.NewTLSConfig.
+func WithOTLPTLS(cfg *tls.Config) Option {
+	return func(o *options) {
+		o.otlpTLS = cfg
+	}
+}
+
+// WithOTLPGzip compresses the OTLP export requests with gzip, over gRPC and
+// HTTP, reducing the egress of verbose services shipping to remote collectors
+// at the cost of some CPU.
+func WithOTLPGzip() Option {
+	return func(o *options) {
+		o.otlpGzip = true
+	}
+}
+
+// WithOTLPFailFast makes New fail when the collector is not reachable within
+// the startup timeout, instead of falling back to the local outputs until it
+// is. Configuration errors, such as an invalid endpoint URL, always fail New.
+func WithOTLPFailFast() Option {
+	return func(o *options) {
+		o.otlpFailFast = true
+	}
+}
+
+// WithOTLPHeaders sets static headers sent with every export request, such as
+// the "Authorization" header required by SaaS OTLP endpoints.
+func WithOTLPHeaders(headers map[string]string) Option {
+	return func(o *options) {
+		if o.otlpHeaders == nil {
+			o.otlpHeaders = map[string]string{}
+		}
+		for k, v := range headers {
+			o.otlpHeaders[k] = v
+		}
+	}
+}
+
+// WithOTLPLevel sets the minimum level of the entries exported to the
+// collector, e.g. Warn to keep the export volume low while the local output
+// stays at Debug. It applies on top of the levels of the logger.
+func WithOTLPLevel(level zapcore.Level) Option {
+	return func(o *options) {
+		o.otlpLevel = level
+	}
+}
+
+// WithResourceAttributes adds attributes such as team, region, cluster or version
+// to the OTLP resource, next to the service name, namespace and environment.
+func WithResourceAttributes(attrs ...attribute.KeyValue) Option {
+	return func(o *options) {
+		o.resource = append(o.resource, attrs...)
+	}
+}
+
+// WithBatch tunes the batch processor of the OTLP export: larger queues and
+// batches for high-volume services, shorter intervals for short-lived processes.
+func WithBatch(batch otlp.BatchConfig) Option {
+	return func(o *options) {
+		o.batch = batch
+	}
+}
+
+// WithSampling caps the logging volume of high-QPS services. The same sampling
+// decision applies to the local output and the OTLP export, and entries at Error
+// level and above are never sampled unless a rate is set for them in Levels.
+func WithSampling(sampling Sampling) Option {
+	return func(o *options) {
+		o.sampling = &sampling
+	}
+}
+
+// WithBufferedOutput buffers the writes to the local outputs and the files,
+// flushing them when size bytes are buffered, every interval, on Sync and after
+// every entry above Error level, which reduces the syscalls of services logging
+// thousands of lines per second. Zero values default to 256 KiB and one
+// second. Shutdown flushes the buffers; entries still buffered when the process
+// exits without it are lost.
+func WithBufferedOutput(size int, interval time.Duration) Option {
+	return func(o *options) {
+		o.buffer = &zapInstance.Buffer{Size: size, FlushInterval: interval}
+	}
+}
+
+// WithAsync moves the writes to every output to a background goroutine fed by
+// a bounded buffer. When the buffer is full, cfg.Policy drops entries or makes
+// the caller wait; cfg.OnDrop can count the dropped entries. Sync and Shutdown
+// wait for the buffered entries to be written.
+func WithAsync(cfg async.Config) Option {
+	return func(o *options) {
+		o.async = &cfg
+	}
+}
+
+// WithRedaction masks sensitive fields, as described by cfg, before entries reach
+// the local output or the OTLP exporter. When environments are given, redaction
+// only applies when the logger runs in one of them (e.g. "staging", "production").
+// Use redact.DefaultConfig() for a sensible set of rules.
+func WithRedaction(cfg redact.Config, environments ...string) Option {
+	return func(o *options) {
+		envs := make([]configs.Environment, 0, len(environments))
+		for _, env := range environments {
+			envs = append(envs, configs.NewEnvironment(env))
+		}
+
+		o.redaction = &redaction{cfg: cfg, environments: envs}
+	}
+}
+
+// WithLimits truncates messages, field values and entries larger than the
+// limits of cfg, marking them with a truncated=true field, so a single giant
+// payload cannot blow up the exporter or the collector. Truncation happens after
+// redaction. Use limit.DefaultConfig() for sensible limits.
+func WithLimits(cfg limit.Config) Option {
+	return func(o *options) {
+		o.limits = &cfg
+	}
+}
+
+// WithDedup collapses identical entries (same level, logger, message and
+// cfg.Fingerprint fields) logged within cfg.Window: the first one is written
+// and the repeats are summarized by a single entry carrying a repeat_count field.
+func WithDedup(cfg dedup.Config) Option {
+	return func(o *options) {
+		o.dedup = &cfg
+	}
+}
+
+// WithRateLimits caps the entries written by each logger name with a token
+// bucket; the excess is dropped and periodically reported by a warning holding
+// the suppressed count. The limits are merged over those of LOG_RATE_LIMITS.
+// Entries at Error level and above are never rate limited.
+func WithRateLimits(limits RateLimits) Option {
+	return func(o *options) {
+		o.rateLimits = &limits
+	}
+}
+
+// WithFile additionally writes entries to a rotating file. It can be provided
+// multiple times to write to several files.
+func WithFile(cfg file.Config) Option {
+	return func(o *options) {
+		o.files = append(o.files, &cfg)
+	}
+}
+
+// WithNamedLevels sets the minimum level of the subloggers created with Named,
+// keyed by module name. They take precedence over the LOG_LEVELS variable.
+func WithNamedLevels(levels map[string]zapcore.Level) Option {
+	return func(o *options) {
+		if o.namedLevels == nil {
+			o.namedLevels = map[string]zapcore.Level{}
+		}
+		for name, level := range levels {
+			o.namedLevels[name] = level
+		}
+	}
+}
+
+// WithCores tees additional cores, such as the syslog sink, into the logger.
+// The runtime level, sampling and redaction of the logger apply to them too.
+func WithCores(cores ...zapcore.Core) Option {
+	return func(o *options) {
+		o.cores = append(o.cores, cores...)
+	}
+}
+
+// New creates a logger configured through functional options, without requiring
+// the goxkit configs package. It is intended for applications that already have
+// their own configuration system and only need the Zap and OTLP wiring.
+//
+// When WithOTLPEndpoint is provided, entries are also exported to the collector
+// and the logger provider is registered globally; otherwise only the local
+// output is used.
+//
+// Parameters:
+//   - opts: Options customizing the logger
+//
+// Returns:
+//   - A configured Logger implementation
+//   - An error if logger initialization fails
+func New(opts ...Option) (Logger, error) {
+	o := &options{level: zapcore.InfoLevel, otlpProtocol: otlp.ProtocolFromEnv()}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	namedLevels, err := zapInstance.LevelsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	for name, lvl := range o.namedLevels {
+		namedLevels[name] = lvl
+	}
+
+	level := zap.NewAtomicLevelAt(o.level)
+	levels := zapInstance.NewLevels(level, namedLevels)
+
+	env := configs.NewEnvironment(o.environment)
+
+	var provider *sdklog.LoggerProvider
+	if o.otlpEndpoint != "" || o.otlpProtocol == otlp.StdoutProtocol || o.otlpProtocol == otlp.FileProtocol {
+		ctx, cancel := otlp.WithStartupTimeout(context.Background(), o.startup)
+		defer cancel()
+
+		provider, err = otlp.NewProvider(ctx, &otlp.Config{
+			Protocol:           o.otlpProtocol,
+			Endpoint:           o.otlpEndpoint,
+			Writer:             o.otlpWriter,
+			File:               o.otlpFile,
+			TLSConfig:          o.otlpTLS,
+			Headers:            o.otlpHeaders,
+			Gzip:               o.otlpGzip,
+			FailFast:           o.otlpFailFast,
+			ServiceName:        o.serviceName,
+			ServiceNamespace:   o.namespace,
+			Environment:        env.ToString(),
+			ResourceAttributes: o.resource,
+			Batch:              o.batch,
+			OnExportError:      o.onExportErr,
+			Spill:              o.spill,
+			DeadLetter:         o.deadLetter,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	built := newOutputs()
+
+	// The provider is only installed once the logger is built; on failure it
+	// is shut down so its exporter and batch goroutines do not outlive New,
+	// and the outputs already opened are closed.
+	fail := func(err error) (Logger, error) {
+		if provider != nil {
+			_ = provider.Shutdown(context.Background())
+		}
+		_ = built.close()
+		return nil, err
+	}
+
+	if o.telemetry {
+		meters := o.meters
+		if meters == nil {
+			meters = otel.GetMeterProvider()
+		}
+		if err := telemetry.Register(meters); err != nil {
+			return fail(err)
+		}
+	}
+
+	cfg, err := o.pipeline(levels, provider, built)
+	if err != nil {
+		return fail(err)
+	}
+
+	z, err := zapInstance.New(cfg, o.zapOptions()...)
+	if err != nil {
+		return fail(err)
+	}
+
+	if provider != nil {
+		global.SetLoggerProvider(provider)
+	}
+
+	if len(o.fields) > 0 {
+		z = z.With(o.fields...)
+	}
+
+	l := newLogger(z, provider)
+	l.reload = &reloadState{options: o, outputs: built, sinks: o.configSinks}
+	setRoot(l)
+
+	return l, nil
+}
+
+// pipeline returns the description of the pipeline configured by o, below the
+// levels and the OTLP provider created by New. The file and sink cores already
+// built are taken from built, which receives the new ones, so a reload does not
+// reopen them.
+func (o *options) pipeline(
+	levels *zapInstance.Levels,
+	provider *sdklog.LoggerProvider,
+	built *outputs,
+) (*zapInstance.Config, error) {
+	env := configs.NewEnvironment(o.environment)
+
+	encoder := o.encoder
+	if encoder == "" {
+		encoder = zapInstance.EncoderForEnvironment(env)
+	}
+
+	var output zapcore.WriteSyncer
+	if o.output != nil {
+		output = zapcore.AddSync(o.output)
+	}
+
+	sinks := o.sinks
+	if len(sinks) == 0 && o.split != nil {
+		split := *o.split
+		sinks = []zapInstance.Sink{
+			{
+				Encoder: encoder,
+				Output:  zapcore.Lock(os.Stdout),
+				Level:   zap.LevelEnablerFunc(func(l zapcore.Level) bool { return l < split }),
+			},
+			{Encoder: encoder, Output: zapcore.Lock(os.Stderr), Level: split},
+		}
+	}
+
+	rateLimits, err := zapInstance.RateLimitsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if o.rateLimits != nil {
+		rateLimits = mergeRateLimits(rateLimits, o.rateLimits)
+	}
+
+	var redactor *redact.Redactor
+	if o.redaction != nil && o.redaction.appliesTo(env) {
+		redactor = redact.New(o.redaction.cfg)
+	}
+
+	cores := make([]zapcore.Core, 0, len(o.files)+len(o.cores)+len(o.configSinks))
+	for _, f := range o.files {
+		core, ok := built.files[f]
+		if !ok {
+			var level zapcore.LevelEnabler = levels
+			if f.Level != nil {
+				level = f.Level
+			}
+
+			fc := *f
+			if fc.EncoderOptions == (zapInstance.EncoderOptions{}) {
+				fc.EncoderOptions = o.encoderOpts
+			}
+			if fc.Buffer == nil {
+				fc.Buffer = o.buffer
+			}
+
+			core = file.NewCore(&fc, level)
+			built.files[f] = core
+		}
+		cores = append(cores, core)
+	}
+	cores = append(cores, o.cores...)
+
+	for i, s := range o.configSinks {
+		core, ok := built.sinks[s]
+		if !ok {
+			if core, err = s.sink.core(s.encoderOpts); err != nil {
+				return nil, fmt.Errorf("invalid sink %d (%s): %w", i, s.sink.Type, err)
+			}
+			built.sinks[s] = core
+		}
+		cores = append(cores, core)
+	}
+
+	var errorMetrics *zapInstance.ErrorMetrics
+	if o.errorMetrics {
+		errorMetrics = &zapInstance.ErrorMetrics{}
+	}
+
+	return &zapInstance.Config{
+		Name:           o.serviceName,
+		Levels:         levels,
+		Encoder:        encoder,
+		Output:         output,
+		EncoderOptions: o.encoderOpts,
+		Sinks:          sinks,
+		Provider:       provider,
+		OTLPLevel:      o.otlpLevel,
+		Cores:          cores,
+		Hooks:          o.hooks,
+		Processors:     o.processors,
+		Sampling:       o.sampling,
+		Redactor:       redactor,
+		Limits:         o.limits,
+		Dedup:          o.dedup,
+		RateLimits:     rateLimits,
+		Async:          o.async,
+		Buffer:         o.buffer,
+		GoroutineID:    o.goroutineID,
+		ErrorMetrics:   errorMetrics,
+		Metrics:        o.metrics,
+		MeterProvider:  o.meters,
+		Telemetry:      o.telemetry,
+	}, nil
+}
+
+// zapOptions returns the options of the zap.Logger created by New.
+func (o *options) zapOptions() []zap.Option {
+	var opts []zap.Option
+
+	if !o.noStacktrace {
+		level := zapcore.ErrorLevel
+		if o.stacktrace != nil {
+			level = *o.stacktrace
+		}
+		opts = append(opts, zap.AddStacktrace(level))
+	}
+
+	if !o.noCaller {
+		opts = append(opts, zap.AddCaller())
+	}
+
+	if o.callerSkip != 0 {
+		opts = append(opts, zap.AddCallerSkip(o.callerSkip))
+	}
+
+	return append(opts, o.zapOpts...)
+}
+
+// clone returns a copy of o whose slices and maps can be extended without
+// affecting o.
+func (o *options) clone() *options {
+	c := *o
+	c.sinks = append([]zapInstance.Sink(nil), o.sinks...)
+	c.files = append([]*file.Config(nil), o.files...)
+	c.cores = append([]zapcore.Core(nil), o.cores...)
+	c.configSinks = append([]configSink(nil), o.configSinks...)
+	c.hooks = append([]zapInstance.Hook(nil), o.hooks...)
+	c.processors = append([]Processor(nil), o.processors...)
+	c.metrics = append([]Metric(nil), o.metrics...)
+	c.namedLevels = maps.Clone(o.namedLevels)
+
+	return &c
+}
+
+// mergeRateLimits returns the limits of opt merged over those of env.
+func mergeRateLimits(env, opt *RateLimits) *RateLimits {
+	if env == nil {
+		return opt
+	}
+
+	merged := *opt
+	if merged.Default == (RateLimit{}) {
+		merged.Default = env.Default
+	}
+
+	merged.Names = make(map[string]RateLimit, len(env.Names)+len(opt.Names))
+	for name, limit := range env.Names {
+		merged.Names[name] = limit
+	}
+	for name, limit := range opt.Names {
+		merged.Names[name] = limit
+	}
+
+	return &merged
+}
+
+// appliesTo reports whether the redaction rules are enabled for env.
+func (r *redaction) appliesTo(env configs.Environment) bool {
+	if len(r.environments) == 0 {
+		return true
+	}
+
+	return slices.Contains(r.environments, env)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+)
+
+var (
+	// cgroupIDPattern matches the container ID at the end of a cgroup path, e.g.
+	// "/docker/<id>", "/kubepods/burstable/pod<uid>/<id>" or
+	// "/system.slice/cri-containerd-<id>.scope".
+	cgroupIDPattern = regexp.MustCompile(`([0-9a-f]{64})(?:\.scope)?$`)
+
+	// mountinfoIDPattern matches the container ID in the mount sources of the
+	// hostname, hosts and resolv.conf files set up by Docker and Podman.
+	mountinfoIDPattern = regexp.MustCompile(`/(?:docker/containers|overlay-containers)/([0-9a-f]{64})/`)
+
+	// containerID caches the detected container ID.
+	containerID = sync.OnceValue(detectContainerID)
+)
+
+// ContainerID returns the ID of the container the process runs in, detected
+// from /proc/self/cgroup (cgroup v1 and systemd cgroup v2 layouts) and, failing
+// that, from /proc/self/mountinfo (cgroup v2 with Docker or Podman). It is
+// detected once and added to the resource as container.id.
+//
+// Returns:
+//   - The container ID, or an empty string outside a container or on non-Linux systems
+func ContainerID() string {
+	return containerID()
+}
+
+// detectContainerID reads the container ID from the proc filesystem.
+func detectContainerID() string {
+	for _, source := range []struct {
+		path  string
+		parse func(io.Reader) string
+	}{
+		{"/proc/self/cgroup", parseCgroupContainerID},
+		{"/proc/self/mountinfo", parseMountinfoContainerID},
+	} {
+		f, err := os.Open(source.path)
+		if err != nil {
+			continue
+		}
+
+		id := source.parse(f)
+		_ = f.Close()
+
+		if id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// parseCgroupContainerID returns the container ID found in the paths of a
+// /proc/self/cgroup file.
+func parseCgroupContainerID(r io.Reader) string {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if m := cgroupIDPattern.FindStringSubmatch(scanner.Text()); m != nil {
+			return m[1]
+		}
+	}
+
+	return ""
+}
+
+// parseMountinfoContainerID returns the container ID found in the mount sources
+// of a /proc/self/mountinfo file.
+func parseMountinfoContainerID(r io.Reader) string {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if m := mountinfoIDPattern.FindStringSubmatch(scanner.Text()); m != nil {
+			return m[1]
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/goxkit/logging/telemetry"
+)
+
+// DefaultDeadLetterMaxBytes bounds the dead-letter file when DeadLetter.MaxBytes is zero.
+const DefaultDeadLetterMaxBytes = 100 * 1024 * 1024
+
+var (
+	// errDeadLetterFull is reported when rejected records are dropped because
+	// the dead-letter file is full.
+	errDeadLetterFull = errors.New("otlp dead-letter file full, dropping rejected log records")
+
+	// httpStatusPattern matches the status code of the errors returned by the
+	// HTTP exporter for the responses it does not retry.
+	httpStatusPattern = regexp.MustCompile(`failed to send logs to \S+: (\d{3}) `)
+
+	// httpRetryablePattern matches the errors of the HTTP exporter for the
+	// responses and connection failures it retries.
+	httpRetryablePattern = regexp.MustCompile(`retry-able request failure`)
+)
+
+type (
+	// DeadLetter configures a file receiving the batches the exporter gives up
+	// on, with the reason of the rejection, instead of dropping them silently.
+	// Batches the collector rejects as invalid, e.g. for their schema or size,
+	// are always dead-lettered, as sending them again cannot succeed. Batches
+	// that still fail once the exporter exhausted its retries are dead-lettered
+	// too, unless a Spill keeps them for a later replay.
+	DeadLetter struct {
+		// Path is the dead-letter file, written as NDJSON. Required.
+		Path string
+		// MaxBytes bounds the size of the dead-letter file; further records are
+		// dropped. Defaults to DefaultDeadLetterMaxBytes.
+		MaxBytes int64
+	}
+
+	// deadLetterExporter writes the batches its exporter fails to export to a
+	// dead-letter file.
+	deadLetterExporter struct {
+		sdklog.Exporter
+		cfg DeadLetter
+		// spilled is set when a spill keeps the batches failing transiently.
+		spilled bool
+
+		mu   sync.Mutex
+		file *os.File
+		size int64
+		full bool
+	}
+
+	// deadLetterRecord is the form of a record in the dead-letter file.
+	deadLetterRecord struct {
+		Reason       string    `json:"reason"`
+		DeadLettered time.Time `json:"dead_lettered_at"`
+		spilledRecord
+	}
+)
+
+// newDeadLetterExporter wraps exp, writing to the file of cfg the batches it
+// fails to export. When spilled is set, only the rejected batches are written.
+func newDeadLetterExporter(exp sdklog.Exporter, cfg DeadLetter, spilled bool) (*deadLetterExporter, error) {
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = DefaultDeadLetterMaxBytes
+	}
+
+	f, err := os.OpenFile(cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("otlp dead-letter file: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("otlp dead-letter file: %w", err)
+	}
+
+	return &deadLetterExporter{
+		Exporter: exp,
+		cfg:      cfg,
+		spilled:  spilled,
+		file:     f,
+		size:     info.Size(),
+	}, nil
+}
+
+// Export exports the records, dead-lettering them when the export fails. The
+// export only fails when the records are kept by the spill, as the failure is
+// retryable, or cannot be dead-lettered.
+func (e *deadLetterExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	err := e.Exporter.Export(ctx, records)
+	if err == nil {
+		return nil
+	}
+
+	if e.spilled && retryable(err) {
+		return err
+	}
+
+	if dlErr := e.write(records, err); dlErr != nil {
+		return errors.Join(err, dlErr)
+	}
+
+	return nil
+}
+
+// Shutdown closes the dead-letter file and shuts the exporter down.
+func (e *deadLetterExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	err := e.file.Close()
+	e.mu.Unlock()
+
+	return errors.Join(err, e.Exporter.Shutdown(ctx))
+}
+
+// write appends records to the dead-letter file with the reason of their
+// rejection, dropping them once it is full.
+func (e *deadLetterExporter) write(records []sdklog.Record, reason error) error {
+	now := time.Now()
+
+	var buf []byte
+	for i := range records {
+		line, err := json.Marshal(deadLetterRecord{
+			Reason:        reason.Error(),
+			DeadLettered:  now,
+			spilledRecord: newSpilledRecord(&records[i]),
+		})
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.size+int64(len(buf)) > e.cfg.MaxBytes {
+		if !e.full {
+			e.full = true
+			otel.Handle(errDeadLetterFull)
+		}
+		return nil
+	}
+
+	n, err := e.file.Write(buf)
+	e.size += int64(n)
+	if err != nil {
+		return err
+	}
+
+	telemetry.AddDeadLettered(len(records))
+
+	return nil
+}
+
+// Rejected reports whether err is a rejection of the export request by the
+// collector that sending it again cannot fix, e.g. a malformed or oversized
+// batch: a gRPC InvalidArgument, FailedPrecondition or Unimplemented status, a
+// ResourceExhausted status without retry information, or an HTTP 4xx response
+// other than 408 Request Timeout and 429 Too Many Requests.
+//
+// Parameters:
+//   - err: An error returned by an OTLP exporter
+//
+// Returns:
+//   - true if the request was rejected permanently
+func Rejected(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.InvalidArgument, codes.FailedPrecondition, codes.Unimplemented:
+			return true
+		case codes.ResourceExhausted:
+			return len(s.Details()) == 0
+		}
+		return false
+	}
+
+	m := httpStatusPattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return false
+	}
+
+	code, _ := strconv.Atoi(m[1])
+	switch code {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+
+	return code >= 400 && code < 500
+}
+
+// retryable reports whether exporting the records of a failed export again may
+// succeed: the collector was unreachable, overloaded or too slow to answer.
+func retryable(err error) bool {
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Canceled, codes.DeadlineExceeded, codes.Aborted, codes.OutOfRange,
+			codes.Unavailable, codes.DataLoss:
+			return true
+		case codes.ResourceExhausted:
+			return len(s.Details()) > 0
+		}
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	if httpRetryablePattern.MatchString(err.Error()) {
+		return true
+	}
+
+	m := httpStatusPattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return false
+	}
+
+	code, _ := strconv.Atoi(m[1])
+
+	return code == http.StatusRequestTimeout || code >= http.StatusInternalServerError
+}
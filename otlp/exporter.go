@@ -0,0 +1,195 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"google.golang.org/grpc/credentials"
+)
+
+// Protocol identifies the transport used to export logs to the collector.
+type Protocol string
+
+const (
+	// GRPCProtocol exports logs using OTLP over gRPC (default collector port 4317).
+	GRPCProtocol Protocol = "grpc"
+	// HTTPProtobufProtocol exports logs using OTLP over HTTP with protobuf
+	// payloads (default collector port 4318).
+	HTTPProtobufProtocol Protocol = "http/protobuf"
+	// StdoutProtocol writes the records that would be exported as indented
+	// JSON instead of sending them to a collector, to debug the pipeline: the
+	// attributes, severity and resource of each record are visible without
+	// running a collector.
+	StdoutProtocol Protocol = "stdout"
+	// FileProtocol writes the records as OTLP export requests to a rotating
+	// file described by Config.File, shipped to a collector later, e.g. by its
+	// filelog or otlpjsonfile receiver.
+	FileProtocol Protocol = "file"
+)
+
+const (
+	// ProtocolEnvKey is the standard OpenTelemetry variable selecting the export protocol.
+	ProtocolEnvKey = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	// LogsProtocolEnvKey overrides ProtocolEnvKey for the logs signal only.
+	LogsProtocolEnvKey = "OTEL_EXPORTER_OTLP_LOGS_PROTOCOL"
+)
+
+// defaultHTTPLogsPath is the collector path receiving OTLP/HTTP log requests.
+const defaultHTTPLogsPath = "/v1/logs"
+
+// ProtocolFromEnv reads the export protocol from OTEL_EXPORTER_OTLP_LOGS_PROTOCOL,
+// falling back to OTEL_EXPORTER_OTLP_PROTOCOL. It returns GRPCProtocol when none
+// is set or the value is not supported.
+func ProtocolFromEnv() Protocol {
+	value := os.Getenv(LogsProtocolEnvKey)
+	if value == "" {
+		value = os.Getenv(ProtocolEnvKey)
+	}
+
+	if Protocol(strings.TrimSpace(value)) == HTTPProtobufProtocol {
+		return HTTPProtobufProtocol
+	}
+
+	return GRPCProtocol
+}
+
+// ParseHeaders parses headers in the OTEL_EXPORTER_OTLP_HEADERS format
+// (key1=value1,key2=value2), ignoring malformed pairs.
+func ParseHeaders(raw string) map[string]string {
+	headers := map[string]string{}
+
+	for _, kv := range strings.Split(raw, ",") {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		key := strings.TrimSpace(parts[0])
+		if key != "" {
+			headers[key] = strings.TrimSpace(parts[1])
+		}
+	}
+
+	return headers
+}
+
+// newExporter creates the log exporter for the protocol selected in cfg,
+// counting its requests in the self-telemetry and recording their outcome in
+// exports.
+func newExporter(ctx context.Context, cfg *Config, exports *exportState) (sdklog.Exporter, error) {
+	var (
+		exp sdklog.Exporter
+		err error
+	)
+	switch cfg.Protocol {
+	case HTTPProtobufProtocol:
+		exp, err = newHTTPExporter(ctx, cfg)
+	case StdoutProtocol:
+		exp, err = newStdoutExporter(cfg)
+	case FileProtocol:
+		exp, err = newFileExporter(cfg)
+	default:
+		exp, err = newGRPCExporter(ctx, cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &countingExporter{Exporter: exp, exports: exports, onError: cfg.OnExportError}, nil
+}
+
+// newStdoutExporter creates an exporter writing the records as indented JSON to
+// cfg.Writer, or to the standard output.
+func newStdoutExporter(cfg *Config) (sdklog.Exporter, error) {
+	opts := []stdoutlog.Option{stdoutlog.WithPrettyPrint()}
+
+	if cfg.Writer != nil {
+		opts = append(opts, stdoutlog.WithWriter(cfg.Writer))
+	}
+
+	return stdoutlog.New(opts...)
+}
+
+// newGRPCExporter creates an OTLP/gRPC log exporter, reusing cfg.Conn when provided.
+func newGRPCExporter(ctx context.Context, cfg *Config) (sdklog.Exporter, error) {
+	if cfg.Conn != nil {
+		return otlploggrpc.New(ctx, otlploggrpc.WithGRPCConn(cfg.Conn))
+	}
+
+	opts := []otlploggrpc.Option{}
+
+	if strings.Contains(cfg.Endpoint, "://") {
+		opts = append(opts, otlploggrpc.WithEndpointURL(cfg.Endpoint))
+	} else {
+		opts = append(opts, otlploggrpc.WithEndpoint(cfg.Endpoint))
+	}
+
+	switch {
+	case cfg.TLSConfig != nil:
+		opts = append(opts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(cfg.TLSConfig)))
+	case !cfg.TLSEnabled:
+		opts = append(opts, otlploggrpc.WithInsecure())
+	}
+
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlploggrpc.WithHeaders(cfg.Headers))
+	}
+
+	if cfg.Gzip {
+		opts = append(opts, otlploggrpc.WithCompressor("gzip"))
+	}
+
+	if cfg.Timeout > 0 {
+		opts = append(opts, otlploggrpc.WithTimeout(cfg.Timeout))
+	}
+
+	return otlploggrpc.New(ctx, opts...)
+}
+
+// newHTTPExporter creates an OTLP/HTTP log exporter with protobuf payloads.
+func newHTTPExporter(ctx context.Context, cfg *Config) (sdklog.Exporter, error) {
+	opts := []otlploghttp.Option{}
+
+	if strings.Contains(cfg.Endpoint, "://") {
+		opts = append(opts, otlploghttp.WithEndpointURL(cfg.Endpoint))
+
+		// A base URL without path targets the default logs path, as the
+		// OTEL_EXPORTER_OTLP_ENDPOINT variable does.
+		if u, err := url.Parse(cfg.Endpoint); err == nil && strings.Trim(u.Path, "/") == "" {
+			opts = append(opts, otlploghttp.WithURLPath(defaultHTTPLogsPath))
+		}
+	} else {
+		opts = append(opts, otlploghttp.WithEndpoint(cfg.Endpoint))
+	}
+
+	switch {
+	case cfg.TLSConfig != nil:
+		opts = append(opts, otlploghttp.WithTLSClientConfig(cfg.TLSConfig))
+	case !cfg.TLSEnabled:
+		opts = append(opts, otlploghttp.WithInsecure())
+	}
+
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlploghttp.WithHeaders(cfg.Headers))
+	}
+
+	if cfg.Gzip {
+		opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+	}
+
+	if cfg.Timeout > 0 {
+		opts = append(opts, otlploghttp.WithTimeout(cfg.Timeout))
+	}
+
+	return otlploghttp.New(ctx, opts...)
+}
@@ -0,0 +1,334 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	collogpb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logpb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/goxkit/logging/file"
+)
+
+// Formats of the OTLP file export.
+const (
+	// JSONFileFormat writes one OTLP/JSON ExportLogsServiceRequest per line,
+	// as read by the otlpjsonfile receiver of the collector.
+	JSONFileFormat FileFormat = "json"
+	// ProtobufFileFormat writes binary ExportLogsServiceRequest messages, each
+	// preceded by its length as a 4-byte big-endian unsigned integer.
+	ProtobufFileFormat FileFormat = "protobuf"
+)
+
+// errFileExportPath is returned when FileProtocol is selected without a file path.
+var errFileExportPath = errors.New("otlp file export requires a path")
+
+type (
+	// FileFormat is the encoding of the records written by the file export.
+	FileFormat string
+
+	// FileExport configures the export to a rotating file with FileProtocol,
+	// for air-gapped environments where the file is shipped to a collector
+	// later. Each export request is written whole, so rotated files never
+	// split a request.
+	FileExport struct {
+		// Path is the file to write the records to. Backups are kept in the
+		// same directory. Required.
+		Path string
+		// Format selects the encoding of the records. Defaults to JSONFileFormat.
+		Format FileFormat
+		// MaxSizeMB is the maximum size in megabytes of the file before it gets
+		// rotated. Defaults to 100 megabytes.
+		MaxSizeMB int
+		// MaxAgeDays is the maximum number of days to retain old files. Zero
+		// means files are not removed based on age.
+		MaxAgeDays int
+		// MaxBackups is the maximum number of old files to retain. Zero means
+		// all backups are retained (subject to MaxAgeDays).
+		MaxBackups int
+		// Compress determines if the rotated files should be gzip compressed.
+		Compress bool
+	}
+
+	// fileExporter writes the records as OTLP export requests to a file.
+	fileExporter struct {
+		mu     sync.Mutex
+		out    zapcore.WriteSyncer
+		close  func() error
+		format FileFormat
+	}
+)
+
+// newFileExporter creates an exporter writing the records to the rotating file
+// of cfg.File.
+func newFileExporter(cfg *Config) (sdklog.Exporter, error) {
+	if cfg.File == nil || cfg.File.Path == "" {
+		return nil, errFileExportPath
+	}
+
+	format := cfg.File.Format
+	switch format {
+	case "":
+		format = JSONFileFormat
+	case JSONFileFormat, ProtobufFileFormat:
+	default:
+		return nil, fmt.Errorf("otlp file export: unsupported format %q", format)
+	}
+
+	out, closeFile := file.NewWriteCloser(&file.Config{
+		Path:       cfg.File.Path,
+		MaxSizeMB:  cfg.File.MaxSizeMB,
+		MaxAgeDays: cfg.File.MaxAgeDays,
+		MaxBackups: cfg.File.MaxBackups,
+		Compress:   cfg.File.Compress,
+	})
+
+	return &fileExporter{out: out, close: closeFile, format: format}, nil
+}
+
+// Export writes the records as a single export request.
+func (e *fileExporter) Export(_ context.Context, records []sdklog.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	request := &collogpb.ExportLogsServiceRequest{ResourceLogs: resourceLogs(records)}
+
+	var (
+		buf []byte
+		err error
+	)
+	if e.format == ProtobufFileFormat {
+		buf, err = protobufFrame(request)
+	} else {
+		buf, err = jsonLine(request)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	_, err = e.out.Write(buf)
+
+	return err
+}
+
+// ForceFlush syncs the file.
+func (e *fileExporter) ForceFlush(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.out.Sync()
+}
+
+// Shutdown syncs and closes the file.
+func (e *fileExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return errors.Join(e.out.Sync(), e.close())
+}
+
+// protobufFrame encodes request as binary protobuf preceded by its length.
+func protobufFrame(request *collogpb.ExportLogsServiceRequest) ([]byte, error) {
+	size := proto.Size(request)
+	buf := binary.BigEndian.AppendUint32(make([]byte, 0, 4+size), uint32(size))
+
+	return proto.MarshalOptions{}.MarshalAppend(buf, request)
+}
+
+// jsonLine encodes request as a line of OTLP/JSON. The trace and span IDs are
+// hex-encoded, as OTLP/JSON requires, where protojson would use base64.
+func jsonLine(request *collogpb.ExportLogsServiceRequest) ([]byte, error) {
+	raw, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	for _, rl := range jsonArray(doc, "resourceLogs") {
+		for _, sl := range jsonArray(rl, "scopeLogs") {
+			for _, lr := range jsonArray(sl, "logRecords") {
+				hexID(lr, "traceId")
+				hexID(lr, "spanId")
+			}
+		}
+	}
+
+	line, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(line, '\n'), nil
+}
+
+// jsonArray returns the objects of the array under key of v.
+func jsonArray(v any, key string) []any {
+	obj, _ := v.(map[string]any)
+	items, _ := obj[key].([]any)
+	return items
+}
+
+// hexID re-encodes the base64 ID under key of v in hex.
+func hexID(v any, key string) {
+	obj, _ := v.(map[string]any)
+	if id, ok := obj[key].(string); ok {
+		if raw, err := base64.StdEncoding.DecodeString(id); err == nil {
+			obj[key] = hex.EncodeToString(raw)
+		}
+	}
+}
+
+// resourceLogs groups the records by resource and instrumentation scope.
+func resourceLogs(records []sdklog.Record) []*logpb.ResourceLogs {
+	type scopeKey struct {
+		resource attribute.Distinct
+		scope    instrumentation.Scope
+	}
+
+	var out []*logpb.ResourceLogs
+	resources := map[attribute.Distinct]*logpb.ResourceLogs{}
+	scopes := map[scopeKey]*logpb.ScopeLogs{}
+
+	for i := range records {
+		r := &records[i]
+		res := r.Resource()
+		resKey := res.Equivalent()
+
+		rl, ok := resources[resKey]
+		if !ok {
+			rl = &logpb.ResourceLogs{
+				Resource:  &resourcepb.Resource{Attributes: resourceAttributes(res.Attributes())},
+				SchemaUrl: res.SchemaURL(),
+			}
+			resources[resKey] = rl
+			out = append(out, rl)
+		}
+
+		scope := r.InstrumentationScope()
+		key := scopeKey{resource: resKey, scope: scope}
+
+		sl, ok := scopes[key]
+		if !ok {
+			sl = &logpb.ScopeLogs{
+				Scope: &commonpb.InstrumentationScope{
+					Name:       scope.Name,
+					Version:    scope.Version,
+					Attributes: resourceAttributes(scope.Attributes.ToSlice()),
+				},
+				SchemaUrl: scope.SchemaURL,
+			}
+			scopes[key] = sl
+			rl.ScopeLogs = append(rl.ScopeLogs, sl)
+		}
+
+		sl.LogRecords = append(sl.LogRecords, logRecord(r))
+	}
+
+	return out
+}
+
+// logRecord returns the OTLP form of r.
+func logRecord(r *sdklog.Record) *logpb.LogRecord {
+	lr := &logpb.LogRecord{
+		TimeUnixNano:           unixNano(r.Timestamp().UnixNano()),
+		ObservedTimeUnixNano:   unixNano(r.ObservedTimestamp().UnixNano()),
+		EventName:              r.EventName(),
+		SeverityNumber:         logpb.SeverityNumber(r.Severity()),
+		SeverityText:           r.SeverityText(),
+		Body:                   anyValue(r.Body()),
+		DroppedAttributesCount: uint32(r.DroppedAttributes()),
+		Flags:                  uint32(r.TraceFlags()),
+	}
+
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		lr.Attributes = append(lr.Attributes, &commonpb.KeyValue{Key: kv.Key, Value: anyValue(kv.Value)})
+		return true
+	})
+
+	if id := r.TraceID(); id.IsValid() {
+		lr.TraceId = id[:]
+	}
+	if id := r.SpanID(); id.IsValid() {
+		lr.SpanId = id[:]
+	}
+
+	return lr
+}
+
+// resourceAttributes returns the OTLP form of attrs.
+func resourceAttributes(attrs []attribute.KeyValue) []*commonpb.KeyValue {
+	out := make([]*commonpb.KeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		out = append(out, &commonpb.KeyValue{Key: string(kv.Key), Value: anyValue(log.ValueFromAttribute(kv.Value))})
+	}
+
+	return out
+}
+
+// anyValue returns the OTLP form of v, nil for an empty value.
+func anyValue(v log.Value) *commonpb.AnyValue {
+	switch v.Kind() {
+	case log.KindBool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v.AsBool()}}
+	case log.KindInt64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v.AsInt64()}}
+	case log.KindFloat64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v.AsFloat64()}}
+	case log.KindString:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.AsString()}}
+	case log.KindBytes:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: v.AsBytes()}}
+	case log.KindSlice:
+		items := v.AsSlice()
+		values := make([]*commonpb.AnyValue, len(items))
+		for i, item := range items {
+			values[i] = anyValue(item)
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: values}}}
+	case log.KindMap:
+		kvs := v.AsMap()
+		values := make([]*commonpb.KeyValue, len(kvs))
+		for i, kv := range kvs {
+			values[i] = &commonpb.KeyValue{Key: kv.Key, Value: anyValue(kv.Value)}
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{Values: values}}}
+	}
+
+	return nil
+}
+
+// unixNano returns nanos as an unsigned timestamp, zero before the epoch.
+func unixNano(nanos int64) uint64 {
+	if nanos < 0 {
+		return 0
+	}
+
+	return uint64(nanos)
+}
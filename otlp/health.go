@@ -0,0 +1,156 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// ErrUnhealthy is returned by HealthChecker.Healthy when the log export
+// pipeline is broken.
+var ErrUnhealthy = errors.New("otlp log export unhealthy")
+
+// checkers holds the HealthChecker of the providers created by NewProvider,
+// until they are shut down.
+var checkers sync.Map
+
+type (
+	// Health is the state of the log export pipeline of a provider.
+	Health struct {
+		// Available is false while the collector is unreachable and the
+		// export waits for its next attempt.
+		Available bool
+		// ConnState is the state of the gRPC connection shared with the
+		// exporter (Config.Conn), or empty when unknown.
+		ConnState string
+		// LastExport is the time of the last successful export.
+		LastExport time.Time
+		// LastError is the error of the last failed export, and LastErrorTime
+		// its time.
+		LastError     error
+		LastErrorTime time.Time
+	}
+
+	// HealthChecker reports the health of the log export pipeline of a
+	// provider, e.g. to reflect a broken pipeline in readiness probes.
+	HealthChecker struct {
+		conn      *grpc.ClientConn
+		exports   *exportState
+		available func() bool
+	}
+
+	// unregisteringExporter removes the HealthChecker of its provider from
+	// checkers once the provider shuts it down.
+	unregisteringExporter struct {
+		sdklog.Exporter
+		provider *sdklog.LoggerProvider
+	}
+
+	// exportState records the outcome of the exports.
+	exportState struct {
+		mu            sync.Mutex
+		lastExport    time.Time
+		lastError     error
+		lastErrorTime time.Time
+	}
+)
+
+// HealthCheckerFor returns the HealthChecker of a provider created by
+// NewProvider, or by Install through cfgs.LoggerProvider.
+//
+// Parameters:
+//   - provider: The logger provider
+//
+// Returns:
+//   - The HealthChecker, or nil when provider was not created by this package
+//     or was shut down
+func HealthCheckerFor(provider *sdklog.LoggerProvider) *HealthChecker {
+	if h, ok := checkers.Load(provider); ok {
+		return h.(*HealthChecker)
+	}
+
+	return nil
+}
+
+// Status returns the state of the export pipeline.
+func (h *HealthChecker) Status() Health {
+	h.exports.mu.Lock()
+	status := Health{
+		Available:     h.available(),
+		LastExport:    h.exports.lastExport,
+		LastError:     h.exports.lastError,
+		LastErrorTime: h.exports.lastErrorTime,
+	}
+	h.exports.mu.Unlock()
+
+	if h.conn != nil {
+		status.ConnState = h.conn.GetState().String()
+	}
+
+	return status
+}
+
+// Healthy reports whether logs are being exported: the collector is available,
+// the gRPC connection, when known, is not failing, and the last export, if
+// any, succeeded. While the connection is being established, Healthy waits for
+// the outcome until ctx is done.
+//
+// Parameters:
+//   - ctx: Context bounding the wait for a connecting gRPC connection
+//
+// Returns:
+//   - nil when healthy, or ErrUnhealthy wrapped with the reason
+func (h *HealthChecker) Healthy(ctx context.Context) error {
+	if !h.available() {
+		return fmt.Errorf("%w: collector unavailable", ErrUnhealthy)
+	}
+
+	if h.conn != nil {
+		state := h.conn.GetState()
+		for state == connectivity.Connecting && h.conn.WaitForStateChange(ctx, state) {
+			state = h.conn.GetState()
+		}
+
+		switch state {
+		case connectivity.Connecting, connectivity.TransientFailure, connectivity.Shutdown:
+			return fmt.Errorf("%w: grpc connection %s", ErrUnhealthy, state)
+		}
+	}
+
+	h.exports.mu.Lock()
+	defer h.exports.mu.Unlock()
+
+	if h.exports.lastError != nil && !h.exports.lastErrorTime.Before(h.exports.lastExport) {
+		return fmt.Errorf("%w: last export failed: %w", ErrUnhealthy, h.exports.lastError)
+	}
+
+	return nil
+}
+
+// Shutdown unregisters the HealthChecker and shuts the exporter down.
+func (e *unregisteringExporter) Shutdown(ctx context.Context) error {
+	checkers.Delete(e.provider)
+	return e.Exporter.Shutdown(ctx)
+}
+
+// record records the outcome of an export.
+func (s *exportState) record(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		s.lastError, s.lastErrorTime = err, time.Now()
+	} else {
+		s.lastExport = time.Now()
+	}
+}
@@ -10,72 +10,340 @@ package otlp
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"time"
 
 	"github.com/goxkit/configs"
 	"github.com/goxkit/otel/otlpgrpc"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	"go.opentelemetry.io/otel/log/global"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.28.0"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	zapInstance "github.com/goxkit/logging/zap"
 )
 
+// DefaultStartupTimeout bounds the startup of the export pipeline when the
+// context passed to Install has no deadline.
+const DefaultStartupTimeout = 10 * time.Second
+
+// Config describes the OTLP log export pipeline built by NewProvider. It is
+// independent of configs.Configs so the exporter can be set up from any
+// configuration source.
+type Config struct {
+	// Protocol selects the export transport. Defaults to GRPCProtocol.
+	Protocol Protocol
+	// Endpoint is the collector address (host:port or URL) used when Conn is nil.
+	Endpoint string
+	// Writer receives the records with StdoutProtocol. Defaults to os.Stdout.
+	Writer io.Writer
+	// File is the file receiving the records with FileProtocol.
+	File *FileExport
+	// TLSEnabled dials the collector over TLS instead of an insecure connection.
+	TLSEnabled bool
+	// TLSConfig sets the CA bundle and client certificates (mTLS) used to reach
+	// the collector. It implies TLSEnabled. See NewTLSConfig.
+	TLSConfig *tls.Config
+	// Headers are sent with every export request (e.g. "Authorization": "Bearer ...").
+	Headers map[string]string
+	// Gzip compresses the export requests, reducing egress to remote
+	// collectors. It is ignored with Conn, whose dial options set compression.
+	Gzip bool
+	// Timeout bounds each export request. Zero uses the exporter default.
+	Timeout time.Duration
+	// Conn is an already established gRPC connection to the collector.
+	// When set with GRPCProtocol, Endpoint, TLSEnabled, TLSConfig and Headers are ignored.
+	Conn *grpc.ClientConn
+	// ServiceName is reported as the service.name resource attribute.
+	ServiceName string
+	// ServiceNamespace is reported as the service.namespace resource attribute.
+	ServiceNamespace string
+	// Environment is reported as the deployment environment resource attribute.
+	Environment string
+	// ResourceAttributes are added to the resource (e.g. team, region, cluster,
+	// version), overriding the attributes above and OTEL_RESOURCE_ATTRIBUTES.
+	ResourceAttributes []attribute.KeyValue
+	// Batch tunes the batch processor. Zero values keep the SDK defaults.
+	Batch BatchConfig
+	// FailFast makes NewProvider check that the collector is reachable,
+	// returning an error instead of falling back to the local outputs.
+	FailFast bool
+	// Spill, when set, appends the records that cannot be exported to a
+	// bounded local file, replayed once the collector is reachable again.
+	Spill *Spill
+	// DeadLetter, when set, writes the batches the collector rejects, and
+	// those that cannot be exported nor spilled, to a local NDJSON file with
+	// the reason of the failure.
+	DeadLetter *DeadLetter
+	// OnExportError, when set, is called with the error and the number of
+	// records of every failed export, so exporter problems can be surfaced in
+	// the application monitoring. The records are lost. It is called from the
+	// batch processor goroutine and must not block.
+	OnExportError func(err error, records int)
+	// ReconnectInitialInterval is the first delay before exporting to the
+	// collector again once an export failed. Defaults to
+	// DefaultReconnectInitialInterval.
+	ReconnectInitialInterval time.Duration
+	// ReconnectMaxInterval caps the exponential backoff between attempts.
+	// Defaults to DefaultReconnectMaxInterval.
+	ReconnectMaxInterval time.Duration
+}
+
+// BatchConfig tunes the batch processor buffering log records before export.
+// Zero values keep the SDK defaults, which can also be set through the
+// OTEL_BLRP_* environment variables.
+type BatchConfig struct {
+	// MaxQueueSize is the maximum number of records buffered; further records
+	// are dropped. SDK default: 2048.
+	MaxQueueSize int
+	// ExportInterval is the delay between two exports. SDK default: 1s.
+	ExportInterval time.Duration
+	// ExportTimeout bounds each export. SDK default: 30s.
+	ExportTimeout time.Duration
+	// ExportMaxBatchSize is the maximum number of records per export. SDK default: 512.
+	ExportMaxBatchSize int
+}
+
+// options returns the batch processor options of the non-zero settings.
+func (b *BatchConfig) options() []sdklog.BatchProcessorOption {
+	var opts []sdklog.BatchProcessorOption
+
+	if b.MaxQueueSize > 0 {
+		opts = append(opts, sdklog.WithMaxQueueSize(b.MaxQueueSize))
+	}
+	if b.ExportInterval > 0 {
+		opts = append(opts, sdklog.WithExportInterval(b.ExportInterval))
+	}
+	if b.ExportTimeout > 0 {
+		opts = append(opts, sdklog.WithExportTimeout(b.ExportTimeout))
+	}
+	if b.ExportMaxBatchSize > 0 {
+		opts = append(opts, sdklog.WithExportMaxBatchSize(b.ExportMaxBatchSize))
+	}
+
+	return opts
+}
+
+// NewProvider creates an OpenTelemetry logger provider that batches log records
+// and exports them to the collector described by cfg, over gRPC or HTTP depending
+// on cfg.Protocol. The provider is tagged with service and environment resource
+// attributes, plus those of OTEL_RESOURCE_ATTRIBUTES and cfg.ResourceAttributes.
+//
+// Configuration errors, such as an invalid endpoint URL, are returned. The
+// collector itself is connected lazily: while it is unreachable, the provider
+// drops records, leaving the local outputs as the only destination, and tries
+// exporting again with exponential backoff until an export succeeds. Set
+// cfg.FailFast to check that the collector is reachable within ctx instead.
+// The health of the export is reported by the HealthChecker returned by
+// HealthCheckerFor.
+//
+// Parameters:
+//   - ctx: Context used while creating the exporter
+//   - cfg: Export pipeline description
+//
+// Returns:
+//   - A configured sdklog.LoggerProvider
+//   - An error if cfg is invalid, or the collector is unreachable and cfg.FailFast is set
+func NewProvider(ctx context.Context, cfg *Config) (*sdklog.LoggerProvider, error) {
+	health := &HealthChecker{exports: &exportState{}, available: func() bool { return true }}
+	if cfg.Protocol == GRPCProtocol || cfg.Protocol == "" {
+		health.conn = cfg.Conn
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	exp, err := newExporter(ctx, cfg, health.exports)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.FailFast {
+		if err := probe(ctx, cfg); err != nil {
+			// ctx may be done, the probe having waited for the collector.
+			_ = exp.Shutdown(context.Background())
+			return nil, err
+		}
+	}
+
+	reconnecting := newReconnectingExporter(exp, cfg, health.conn)
+	health.available = reconnecting.available
+	exp = reconnecting
+
+	// The exporters created so far are shut down when a file cannot be
+	// opened, closing their connection and files.
+	if cfg.DeadLetter != nil {
+		deadLetter, err := newDeadLetterExporter(exp, *cfg.DeadLetter, cfg.Spill != nil)
+		if err != nil {
+			_ = exp.Shutdown(ctx)
+			return nil, err
+		}
+		exp = deadLetter
+	}
+
+	if cfg.Spill != nil {
+		spill, err := newSpillExporter(exp, *cfg.Spill, health.available)
+		if err != nil {
+			_ = exp.Shutdown(ctx)
+			return nil, err
+		}
+		exp = spill
+	}
+
+	unregistering := &unregisteringExporter{Exporter: exp}
+	processor := sdklog.NewBatchProcessor(unregistering, cfg.Batch.options()...)
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(processor),
+		sdklog.WithResource(newResource(ctx, cfg)),
+	)
+	unregistering.provider = provider
+	checkers.Store(provider, health)
+
+	return provider, nil
+}
+
+// newResource builds the resource describing the service. Attributes from
+// OTEL_RESOURCE_ATTRIBUTES are overridden by the service attributes and the
+// detected container ID, which are in turn overridden by cfg.ResourceAttributes.
+func newResource(ctx context.Context, cfg *Config) *resource.Resource {
+	attrs := []attribute.KeyValue{
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceNamespace(cfg.ServiceNamespace),
+		attribute.String("service.environment", cfg.Environment),
+		semconv.DeploymentEnvironmentName(cfg.Environment),
+		semconv.TelemetrySDKLanguageGo,
+	}
+	if id := ContainerID(); id != "" {
+		attrs = append(attrs, semconv.ContainerID(id))
+	}
+	attrs = append(attrs, cfg.ResourceAttributes...)
+
+	res, err := resource.New(ctx,
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithFromEnv(),
+		resource.WithAttributes(attrs...),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	if res == nil {
+		res = resource.NewWithAttributes(semconv.SchemaURL, attrs...)
+	}
+
+	return res
+}
+
 // Install configures and initializes an OpenTelemetry-enabled logger that exports
 // logs to an OTLP collector. It sets up the connection to the OTLP endpoint specified
 // in the configuration and configures the logger with proper service and environment
 // attributes for better observability context.
 //
+// The export protocol is read from OTEL_EXPORTER_OTLP_LOGS_PROTOCOL or
+// OTEL_EXPORTER_OTLP_PROTOCOL; "http/protobuf" selects the HTTP exporter and
+// anything else keeps the gRPC exporter.
+//
 // The function handles the complete setup of:
-// - OTLP exporter with gRPC transport
+// - OTLP exporter with gRPC or HTTP transport
 // - Batch processing for efficient log export
 // - Resource attributes for service identification
 // - Global logger provider registration
 // - Integration with Zap for structured logging
 //
+// An unreachable collector does not fail the installation: the logger writes to
+// stdout while the export is retried with backoff. A misconfigured one does.
+// The startup (exporter creation and resource detection) is bounded by ctx, or
+// by DefaultStartupTimeout when ctx has no deadline.
+//
 // Parameters:
+//   - ctx: Context bounding the startup
 //   - cfgs: Application configurations including OTLP endpoint and service information
+//   - opts: Additional zap options (hooks, fields, core wrappers, ...)
 //
 // Returns:
 //   - A configured zap.Logger instance with OTLP export capabilities
-//   - An error if the OTLP exporter or logger initialization fails
-func Install(cfgs *configs.Configs) (*zap.Logger, error) {
-	ctx := context.Background()
+//   - An error if the OTLP exporter or logger initialization fails, or ctx is done
+func Install(ctx context.Context, cfgs *configs.Configs, opts ...zap.Option) (*zap.Logger, error) {
+	ctx, cancel := WithStartupTimeout(ctx, 0)
+	defer cancel()
 
-	if cfgs.OTLPExporterConn == nil {
-		conn, err := otlpgrpc.NewExporterGRPCClient(cfgs)
-		if err != nil {
-			return nil, err
+	protocol := ProtocolFromEnv()
+
+	tlsConfig, err := TLSConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	// Certificates from the environment are applied by the exporter itself,
+	// the shared connection only supports the system roots.
+	if protocol == GRPCProtocol && cfgs.OTLPExporterConn == nil && tlsConfig == nil {
+		// Without the shared connection the exporter dials the endpoint itself,
+		// falling back to local output while the collector is unreachable.
+		if conn, err := otlpgrpc.NewExporterGRPCClient(cfgs); err == nil {
+			cfgs.OTLPExporterConn = conn
+		} else {
+			otel.Handle(err)
 		}
-		cfgs.OTLPExporterConn = conn
 	}
 
-	exp, err := otlploggrpc.New(
-		ctx,
-		otlploggrpc.WithGRPCConn(cfgs.OTLPExporterConn),
-	)
+	provider, err := NewProvider(ctx, &Config{
+		Protocol:         protocol,
+		Endpoint:         cfgs.OTLPConfigs.Endpoint,
+		TLSEnabled:       cfgs.OTLPConfigs.ExporterTLSEnabled,
+		TLSConfig:        tlsConfig,
+		Headers:          ParseHeaders(cfgs.OTLPConfigs.ExporterHeaders),
+		Timeout:          cfgs.OTLPConfigs.ExporterTimeout,
+		Conn:             cfgs.OTLPExporterConn,
+		ServiceName:      cfgs.AppConfigs.Name,
+		ServiceNamespace: cfgs.AppConfigs.Namespace,
+		Environment:      cfgs.AppConfigs.Environment.String(),
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	processor := sdklog.NewBatchProcessor(exp)
-	provider := sdklog.NewLoggerProvider(
-		sdklog.WithProcessor(processor),
-		sdklog.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceName(cfgs.AppConfigs.Name),
-			semconv.ServiceNamespace(cfgs.AppConfigs.Namespace),
-			attribute.String("service.environment", cfgs.AppConfigs.Environment.String()),
-			semconv.DeploymentEnvironmentName(cfgs.AppConfigs.Environment.String()),
-			semconv.TelemetrySDKLanguageGo,
-		)),
-	)
+	if err := ctx.Err(); err != nil {
+		_ = provider.Shutdown(context.Background())
+		return nil, fmt.Errorf("otlp log exporter startup: %w", err)
+	}
+
+	// The provider is only registered once the logger is built, and shut down
+	// when it cannot be, so a failed installation leaves no global state.
+	logger, err := zapInstance.NewZapLogger(cfgs, provider, opts...)
+	if err != nil {
+		_ = provider.Shutdown(context.Background())
+		return nil, err
+	}
 
 	global.SetLoggerProvider(provider)
 	cfgs.LoggerProvider = provider
 
-	return zapInstance.NewZapLogger(cfgs, provider)
+	return logger, nil
+}
+
+// WithStartupTimeout returns a copy of ctx bounded by timeout, or by
+// DefaultStartupTimeout when timeout is zero, unless ctx already has a deadline.
+//
+// Parameters:
+//   - ctx: The parent context
+//   - timeout: The startup timeout, or zero for DefaultStartupTimeout
+//
+// Returns:
+//   - The bounded context and its cancel function
+func WithStartupTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+
+	if timeout <= 0 {
+		timeout = DefaultStartupTimeout
+	}
+
+	return context.WithTimeout(ctx, timeout)
 }
@@ -0,0 +1,227 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/goxkit/logging/telemetry"
+)
+
+const (
+	// DefaultReconnectInitialInterval is the first delay before exporting to
+	// the collector again once an export failed.
+	DefaultReconnectInitialInterval = time.Second
+	// DefaultReconnectMaxInterval caps the delay between two attempts to export
+	// to an unreachable collector.
+	DefaultReconnectMaxInterval = time.Minute
+)
+
+// reconnectingExporter falls back to the local outputs while the collector is
+// unreachable. The exporters connect lazily, so the collector is only known to
+// be unreachable once an export fails: the records of the following exports are
+// then dropped, still written by the local cores, until a backoff delay has
+// elapsed and the next export tries the collector again. The delay doubles
+// with every failed attempt and is reset by a successful export. A shared gRPC
+// connection in transient failure also makes the collector unavailable.
+type reconnectingExporter struct {
+	sdklog.Exporter
+	conn        *grpc.ClientConn
+	initial     time.Duration
+	maxInterval time.Duration
+
+	mu       sync.Mutex
+	interval time.Duration
+	retryAt  time.Time
+}
+
+// newReconnectingExporter wraps exp, watching conn when it is not nil.
+func newReconnectingExporter(exp sdklog.Exporter, cfg *Config, conn *grpc.ClientConn) *reconnectingExporter {
+	e := &reconnectingExporter{
+		Exporter:    exp,
+		conn:        conn,
+		initial:     cfg.ReconnectInitialInterval,
+		maxInterval: cfg.ReconnectMaxInterval,
+	}
+
+	if e.initial <= 0 {
+		e.initial = DefaultReconnectInitialInterval
+	}
+	if e.maxInterval <= 0 {
+		e.maxInterval = DefaultReconnectMaxInterval
+	}
+
+	return e
+}
+
+// Export exports the records, or drops them while the collector is unavailable.
+func (e *reconnectingExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	if !e.available() {
+		return nil
+	}
+
+	e.mu.Lock()
+	retrying := !e.retryAt.IsZero()
+	e.mu.Unlock()
+
+	if retrying {
+		telemetry.AddExportRetry()
+	}
+
+	err := e.Exporter.Export(ctx, records)
+	e.record(err)
+
+	return err
+}
+
+// available reports whether records are exported: no backoff delay is pending
+// and the gRPC connection, when known, is not failing.
+func (e *reconnectingExporter) available() bool {
+	e.mu.Lock()
+	waiting := time.Now().Before(e.retryAt)
+	e.mu.Unlock()
+
+	if waiting {
+		return false
+	}
+
+	if e.conn != nil {
+		switch e.conn.GetState() {
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return false
+		}
+	}
+
+	return true
+}
+
+// record updates the backoff with the outcome of an export. A rejected batch
+// reached the collector, which is therefore available.
+func (e *reconnectingExporter) record(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err == nil || Rejected(err) {
+		e.interval, e.retryAt = 0, time.Time{}
+		return
+	}
+
+	if e.interval == 0 {
+		e.interval = e.initial
+	} else {
+		e.interval = min(e.interval*2, e.maxInterval)
+	}
+	e.retryAt = time.Now().Add(e.interval)
+
+	otel.Handle(fmt.Errorf("otlp log export failed, falling back to local output, retrying in %s: %w", e.interval, err))
+}
+
+// validate reports the configuration errors of cfg, which retrying cannot fix.
+func (cfg *Config) validate() error {
+	switch cfg.Protocol {
+	case StdoutProtocol:
+		return nil
+	case FileProtocol:
+		if cfg.File == nil {
+			return errors.New("otlp file export requires a file configuration")
+		}
+		return nil
+	}
+
+	if cfg.Conn != nil || !strings.Contains(cfg.Endpoint, "://") {
+		return nil
+	}
+
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid otlp endpoint: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid otlp endpoint %q: unsupported scheme %q", cfg.Endpoint, u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid otlp endpoint %q: missing host", cfg.Endpoint)
+	}
+
+	return nil
+}
+
+// probe checks that the collector of cfg is reachable before ctx is done, by
+// connecting the shared gRPC connection or dialing the endpoint.
+func probe(ctx context.Context, cfg *Config) error {
+	switch cfg.Protocol {
+	case StdoutProtocol, FileProtocol:
+		return nil
+	}
+
+	if cfg.Conn != nil && (cfg.Protocol == GRPCProtocol || cfg.Protocol == "") {
+		cfg.Conn.Connect()
+
+		state := cfg.Conn.GetState()
+		for state != connectivity.Ready {
+			if state == connectivity.Shutdown || !cfg.Conn.WaitForStateChange(ctx, state) {
+				return fmt.Errorf("otlp collector unreachable: grpc connection %s", state)
+			}
+			state = cfg.Conn.GetState()
+		}
+
+		return nil
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", collectorAddress(cfg))
+	if err != nil {
+		return fmt.Errorf("otlp collector unreachable: %w", err)
+	}
+
+	return conn.Close()
+}
+
+// collectorAddress returns the host:port of the collector of cfg, with the
+// default port of the protocol, or of the scheme of an endpoint URL.
+func collectorAddress(cfg *Config) string {
+	port := "4317"
+	if cfg.Protocol == HTTPProtobufProtocol {
+		port = "4318"
+	}
+
+	host := cfg.Endpoint
+	if strings.Contains(host, "://") {
+		// validate already rejected the endpoints that do not parse.
+		u, _ := url.Parse(host)
+		if u.Port() != "" {
+			return u.Host
+		}
+
+		host = u.Hostname()
+		if u.Scheme == "https" {
+			port = "443"
+		} else {
+			port = "80"
+		}
+	}
+
+	if host == "" {
+		host = "localhost"
+	}
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+
+	return net.JoinHostPort(host, port)
+}
@@ -0,0 +1,418 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultSpillMaxBytes bounds the spill file when Spill.MaxBytes is zero.
+	DefaultSpillMaxBytes = 100 * 1024 * 1024
+	// spillReplayBatch is the number of spilled records per replayed export.
+	spillReplayBatch = 512
+)
+
+// errSpillFull is reported when records are dropped because the spill file is full.
+var errSpillFull = errors.New("otlp spill file full, dropping log records")
+
+type (
+	// Spill configures a disk-backed buffer for the records that cannot be
+	// exported: while the collector is unreachable, or exports fail with a
+	// retryable error, records are appended to a bounded local file, and
+	// replayed in order before the next records once an export succeeds, so
+	// restarts of the collector, or of the service, do not lose logs. Records
+	// failing permanently, e.g. rejected by the collector, are not spilled:
+	// they are dead-lettered when a DeadLetter is set, and dropped otherwise.
+	Spill struct {
+		// Path is the spill file. Required.
+		Path string
+		// MaxBytes bounds the size of the spill file; further records are
+		// dropped. Defaults to DefaultSpillMaxBytes.
+		MaxBytes int64
+	}
+
+	// spillExporter spills the records its exporter fails to export to a file
+	// and replays them once the exporter recovers. The head of the file up to
+	// offset was already replayed; it is removed once the whole file is, or
+	// when space is needed.
+	spillExporter struct {
+		sdklog.Exporter
+		cfg       Spill
+		available func() bool
+
+		mu        sync.Mutex
+		file      *os.File
+		size      int64
+		offset    int64
+		full      bool
+		templates map[string]sdklog.Record
+	}
+
+	// spilledRecord is the form of a record in the spill file.
+	spilledRecord struct {
+		Scope             string       `json:"scope,omitempty"`
+		ScopeVersion      string       `json:"scope_version,omitempty"`
+		EventName         string       `json:"event_name,omitempty"`
+		Timestamp         time.Time    `json:"ts"`
+		ObservedTimestamp time.Time    `json:"observed_ts"`
+		Severity          log.Severity `json:"severity"`
+		SeverityText      string       `json:"severity_text,omitempty"`
+		Body              spilledValue `json:"body"`
+		Attributes        []spilledKV  `json:"attrs,omitempty"`
+		TraceID           string       `json:"trace_id,omitempty"`
+		SpanID            string       `json:"span_id,omitempty"`
+		TraceFlags        byte         `json:"trace_flags,omitempty"`
+	}
+
+	// spilledValue is the form of a log.Value in the spill file.
+	spilledValue struct {
+		Kind   log.Kind       `json:"k"`
+		Bool   bool           `json:"b,omitempty"`
+		Int    int64          `json:"i,omitempty"`
+		Float  float64        `json:"f,omitempty"`
+		String string         `json:"s,omitempty"`
+		Bytes  []byte         `json:"y,omitempty"`
+		Slice  []spilledValue `json:"l,omitempty"`
+		Map    []spilledKV    `json:"m,omitempty"`
+	}
+
+	// spilledKV is the form of a log.KeyValue in the spill file.
+	spilledKV struct {
+		Key   string       `json:"k"`
+		Value spilledValue `json:"v"`
+	}
+)
+
+// newSpillExporter wraps exp, spilling to the file of cfg the records it fails
+// to export or receives while available reports the exporter unavailable.
+func newSpillExporter(exp sdklog.Exporter, cfg Spill, available func() bool) (*spillExporter, error) {
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = DefaultSpillMaxBytes
+	}
+
+	f, err := os.OpenFile(cfg.Path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("otlp spill file: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("otlp spill file: %w", err)
+	}
+
+	return &spillExporter{
+		Exporter:  exp,
+		cfg:       cfg,
+		available: available,
+		file:      f,
+		size:      info.Size(),
+		templates: map[string]sdklog.Record{},
+	}, nil
+}
+
+// Export replays the spilled records, if any, then exports records. Records
+// that fail to export with a retryable error are spilled instead, so the export
+// only fails when the failure is permanent, or they cannot be spilled either.
+func (e *spillExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.remember(records)
+
+	if !e.available() {
+		return e.spill(records)
+	}
+
+	if e.size > e.offset {
+		if err := e.replay(ctx); err != nil {
+			return e.spill(records)
+		}
+	}
+
+	if err := e.Exporter.Export(ctx, records); err != nil {
+		if !retryable(err) {
+			return err
+		}
+		return e.spill(records)
+	}
+
+	return nil
+}
+
+// Shutdown removes the records already replayed from the spill file and closes
+// it, its other records being replayed by the next process, and shuts the
+// exporter down.
+func (e *spillExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	err := errors.Join(e.compact(), e.file.Close())
+	e.mu.Unlock()
+
+	return errors.Join(err, e.Exporter.Shutdown(ctx))
+}
+
+// remember keeps a record per instrumentation scope, carrying the resource and
+// scope the replayed records are exported with.
+func (e *spillExporter) remember(records []sdklog.Record) {
+	for i := range records {
+		scope := records[i].InstrumentationScope().Name
+		if _, ok := e.templates[scope]; !ok {
+			e.templates[scope] = records[i].Clone()
+		}
+	}
+}
+
+// spill appends records to the spill file, removing the records already
+// replayed when space is needed, and dropping them once it is full.
+func (e *spillExporter) spill(records []sdklog.Record) error {
+	var buf []byte
+	for i := range records {
+		line, err := json.Marshal(newSpilledRecord(&records[i]))
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+
+	if e.size+int64(len(buf)) > e.cfg.MaxBytes && e.offset > 0 {
+		if err := e.compact(); err != nil {
+			return err
+		}
+	}
+
+	if e.size+int64(len(buf)) > e.cfg.MaxBytes {
+		if !e.full {
+			e.full = true
+			otel.Handle(errSpillFull)
+		}
+		return nil
+	}
+
+	n, err := e.file.Write(buf)
+	e.size += int64(n)
+
+	return err
+}
+
+// replay exports the spilled records in order, in batches, from the offset
+// of the records not replayed yet. On a retryable failure, the offset stays at
+// the first record not exported; batches failing permanently are dropped.
+func (e *spillExporter) replay(ctx context.Context) error {
+	if len(e.templates) == 0 {
+		return nil
+	}
+
+	if _, err := e.file.Seek(e.offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(e.file)
+	batch := make([]sdklog.Record, 0, spillReplayBatch)
+
+	offset := e.offset
+	flush := func() error {
+		if len(batch) > 0 {
+			if err := e.Exporter.Export(ctx, batch); err != nil {
+				if retryable(err) {
+					return err
+				}
+				otel.Handle(fmt.Errorf("otlp spill replay failed, dropping %d log records: %w", len(batch), err))
+			}
+		}
+		e.offset, batch = offset, batch[:0]
+		return nil
+	}
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// A partial last line, left by a failed write, cannot be replayed.
+			offset += int64(len(line))
+			break
+		}
+		if err != nil {
+			return err
+		}
+		offset += int64(len(line))
+
+		var spilled spilledRecord
+		if json.Unmarshal(line, &spilled) == nil {
+			batch = append(batch, e.record(&spilled))
+		}
+
+		if len(batch) == spillReplayBatch {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	return e.compact()
+}
+
+// compact removes the records already replayed from the head of the spill file.
+func (e *spillExporter) compact() error {
+	if e.offset == 0 {
+		return nil
+	}
+
+	if _, err := e.file.Seek(e.offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	rest, err := io.ReadAll(e.file)
+	if err != nil {
+		return err
+	}
+
+	if err := e.file.Truncate(0); err != nil {
+		return err
+	}
+
+	written, err := e.file.Write(rest)
+	e.size, e.offset, e.full = int64(written), 0, false
+
+	return err
+}
+
+// record rebuilds a spilled record, with the resource and scope of the
+// template of its scope, or of any template.
+func (e *spillExporter) record(s *spilledRecord) sdklog.Record {
+	template, ok := e.templates[s.Scope]
+	if !ok {
+		for _, t := range e.templates {
+			template = t
+			break
+		}
+	}
+
+	r := template.Clone()
+	r.SetEventName(s.EventName)
+	r.SetTimestamp(s.Timestamp)
+	r.SetObservedTimestamp(s.ObservedTimestamp)
+	r.SetSeverity(s.Severity)
+	r.SetSeverityText(s.SeverityText)
+	r.SetBody(s.Body.value())
+	r.SetTraceFlags(trace.TraceFlags(s.TraceFlags))
+
+	// Invalid IDs leave the zero IDs of a record without span.
+	traceID, _ := trace.TraceIDFromHex(s.TraceID)
+	r.SetTraceID(traceID)
+	spanID, _ := trace.SpanIDFromHex(s.SpanID)
+	r.SetSpanID(spanID)
+
+	attrs := make([]log.KeyValue, len(s.Attributes))
+	for i, kv := range s.Attributes {
+		attrs[i] = log.KeyValue{Key: kv.Key, Value: kv.Value.value()}
+	}
+	r.SetAttributes(attrs...)
+
+	return r
+}
+
+// newSpilledRecord returns the spilled form of r.
+func newSpilledRecord(r *sdklog.Record) spilledRecord {
+	s := spilledRecord{
+		Scope:             r.InstrumentationScope().Name,
+		ScopeVersion:      r.InstrumentationScope().Version,
+		EventName:         r.EventName(),
+		Timestamp:         r.Timestamp(),
+		ObservedTimestamp: r.ObservedTimestamp(),
+		Severity:          r.Severity(),
+		SeverityText:      r.SeverityText(),
+		Body:              newSpilledValue(r.Body()),
+		TraceFlags:        byte(r.TraceFlags()),
+	}
+
+	if id := r.TraceID(); id.IsValid() {
+		s.TraceID = id.String()
+	}
+	if id := r.SpanID(); id.IsValid() {
+		s.SpanID = id.String()
+	}
+
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		s.Attributes = append(s.Attributes, spilledKV{Key: kv.Key, Value: newSpilledValue(kv.Value)})
+		return true
+	})
+
+	return s
+}
+
+// newSpilledValue returns the spilled form of v.
+func newSpilledValue(v log.Value) spilledValue {
+	s := spilledValue{Kind: v.Kind()}
+
+	switch v.Kind() {
+	case log.KindBool:
+		s.Bool = v.AsBool()
+	case log.KindInt64:
+		s.Int = v.AsInt64()
+	case log.KindFloat64:
+		s.Float = v.AsFloat64()
+	case log.KindString:
+		s.String = v.AsString()
+	case log.KindBytes:
+		s.Bytes = v.AsBytes()
+	case log.KindSlice:
+		for _, item := range v.AsSlice() {
+			s.Slice = append(s.Slice, newSpilledValue(item))
+		}
+	case log.KindMap:
+		for _, kv := range v.AsMap() {
+			s.Map = append(s.Map, spilledKV{Key: kv.Key, Value: newSpilledValue(kv.Value)})
+		}
+	}
+
+	return s
+}
+
+// value rebuilds the log.Value of s.
+func (s spilledValue) value() log.Value {
+	switch s.Kind {
+	case log.KindBool:
+		return log.BoolValue(s.Bool)
+	case log.KindInt64:
+		return log.Int64Value(s.Int)
+	case log.KindFloat64:
+		return log.Float64Value(s.Float)
+	case log.KindString:
+		return log.StringValue(s.String)
+	case log.KindBytes:
+		return log.BytesValue(s.Bytes)
+	case log.KindSlice:
+		items := make([]log.Value, len(s.Slice))
+		for i, item := range s.Slice {
+			items[i] = item.value()
+		}
+		return log.SliceValue(items...)
+	case log.KindMap:
+		kvs := make([]log.KeyValue, len(s.Map))
+		for i, kv := range s.Map {
+			kvs[i] = log.KeyValue{Key: kv.Key, Value: kv.Value.value()}
+		}
+		return log.MapValue(kvs...)
+	}
+
+	return log.Value{}
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"context"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/goxkit/logging/telemetry"
+)
+
+// countingExporter counts the export requests of an exporter and their
+// failures in the self-telemetry, records their outcome for the health checks
+// and reports the failures to Config.OnExportError.
+type countingExporter struct {
+	sdklog.Exporter
+	exports *exportState
+	onError func(error, int)
+}
+
+// Export exports the records and counts the request.
+func (e *countingExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	err := e.Exporter.Export(ctx, records)
+	telemetry.AddExport(len(records), err)
+	e.exports.record(err)
+
+	if err != nil && e.onError != nil {
+		e.onError(err, len(records))
+	}
+
+	return err
+}
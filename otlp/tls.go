@@ -0,0 +1,90 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package otlp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Standard OpenTelemetry variables holding the exporter TLS material. The
+// OTEL_EXPORTER_OTLP_LOGS_* variants take precedence for the logs signal.
+const (
+	CertificateEnvKey           = "OTEL_EXPORTER_OTLP_CERTIFICATE"
+	LogsCertificateEnvKey       = "OTEL_EXPORTER_OTLP_LOGS_CERTIFICATE"
+	ClientCertificateEnvKey     = "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE"
+	LogsClientCertificateEnvKey = "OTEL_EXPORTER_OTLP_LOGS_CLIENT_CERTIFICATE"
+	ClientKeyEnvKey             = "OTEL_EXPORTER_OTLP_CLIENT_KEY"
+	LogsClientKeyEnvKey         = "OTEL_EXPORTER_OTLP_LOGS_CLIENT_KEY"
+)
+
+// NewTLSConfig builds the TLS configuration of the exporter from PEM files.
+//
+// Parameters:
+//   - caFile: CA bundle verifying the collector certificate; empty uses the system roots
+//   - certFile: Client certificate for mTLS; empty disables client authentication
+//   - keyFile: Private key of the client certificate
+//
+// Returns:
+//   - The TLS configuration to set in Config.TLSConfig
+//   - An error if a file cannot be read or parsed
+func NewTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read otlp CA bundle: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("failed to parse otlp CA bundle: no certificate found")
+		}
+		cfg.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load otlp client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// TLSConfigFromEnv builds the exporter TLS configuration from the standard
+// OTEL_EXPORTER_OTLP_[LOGS_]CERTIFICATE, CLIENT_CERTIFICATE and CLIENT_KEY
+// variables.
+//
+// Returns:
+//   - The TLS configuration, or nil when none of the variables is set
+//   - An error if a file cannot be read or parsed
+func TLSConfigFromEnv() (*tls.Config, error) {
+	caFile := envOr(LogsCertificateEnvKey, CertificateEnvKey)
+	certFile := envOr(LogsClientCertificateEnvKey, ClientCertificateEnvKey)
+	keyFile := envOr(LogsClientKeyEnvKey, ClientKeyEnvKey)
+
+	if caFile == "" && certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+
+	return NewTLSConfig(caFile, certFile, keyFile)
+}
+
+// envOr returns the value of the signal-specific variable, falling back to the
+// generic one.
+func envOr(signalKey, key string) string {
+	if value := os.Getenv(signalKey); value != "" {
+		return value
+	}
+
+	return os.Getenv(key)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package pubsublog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// MetadataHostEnvKey overrides the host of the metadata server, as used by
+	// the Google Cloud clients.
+	MetadataHostEnvKey = "GCE_METADATA_HOST"
+
+	// defaultMetadataHost is the metadata server of GCE, GKE and Cloud Run.
+	defaultMetadataHost = "metadata.google.internal"
+	// tokenPath is the metadata path of the access token of the default
+	// service account.
+	tokenPath = "/computeMetadata/v1/instance/service-accounts/default/token"
+	// refreshMargin renews tokens before they expire.
+	refreshMargin = 5 * time.Minute
+)
+
+// Credential provides Google OAuth2 access tokens. An oauth2.TokenSource can
+// be adapted with CredentialFunc.
+type Credential interface {
+	// Token returns an access token and its expiration time.
+	Token(ctx context.Context) (string, time.Time, error)
+}
+
+// CredentialFunc adapts a function to the Credential interface.
+type CredentialFunc func(ctx context.Context) (string, time.Time, error)
+
+// Token calls f.
+func (f CredentialFunc) Token(ctx context.Context) (string, time.Time, error) {
+	return f(ctx)
+}
+
+// metadataCredential fetches the tokens of the default service account from
+// the metadata server, reusing a token until it is about to expire.
+type metadataCredential struct {
+	client *http.Client
+
+	mu        sync.Mutex
+	token     string
+	expiresOn time.Time
+}
+
+// MetadataCredential authenticates with the service account attached to the
+// GCE instance, GKE workload (Workload Identity) or Cloud Run service the
+// process runs on, through the metadata server.
+//
+// Returns:
+//   - A Credential caching its tokens
+func MetadataCredential() Credential {
+	return &metadataCredential{client: &http.Client{Timeout: DefaultTimeout}}
+}
+
+// Token returns the cached token, fetching a new one when needed.
+func (c *metadataCredential) Token(ctx context.Context) (string, time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Until(c.expiresOn) > refreshMargin {
+		return c.token, c.expiresOn, nil
+	}
+
+	host := os.Getenv(MetadataHostEnvKey)
+	if host == "" {
+		host = defaultMetadataHost
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+host+tokenPath, nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to get gcp access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, fmt.Errorf("failed to get gcp access token: %s", resp.Status)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to decode gcp access token: %w", err)
+	}
+
+	c.token = token.AccessToken
+	c.expiresOn = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	return c.token, c.expiresOn, nil
+}
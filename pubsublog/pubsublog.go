@@ -0,0 +1,327 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package pubsublog provides a sink publishing entries to a Google Cloud
+// Pub/Sub topic, for GCP-native log pipelines not using Cloud Logging. Entries
+// are published in batches through the Pub/Sub REST API as JSON messages,
+// carrying the level, logger and service as message attributes so
+// subscriptions can filter on them, and optionally an ordering key.
+package pubsublog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/batch"
+	"github.com/goxkit/logging/internal/checked"
+	zapInstance "github.com/goxkit/logging/zap"
+)
+
+const (
+	// DefaultEndpoint is the Pub/Sub REST API endpoint.
+	DefaultEndpoint = "https://pubsub.googleapis.com"
+	// DefaultTimeout bounds each publish request.
+	DefaultTimeout = 10 * time.Second
+	// EmulatorHostEnvKey is the variable pointing the Google Cloud clients to
+	// a local Pub/Sub emulator, e.g. "localhost:8085".
+	EmulatorHostEnvKey = "PUBSUB_EMULATOR_HOST"
+
+	// maxBatchSize is the maximum number of messages per publish request.
+	maxBatchSize = 1000
+	// maxRequestBytes is the maximum size of a publish request.
+	maxRequestBytes = 10 << 20
+)
+
+// Attributes of the published messages.
+const (
+	LevelAttribute   = "level"
+	LoggerAttribute  = "logger"
+	ServiceAttribute = "service"
+)
+
+var (
+	// ErrMissingConfig is returned when the project or topic is not set.
+	ErrMissingConfig = errors.New("pubsub project and topic are required")
+
+	// ErrMessageTooLarge is reported when an entry exceeds the maximum request
+	// size of Pub/Sub and is dropped.
+	ErrMessageTooLarge = errors.New("log entry exceeds the maximum pubsub message size")
+)
+
+type (
+	// Config describes the Pub/Sub sink.
+	Config struct {
+		// Project is the Google Cloud project ID of the topic.
+		Project string
+		// Topic is the topic ID.
+		Topic string
+		// Service is added to every message as the service attribute.
+		Service string
+		// OrderingKeyField names the field whose value is the ordering key of
+		// the messages, e.g. "session_id". Message ordering must be enabled on
+		// the subscription. Entries without it are published unordered.
+		OrderingKeyField string
+		// Credential authenticates the requests. Defaults to
+		// MetadataCredential, and to none with PUBSUB_EMULATOR_HOST.
+		Credential Credential
+		// Endpoint is the Pub/Sub REST API endpoint. Defaults to
+		// DefaultEndpoint, or to the emulator of PUBSUB_EMULATOR_HOST.
+		Endpoint string
+		// BatchSize is the maximum number of messages per request. Defaults to
+		// 100, capped at 1000.
+		BatchSize int
+		// FlushInterval is the maximum delay before buffered messages are
+		// sent. Defaults to 5s.
+		FlushInterval time.Duration
+		// Timeout bounds each request. Defaults to DefaultTimeout.
+		Timeout time.Duration
+		// Client sends the requests. Defaults to an http.Client with Timeout.
+		Client *http.Client
+		// EncoderOptions customizes the JSON encoder of the messages.
+		EncoderOptions zapInstance.EncoderOptions
+	}
+
+	// message is a Pub/Sub message of the publish request.
+	message struct {
+		Data        []byte            `json:"data"`
+		Attributes  map[string]string `json:"attributes,omitempty"`
+		OrderingKey string            `json:"orderingKey,omitempty"`
+	}
+
+	// core is a zapcore.Core batching entries to a Pub/Sub topic.
+	core struct {
+		zapcore.LevelEnabler
+		cfg     *Config
+		url     string
+		enc     zapcore.Encoder
+		key     string
+		batcher *batch.Batcher[*message]
+	}
+)
+
+// NewCore returns a core publishing entries at or above level to the Pub/Sub
+// topic of cfg.
+//
+// Parameters:
+//   - cfg: Pub/Sub sink configuration
+//   - level: Minimum level published to the topic
+//
+// Returns:
+//   - A zapcore.Core ready to be teed into a logger; Sync sends the buffered messages
+//   - ErrMissingConfig if the project or topic is not set
+func NewCore(cfg Config, level zapcore.LevelEnabler) (zapcore.Core, error) {
+	if cfg.Project == "" || cfg.Topic == "" {
+		return nil, ErrMissingConfig
+	}
+
+	emulator := os.Getenv(EmulatorHostEnvKey)
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultEndpoint
+		if emulator != "" {
+			cfg.Endpoint = "http://" + emulator
+		}
+	}
+	if cfg.Credential == nil && emulator == "" {
+		cfg.Credential = MetadataCredential()
+	}
+	if cfg.BatchSize > maxBatchSize {
+		cfg.BatchSize = maxBatchSize
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: cfg.Timeout}
+	}
+
+	c := &core{
+		LevelEnabler: level,
+		cfg:          &cfg,
+		url: fmt.Sprintf("%s/v1/projects/%s/topics/%s:publish",
+			strings.TrimSuffix(cfg.Endpoint, "/"),
+			url.PathEscape(cfg.Project),
+			url.PathEscape(cfg.Topic),
+		),
+		enc: zapInstance.NewEncoderWithOptions(zapInstance.JSONEncoder, cfg.EncoderOptions),
+	}
+	c.batcher = batch.New(batch.Config{
+		Size:     cfg.BatchSize,
+		Interval: cfg.FlushInterval,
+		OnError:  otel.Handle,
+	}, c.send)
+
+	return c, nil
+}
+
+// With adds structured context to the core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.enc = c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(clone.enc)
+		if c.isKey(f) {
+			clone.key = fieldValue(f)
+		}
+	}
+
+	return &clone
+}
+
+// Check adds the core to the checked entry when the level is enabled.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write encodes the entry and buffers it for the next request.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	data := bytes.TrimSuffix(append([]byte(nil), buf.Bytes()...), []byte("\n"))
+	buf.Free()
+
+	msg := &message{
+		Data:       data,
+		Attributes: map[string]string{LevelAttribute: ent.Level.String()},
+	}
+	if ent.LoggerName != "" {
+		msg.Attributes[LoggerAttribute] = ent.LoggerName
+	}
+	if c.cfg.Service != "" {
+		msg.Attributes[ServiceAttribute] = c.cfg.Service
+	}
+
+	msg.OrderingKey = c.key
+	for i := len(fields) - 1; i >= 0; i-- {
+		if c.isKey(fields[i]) {
+			msg.OrderingKey = fieldValue(fields[i])
+			break
+		}
+	}
+
+	c.batcher.Add(msg)
+
+	return nil
+}
+
+// Sync sends the buffered messages.
+func (c *core) Sync() error {
+	return c.batcher.Flush()
+}
+
+// Close stops the background flusher and sends the buffered entries.
+func (c *core) Close() error {
+	return c.batcher.Close()
+}
+
+// isKey reports whether f is the ordering key field.
+func (c *core) isKey(f zapcore.Field) bool {
+	return c.cfg.OrderingKeyField != "" && f.Key == c.cfg.OrderingKeyField
+}
+
+// fieldValue returns the string form of the value of f, as an ordering key.
+func fieldValue(f zapcore.Field) string {
+	if f.Type == zapcore.StringType {
+		return f.String
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+
+	return fmt.Sprint(enc.Fields[f.Key])
+}
+
+// send splits messages into requests within the size limit of Pub/Sub,
+// dropping the messages too large for it, and publishes them.
+func (c *core) send(messages []*message) error {
+	var (
+		errs    []error
+		request []*message
+		size    int
+	)
+
+	for _, m := range messages {
+		// Data is base64-encoded in the request.
+		n := (len(m.Data)+2)/3*4 + len(m.OrderingKey)
+		for k, v := range m.Attributes {
+			n += len(k) + len(v)
+		}
+
+		if n > maxRequestBytes {
+			errs = append(errs, ErrMessageTooLarge)
+			continue
+		}
+
+		if size+n > maxRequestBytes {
+			errs = append(errs, c.publish(request))
+			request, size = nil, 0
+		}
+
+		request = append(request, m)
+		size += n
+	}
+
+	if len(request) > 0 {
+		errs = append(errs, c.publish(request))
+	}
+
+	return errors.Join(errs...)
+}
+
+// publish sends a publish request.
+func (c *core) publish(messages []*message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+
+	body, err := json.Marshal(map[string]any{"messages": messages})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if c.cfg.Credential != nil {
+		token, _, err := c.cfg.Credential.Token(ctx)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.cfg.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish logs to pubsub: %w", err)
+	}
+	defer resp.Body.Close()
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("failed to publish logs to pubsub: %s: %s", resp.Status, msg)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package redact
+
+import (
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+)
+
+// core redacts the fields of every entry before handing it to the wrapped core.
+type core struct {
+	zapcore.Core
+	redactor *Redactor
+}
+
+// NewCore wraps c so every field, including the ones added through With, is
+// redacted before reaching it. When c is a tee, redaction runs once for all of
+// its outputs.
+//
+// Parameters:
+//   - c: The core receiving redacted entries
+//   - r: The redaction rules
+//
+// Returns:
+//   - A zapcore.Core applying the redaction
+func NewCore(c zapcore.Core, r *Redactor) zapcore.Core {
+	return &core{Core: c, redactor: r}
+}
+
+// With redacts the fields before adding them to the wrapped core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	return &core{Core: c.Core.With(c.redactor.Fields(fields)), redactor: c.redactor}
+}
+
+// Check registers this core for the entry when any wrapped output accepts it,
+// so the fields can be redacted in Write.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Core.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write redacts the message and fields and writes the entry to the wrapped
+// outputs that accept it.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = c.redactor.Message(ent.Message)
+
+	return checked.Write(c.Core, ent, c.redactor.Fields(fields))
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Hash returns the pseudonym written in place of a hashed field value: the
+// hex-encoded HMAC-SHA256 of value with the configured HashSecret. It can be
+// used to look up the entries of a known value, e.g. a user's email address.
+// Without a secret the mask is returned, as unkeyed hashes of low-entropy
+// values such as emails or IPs are easily reversed.
+func (r *Redactor) Hash(value string) string {
+	if len(r.hashSecret) == 0 {
+		return r.mask
+	}
+
+	mac := hmac.New(sha256.New, r.hashSecret)
+	mac.Write([]byte(value))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// hashedKey reports whether values stored under key must be pseudonymized.
+func (r *Redactor) hashedKey(key string) bool {
+	_, ok := r.hashed[strings.ToLower(key)]
+	return ok
+}
+
+// fieldText renders the value of a field as the text that is hashed.
+func fieldText(f zapcore.Field) string {
+	if f.Type == zapcore.StringType {
+		return f.String
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+
+	return valueText(enc.Fields[f.Key])
+}
+
+// valueText renders a generic value as text, strings as is and other values
+// as JSON.
+func valueText(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(b)
+}
@@ -0,0 +1,376 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package redact provides a redaction layer for the logging framework. It masks
+// sensitive fields (passwords, tokens, authorization headers, card numbers, ...)
+// and secrets found in messages and string values (JWTs, API keys, AWS keys, ...)
+// before log entries reach any output, including the OTLP exporter. Redaction
+// applies to the top-level fields of an entry as well as to the keys and values
+// of nested objects logged through zap.Any, zap.Object or zap.Array.
+package redact
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultMask is the replacement written in place of redacted values.
+const DefaultMask = "[REDACTED]"
+
+// DefaultKeys are the field names masked by DefaultConfig.
+var DefaultKeys = []string{
+	"password",
+	"passwd",
+	"secret",
+	"client_secret",
+	"token",
+	"access_token",
+	"refresh_token",
+	"id_token",
+	"api_key",
+	"apikey",
+	"authorization",
+	"cookie",
+	"set-cookie",
+	"card_number",
+	"cvv",
+}
+
+// Config describes which fields are redacted.
+type Config struct {
+	// Keys are field names (case-insensitive) whose values are fully masked.
+	Keys []string
+	// KeyPatterns mask the values of every field whose name matches one of the patterns.
+	KeyPatterns []*regexp.Regexp
+	// ValuePatterns mask the parts of string values matching one of the patterns,
+	// or only their SecretGroup capture group when they define one.
+	ValuePatterns []*regexp.Regexp
+	// CardNumbers masks sequences of 13 to 19 digits that pass the Luhn check.
+	CardNumbers bool
+	// Messages applies the value patterns and card number detection to the
+	// entry messages too.
+	Messages bool
+	// HashedKeys are field names (case-insensitive) whose values are replaced by
+	// their HMAC-SHA256 with HashSecret instead of being masked, so that entries
+	// about the same email, user ID or IP can still be correlated.
+	HashedKeys []string
+	// HashSecret is the HMAC key of HashedKeys. Without it, their values are masked.
+	HashSecret []byte
+	// Mask replaces redacted values. Defaults to DefaultMask.
+	Mask string
+}
+
+// DefaultConfig returns a Config masking the DefaultKeys, and the SecretPatterns
+// and card numbers found in messages and string values.
+func DefaultConfig() Config {
+	return Config{
+		Keys:          DefaultKeys,
+		ValuePatterns: SecretPatterns,
+		CardNumbers:   true,
+		Messages:      true,
+	}
+}
+
+// Redactor applies a Config to Zap fields.
+type Redactor struct {
+	keys          map[string]struct{}
+	keyPatterns   []*regexp.Regexp
+	valuePatterns []*regexp.Regexp
+	cardNumbers   bool
+	messages      bool
+	hashed        map[string]struct{}
+	hashSecret    []byte
+	mask          string
+}
+
+// cardNumberPattern matches candidate card numbers, optionally separated by
+// spaces or dashes. Candidates are confirmed with the Luhn check.
+var cardNumberPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+
+// New creates a Redactor from the given Config.
+//
+// Parameters:
+//   - cfg: Redaction rules
+//
+// Returns:
+//   - A Redactor ready to be used by NewCore
+func New(cfg Config) *Redactor {
+	mask := cfg.Mask
+	if mask == "" {
+		mask = DefaultMask
+	}
+
+	keys := make(map[string]struct{}, len(cfg.Keys))
+	for _, k := range cfg.Keys {
+		keys[strings.ToLower(k)] = struct{}{}
+	}
+
+	hashed := make(map[string]struct{}, len(cfg.HashedKeys))
+	for _, k := range cfg.HashedKeys {
+		hashed[strings.ToLower(k)] = struct{}{}
+	}
+
+	return &Redactor{
+		keys:          keys,
+		keyPatterns:   cfg.KeyPatterns,
+		valuePatterns: cfg.ValuePatterns,
+		cardNumbers:   cfg.CardNumbers,
+		messages:      cfg.Messages,
+		hashed:        hashed,
+		hashSecret:    cfg.HashSecret,
+		mask:          mask,
+	}
+}
+
+// Message returns the message with the secrets it contains masked, when the
+// Config enables message redaction.
+func (r *Redactor) Message(msg string) string {
+	if !r.messages {
+		return msg
+	}
+
+	redacted, _ := r.redactString(msg)
+
+	return redacted
+}
+
+// Fields returns the fields with sensitive values masked. The input slice is
+// never modified; it is returned as is when nothing needs to be redacted.
+func (r *Redactor) Fields(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+
+	for i, f := range fields {
+		redacted, changed := r.Field(f)
+		if !changed {
+			if out != nil {
+				out = append(out, f)
+			}
+			continue
+		}
+
+		if out == nil {
+			out = make([]zapcore.Field, i, len(fields))
+			copy(out, fields[:i])
+		}
+		out = append(out, redacted)
+	}
+
+	if out == nil {
+		return fields
+	}
+
+	return out
+}
+
+// Field redacts a single field, reporting whether it was changed.
+func (r *Redactor) Field(f zapcore.Field) (zapcore.Field, bool) {
+	switch f.Type {
+	case zapcore.NamespaceType, zapcore.SkipType:
+		return f, false
+	}
+
+	if f.Key != "" && r.sensitiveKey(f.Key) {
+		return zap.String(f.Key, r.mask), true
+	}
+
+	if f.Key != "" && r.hashedKey(f.Key) {
+		return zap.String(f.Key, r.Hash(fieldText(f))), true
+	}
+
+	switch f.Type {
+	case zapcore.StringType:
+		if s, ok := r.redactString(f.String); ok {
+			return zap.String(f.Key, s), true
+		}
+	case zapcore.ByteStringType:
+		if b, ok := f.Interface.([]byte); ok {
+			if s, ok := r.redactString(string(b)); ok {
+				return zap.String(f.Key, s), true
+			}
+		}
+	case zapcore.StringerType, zapcore.ErrorType:
+		return r.redactText(f)
+	case zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType,
+		zapcore.InlineMarshalerType, zapcore.ReflectType:
+		return r.redactComplex(f)
+	}
+
+	return f, false
+}
+
+// redactText redacts fields rendered through fmt.Stringer or error values.
+func (r *Redactor) redactText(f zapcore.Field) (zapcore.Field, bool) {
+	var text string
+
+	switch v := f.Interface.(type) {
+	case error:
+		text = v.Error()
+	case interface{ String() string }:
+		text = v.String()
+	default:
+		return f, false
+	}
+
+	if s, ok := r.redactString(text); ok {
+		return zap.String(f.Key, s), true
+	}
+
+	return f, false
+}
+
+// redactComplex captures nested objects and arrays as generic values, redacts
+// them recursively and replaces the field when anything was masked.
+func (r *Redactor) redactComplex(f zapcore.Field) (zapcore.Field, bool) {
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+
+	var captured any = enc.Fields
+	if f.Type != zapcore.InlineMarshalerType {
+		captured = enc.Fields[f.Key]
+	}
+
+	// Round-trip through JSON so structs and typed maps become generic values
+	// whose keys can be inspected.
+	raw, err := json.Marshal(captured)
+	if err != nil {
+		return f, false
+	}
+
+	var generic any
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return f, false
+	}
+
+	redacted, changed := r.redactValue(generic)
+	if !changed {
+		return f, false
+	}
+
+	if f.Type == zapcore.InlineMarshalerType {
+		if m, ok := redacted.(map[string]any); ok {
+			return zap.Inline(object(m)), true
+		}
+
+		return f, false
+	}
+
+	return zap.Any(f.Key, redacted), true
+}
+
+// redactValue walks a generic JSON value masking sensitive keys and values.
+func (r *Redactor) redactValue(v any) (any, bool) {
+	switch value := v.(type) {
+	case map[string]any:
+		changed := false
+		for k, nested := range value {
+			if r.sensitiveKey(k) {
+				value[k] = r.mask
+				changed = true
+				continue
+			}
+
+			if r.hashedKey(k) {
+				value[k] = r.Hash(valueText(nested))
+				changed = true
+				continue
+			}
+
+			if redacted, ok := r.redactValue(nested); ok {
+				value[k] = redacted
+				changed = true
+			}
+		}
+		return value, changed
+	case []any:
+		changed := false
+		for i, nested := range value {
+			if redacted, ok := r.redactValue(nested); ok {
+				value[i] = redacted
+				changed = true
+			}
+		}
+		return value, changed
+	case string:
+		return r.redactString(value)
+	default:
+		return v, false
+	}
+}
+
+// sensitiveKey reports whether values stored under key must be masked.
+func (r *Redactor) sensitiveKey(key string) bool {
+	if _, ok := r.keys[strings.ToLower(key)]; ok {
+		return true
+	}
+
+	for _, p := range r.keyPatterns {
+		if p.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactString masks the parts of s matching the value patterns or card numbers.
+func (r *Redactor) redactString(s string) (string, bool) {
+	out := s
+
+	for _, p := range r.valuePatterns {
+		out = replace(p, out, r.mask)
+	}
+
+	if r.cardNumbers {
+		out = cardNumberPattern.ReplaceAllStringFunc(out, func(candidate string) string {
+			if luhn(candidate) {
+				return r.mask
+			}
+			return candidate
+		})
+	}
+
+	return out, out != s
+}
+
+// luhn validates the digits of s with the Luhn checksum, ignoring separators.
+func luhn(s string) bool {
+	sum := 0
+	double := false
+
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+// object marshals a generic map as a Zap object.
+type object map[string]any
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (o object) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for k, v := range o {
+		zap.Any(k, v).AddTo(enc)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package redact
+
+import "regexp"
+
+// SecretGroup is the name of the capture group masked by a value pattern.
+// Patterns without it are masked entirely; patterns with it keep the
+// surrounding context, e.g. the "Bearer " prefix of an authorization header.
+const SecretGroup = "secret"
+
+// Patterns matching well-known secret formats in string values and messages.
+var (
+	// JWTPattern matches JSON Web Tokens.
+	JWTPattern = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+	// BearerTokenPattern matches the token of "Bearer <token>" credentials.
+	BearerTokenPattern = regexp.MustCompile(`(?i)\bbearer\s+(?P<secret>[A-Za-z0-9._~+/-]+=*)`)
+	// AWSAccessKeyPattern matches AWS access key IDs.
+	AWSAccessKeyPattern = regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)
+	// AWSSecretKeyPattern matches AWS secret access keys assigned to a name
+	// mentioning AWS and a secret or key.
+	AWSSecretKeyPattern = regexp.MustCompile(`(?i)aws.{0,20}?(?:secret|key).{0,20}?["'=:\s]+(?P<secret>[A-Za-z0-9/+]{40})\b`)
+	// GitHubTokenPattern matches GitHub personal, OAuth and app tokens.
+	GitHubTokenPattern = regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)
+	// SlackTokenPattern matches Slack bot, user and app tokens.
+	SlackTokenPattern = regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)
+	// StripeKeyPattern matches Stripe secret and restricted keys.
+	StripeKeyPattern = regexp.MustCompile(`\b[rs]k_(?:live|test)_[A-Za-z0-9]{16,}`)
+	// GoogleAPIKeyPattern matches Google API keys.
+	GoogleAPIKeyPattern = regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)
+	// PrivateKeyPattern matches PEM encoded private keys.
+	PrivateKeyPattern = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)
+	// CredentialAssignmentPattern matches the values of "api_key=...",
+	// "token: ..." or "password=..." assignments, e.g. in query strings.
+	CredentialAssignmentPattern = regexp.MustCompile(`(?i)\b(?:api[_-]?key|access[_-]?token|secret|token|password|passwd)["']?\s*[:=]\s*["']?(?P<secret>[^\s"'&,;]{6,})`)
+)
+
+// SecretPatterns are the patterns of the well-known secret formats, used as
+// value patterns by DefaultConfig.
+var SecretPatterns = []*regexp.Regexp{
+	JWTPattern,
+	BearerTokenPattern,
+	AWSAccessKeyPattern,
+	AWSSecretKeyPattern,
+	GitHubTokenPattern,
+	SlackTokenPattern,
+	StripeKeyPattern,
+	GoogleAPIKeyPattern,
+	PrivateKeyPattern,
+	CredentialAssignmentPattern,
+}
+
+// replace masks the matches of p in s, or only their SecretGroup when the
+// pattern defines one.
+func replace(p *regexp.Regexp, s, mask string) string {
+	group := p.SubexpIndex(SecretGroup)
+	if group < 0 {
+		return p.ReplaceAllLiteralString(s, mask)
+	}
+
+	matches := p.FindAllStringSubmatchIndex(s, -1)
+	if matches == nil {
+		return s
+	}
+
+	out := make([]byte, 0, len(s))
+	last := 0
+	for _, m := range matches {
+		start, end := m[2*group], m[2*group+1]
+		if start < 0 {
+			continue
+		}
+
+		out = append(out, s[last:start]...)
+		out = append(out, mask...)
+		last = end
+	}
+	out = append(out, s[last:]...)
+
+	return string(out)
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/file"
+	zapInstance "github.com/goxkit/logging/zap"
+)
+
+// reloadDebounce groups the bursts of events of a single file update.
+const reloadDebounce = 100 * time.Millisecond
+
+// ErrNotReloadable is returned when applying pipeline settings to a logger not
+// created by New.
+var ErrNotReloadable = errors.New("logging pipeline can only be reloaded on loggers created by New")
+
+type (
+	// reloadState holds what a logger created by New needs to rebuild its pipeline.
+	reloadState struct {
+		mu      sync.Mutex
+		options *options
+		// outputs are the cores opened by the running pipeline, kept open
+		// across reloads.
+		outputs *outputs
+		// sinks are the configuration sinks of the running pipeline.
+		sinks []configSink
+	}
+
+	// outputs are the file and sink cores opened by the pipelines of a logger.
+	outputs struct {
+		files map[*file.Config]zapcore.Core
+		sinks map[configSink]zapcore.Core
+	}
+)
+
+// newOutputs returns an empty set of outputs.
+func newOutputs() *outputs {
+	return &outputs{files: map[*file.Config]zapcore.Core{}, sinks: map[configSink]zapcore.Core{}}
+}
+
+// release closes the sink cores missing from sinks, once the pipeline using
+// them is replaced.
+func (out *outputs) release(sinks []configSink) error {
+	kept := make(map[configSink]struct{}, len(sinks))
+	for _, s := range sinks {
+		kept[s] = struct{}{}
+	}
+
+	var closing []zapcore.Core
+	for s, core := range out.sinks {
+		if _, ok := kept[s]; !ok {
+			closing = append(closing, core)
+			delete(out.sinks, s)
+		}
+	}
+
+	return closeCores(closing)
+}
+
+// close closes every file and sink core.
+func (out *outputs) close() error {
+	closing := make([]zapcore.Core, 0, len(out.files)+len(out.sinks))
+	for _, core := range out.files {
+		closing = append(closing, core)
+	}
+	for _, core := range out.sinks {
+		closing = append(closing, core)
+	}
+	clear(out.files)
+	clear(out.sinks)
+
+	return closeCores(closing)
+}
+
+// closeCores closes the cores holding a connection or a file.
+func closeCores(cores []zapcore.Core) error {
+	var errs []error
+	for _, core := range cores {
+		if c, ok := core.(interface{ Close() error }); ok {
+			errs = append(errs, c.Close())
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ApplyConfig applies a logging configuration to a running logger: the levels
+// change atomically, and the pipeline (encoder, sampling, rate limits,
+// redaction, limits, deduplication and sinks) is rebuilt and swapped in without
+// losing the loggers derived from l. Unset sections fall back to the options
+// the logger was created with; the service, OTLP and asynchronous sections are
+// ignored, as they are fixed at creation.
+//
+// Parameters:
+//   - l: A logger created by New or NewFromConfigFile
+//   - cfg: The configuration to apply
+//
+// Returns:
+//   - An error if the configuration is invalid; the logger is then left unchanged
+//   - ErrNotReloadable if l was not created by New
+func ApplyConfig(l Logger, cfg *FileConfig) error {
+	lg, ok := l.(*logger)
+	if !ok || lg.reload == nil {
+		return ErrNotReloadable
+	}
+
+	opts, err := cfg.Options()
+	if err != nil {
+		return err
+	}
+
+	r := lg.reload
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	o := r.options.clone()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	// The identity, OTLP export and asynchronous writing are fixed at creation.
+	o.serviceName, o.namespace, o.environment = r.options.serviceName, r.options.namespace, r.options.environment
+	o.async = r.options.async
+
+	namedLevels, err := zapInstance.LevelsFromEnv()
+	if err != nil {
+		return err
+	}
+	for name, level := range o.namedLevels {
+		namedLevels[name] = level
+	}
+
+	// The sinks opened for a rejected configuration are closed, those of the
+	// running pipeline are kept.
+	pipeline, err := o.pipeline(lg.levels, lg.provider, r.outputs)
+	if err != nil {
+		_ = r.outputs.release(r.sinks)
+		return err
+	}
+
+	// Reload flushes and closes the previous pipeline before the sinks it no
+	// longer shares with the new one are closed.
+	if err := zapInstance.Reload(lg.Core(), pipeline); err != nil {
+		_ = r.outputs.release(r.sinks)
+		return err
+	}
+
+	r.sinks = o.configSinks
+	if err := r.outputs.release(o.configSinks); err != nil {
+		otel.Handle(fmt.Errorf("close logging sinks: %w", err))
+	}
+
+	lg.levels.Global().SetLevel(o.level)
+	for name := range lg.levels.Overrides() {
+		if _, ok := namedLevels[name]; !ok {
+			lg.levels.Unset(name)
+		}
+	}
+	for name, level := range namedLevels {
+		lg.levels.Set(name, level)
+	}
+
+	return nil
+}
+
+// WatchConfigFile applies the logging configuration file at path to l and
+// watches it, applying every change live. Invalid updates are reported through
+// l and leave the running configuration unchanged. The directory of the file is
+// watched, so updates made by renaming a new file over it, such as Kubernetes
+// ConfigMap updates, are detected too.
+//
+// Parameters:
+//   - l: A logger created by New or NewFromConfigFile
+//   - path: Path of the YAML or JSON configuration file
+//
+// Returns:
+//   - A function that stops watching the file
+//   - An error if the file cannot be applied initially or watched
+func WatchConfigFile(l Logger, path string) (stop func(), err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyConfigData(l, data); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	go watchConfig(l, path, data, watcher)
+
+	return func() { _ = watcher.Close() }, nil
+}
+
+// watchConfig reloads the file on every change of its content until the
+// watcher is closed.
+func watchConfig(l Logger, path string, applied []byte, watcher *fsnotify.Watcher) {
+	var debounce <-chan time.Time
+
+	for {
+		select {
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			debounce = time.After(reloadDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			l.Warn("failed to watch logging configuration", zap.String("path", path), zap.Error(err))
+		case <-debounce:
+			data, err := os.ReadFile(path)
+			if err != nil {
+				l.Warn("failed to read logging configuration", zap.String("path", path), zap.Error(err))
+				continue
+			}
+			if bytes.Equal(data, applied) {
+				continue
+			}
+
+			if err := applyConfigData(l, data); err != nil {
+				l.Error("failed to reload logging configuration", zap.String("path", path), zap.Error(err))
+				continue
+			}
+
+			applied = data
+			l.Info("logging configuration reloaded", zap.String("path", path))
+		}
+	}
+}
+
+// applyConfigData parses and applies a configuration document.
+func applyConfigData(l Logger, data []byte) error {
+	cfg, err := ParseConfig(data)
+	if err != nil {
+		return err
+	}
+
+	return ApplyConfig(l, cfg)
+}
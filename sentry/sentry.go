@@ -0,0 +1,431 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package sentry provides a Sentry sink for the logging framework. Error, panic
+// and fatal entries are sent to Sentry as events, with their stack trace, the
+// logged error as the exception, the fields as extra context and the trace
+// context, so exceptions are grouped and alerted on while regular logs keep
+// flowing to OTLP.
+package sentry
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goxkit/configs"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/batch"
+	"github.com/goxkit/logging/internal/checked"
+)
+
+const (
+	// DefaultTimeout bounds each request to Sentry.
+	DefaultTimeout = 10 * time.Second
+
+	// Standard Sentry SDK variables used as defaults.
+	DSNEnvKey         = "SENTRY_DSN"
+	EnvironmentEnvKey = "SENTRY_ENVIRONMENT"
+	ReleaseEnvKey     = "SENTRY_RELEASE"
+
+	// client identifies the sink in the X-Sentry-Auth header.
+	client = "goxkit-logging/1.0"
+)
+
+var (
+	// ErrMissingDSN is returned when no DSN is configured.
+	ErrMissingDSN = errors.New("sentry DSN is required")
+	// ErrInvalidDSN is returned when the DSN cannot be parsed.
+	ErrInvalidDSN = errors.New("invalid sentry DSN")
+)
+
+// Config describes the Sentry sink.
+type Config struct {
+	// DSN is the project DSN. Defaults to SENTRY_DSN.
+	DSN string
+	// Environment is the environment of the events. Defaults to SENTRY_ENVIRONMENT.
+	Environment string
+	// Release is the release of the events. Defaults to SENTRY_RELEASE.
+	Release string
+	// ServerName is the server_name of the events. Defaults to os.Hostname.
+	ServerName string
+	// Tags are added to every event.
+	Tags map[string]string
+	// FlushInterval is the maximum delay before buffered events are sent. Defaults to 5s.
+	// Panic and fatal events are always sent before Write returns.
+	FlushInterval time.Duration
+	// Timeout bounds each request. Defaults to DefaultTimeout.
+	Timeout time.Duration
+	// Client sends the requests. Defaults to an http.Client with Timeout.
+	Client *http.Client
+}
+
+// event is a Sentry event payload.
+type event struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   time.Time         `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	Logger      string            `json:"logger,omitempty"`
+	Message     *message          `json:"message,omitempty"`
+	Exception   []exception       `json:"exception,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Extra       map[string]any    `json:"extra,omitempty"`
+	Contexts    map[string]any    `json:"contexts,omitempty"`
+}
+
+type message struct {
+	Formatted string `json:"formatted"`
+}
+
+type exception struct {
+	Type       string      `json:"type"`
+	Value      string      `json:"value"`
+	Stacktrace *stacktrace `json:"stacktrace,omitempty"`
+}
+
+type stacktrace struct {
+	Frames []frame `json:"frames"`
+}
+
+type frame struct {
+	Function string `json:"function,omitempty"`
+	Module   string `json:"module,omitempty"`
+	AbsPath  string `json:"abs_path,omitempty"`
+	Lineno   int    `json:"lineno,omitempty"`
+	InApp    bool   `json:"in_app"`
+}
+
+// core is a zapcore.Core sending entries to Sentry as events.
+type core struct {
+	zapcore.LevelEnabler
+	cfg     *Config
+	url     string
+	auth    string
+	batcher *batch.Batcher[*event]
+	fields  []zapcore.Field
+}
+
+// NewConfig returns a Config populated from the application configs: the
+// environment and the application name as the service tag.
+//
+// Parameters:
+//   - cfgs: Application configurations
+//
+// Returns:
+//   - The Sentry sink configuration, with the DSN read from SENTRY_DSN
+func NewConfig(cfgs *configs.Configs) Config {
+	return Config{
+		Environment: cfgs.AppConfigs.Environment.String(),
+		Tags:        map[string]string{"service": cfgs.AppConfigs.Name},
+	}
+}
+
+// NewCore returns a core sending entries at or above level to Sentry. The level
+// is typically zapcore.ErrorLevel.
+//
+// Parameters:
+//   - cfg: Sentry sink configuration
+//   - level: Minimum level sent to Sentry
+//
+// Returns:
+//   - A zapcore.Core ready to be teed into a logger; Sync sends the buffered events
+//   - ErrMissingDSN if no DSN is configured, or ErrInvalidDSN if it cannot be parsed
+func NewCore(cfg Config, level zapcore.LevelEnabler) (zapcore.Core, error) {
+	if cfg.DSN == "" {
+		cfg.DSN = os.Getenv(DSNEnvKey)
+	}
+	if cfg.DSN == "" {
+		return nil, ErrMissingDSN
+	}
+	if cfg.Environment == "" {
+		cfg.Environment = os.Getenv(EnvironmentEnvKey)
+	}
+	if cfg.Release == "" {
+		cfg.Release = os.Getenv(ReleaseEnvKey)
+	}
+	if cfg.ServerName == "" {
+		cfg.ServerName, _ = os.Hostname()
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: cfg.Timeout}
+	}
+
+	endpoint, key, err := parseDSN(cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &core{
+		LevelEnabler: level,
+		cfg:          &cfg,
+		url:          endpoint,
+		auth:         fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s", client, key),
+	}
+	c.batcher = batch.New(batch.Config{
+		Interval: cfg.FlushInterval,
+		OnError:  otel.Handle,
+	}, c.send)
+
+	return c, nil
+}
+
+// parseDSN returns the envelope endpoint and the public key of a DSN of the
+// form "https://<key>@<host>[/<path>]/<project>".
+func parseDSN(dsn string) (string, string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil || u.User == nil || u.User.Username() == "" || u.Host == "" {
+		return "", "", ErrInvalidDSN
+	}
+
+	path := strings.TrimSuffix(u.Path, "/")
+	i := strings.LastIndex(path, "/")
+	if i < 0 || path[i+1:] == "" {
+		return "", "", ErrInvalidDSN
+	}
+
+	endpoint := fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, path[:i], path[i+1:])
+
+	return endpoint, u.User.Username(), nil
+}
+
+// With adds structured context to the core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	merged = append(merged, c.fields...)
+	merged = append(merged, fields...)
+
+	return &core{LevelEnabler: c.LevelEnabler, cfg: c.cfg, url: c.url, auth: c.auth, batcher: c.batcher, fields: merged}
+}
+
+// Check adds the core to the checked entry when the level is enabled.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// loggedError returns the error of f when it is an error field, either a
+// zap.Error or a logging.ErrorField, which wraps the error in an object.
+func loggedError(f zapcore.Field) error {
+	switch f.Type {
+	case zapcore.ErrorType:
+		err, _ := f.Interface.(error)
+		return err
+	case zapcore.ObjectMarshalerType:
+		if w, ok := f.Interface.(interface {
+			error
+			Unwrap() error
+		}); ok && f.Key == "error" {
+			return w.Unwrap()
+		}
+	}
+
+	return nil
+}
+
+// Write buffers the entry as an event. Entries above the error level are sent
+// before returning, since the process is about to panic or exit.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	var logged error
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range append(c.fields[:len(c.fields):len(c.fields)], fields...) {
+		if logged == nil {
+			logged = loggedError(f)
+		}
+		f.AddTo(enc)
+	}
+
+	ev := &event{
+		EventID:     newEventID(),
+		Timestamp:   ent.Time.UTC(),
+		Level:       level(ent.Level),
+		Platform:    "go",
+		Logger:      ent.LoggerName,
+		Message:     &message{Formatted: ent.Message},
+		Environment: c.cfg.Environment,
+		Release:     c.cfg.Release,
+		ServerName:  c.cfg.ServerName,
+		Tags:        c.cfg.Tags,
+	}
+
+	if logged != nil || ent.Stack != "" {
+		exc := exception{Type: ent.Level.CapitalString(), Value: ent.Message}
+		if logged != nil {
+			exc.Type = fmt.Sprintf("%T", logged)
+			exc.Value = logged.Error()
+			delete(enc.Fields, "error")
+			delete(enc.Fields, "errorVerbose")
+		}
+		if frames := parseStack(ent.Stack); len(frames) > 0 {
+			exc.Stacktrace = &stacktrace{Frames: frames}
+		}
+		ev.Exception = []exception{exc}
+	}
+
+	traceID, _ := enc.Fields["trace_id"].(string)
+	spanID, _ := enc.Fields["span_id"].(string)
+	if traceID != "" && spanID != "" {
+		ev.Contexts = map[string]any{
+			"trace": map[string]string{"trace_id": traceID, "span_id": spanID},
+		}
+		delete(enc.Fields, "trace_id")
+		delete(enc.Fields, "span_id")
+	}
+	if len(enc.Fields) > 0 {
+		ev.Extra = enc.Fields
+	}
+
+	c.batcher.Add(ev)
+
+	if ent.Level > zapcore.ErrorLevel {
+		return c.Sync()
+	}
+
+	return nil
+}
+
+// Sync sends the buffered events.
+func (c *core) Sync() error {
+	return c.batcher.Flush()
+}
+
+// Close stops the background flusher and sends the buffered entries.
+func (c *core) Close() error {
+	return c.batcher.Close()
+}
+
+// send posts each event of the batch in its own envelope.
+func (c *core) send(events []*event) error {
+	var errs []error
+
+	for _, ev := range events {
+		if err := c.post(ev); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// post sends an event envelope to Sentry.
+func (c *core) post(ev *event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+
+	header, _ := json.Marshal(map[string]any{"event_id": ev.EventID, "sent_at": time.Now().UTC()})
+	body.Write(header)
+	body.WriteByte('\n')
+	fmt.Fprintf(&body, `{"type":"event","length":%d}`, len(payload))
+	body.WriteByte('\n')
+	body.Write(payload)
+	body.WriteByte('\n')
+
+	req, err := http.NewRequest(http.MethodPost, c.url, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", c.auth)
+
+	resp, err := c.cfg.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send event to sentry: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("failed to send event to sentry: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// parseStack converts a Zap stack trace, made of "function\n\tfile:line"
+// pairs with the innermost call first, into Sentry frames, outermost first.
+func parseStack(stack string) []frame {
+	lines := strings.Split(stack, "\n")
+	frames := make([]frame, 0, len(lines)/2)
+
+	for i := 0; i+1 < len(lines); i += 2 {
+		fn := lines[i]
+		file, line, _ := strings.Cut(strings.TrimSpace(lines[i+1]), ":")
+		lineno, _ := strconv.Atoi(line)
+
+		f := frame{Function: fn, AbsPath: file, Lineno: lineno}
+
+		// Split "github.com/org/pkg.(*Type).Method" into module and function.
+		if slash := strings.LastIndex(fn, "/"); slash >= 0 {
+			if dot := strings.Index(fn[slash:], "."); dot >= 0 {
+				f.Module, f.Function = fn[:slash+dot], fn[slash+dot+1:]
+			}
+		} else if dot := strings.Index(fn, "."); dot >= 0 {
+			f.Module, f.Function = fn[:dot], fn[dot+1:]
+		}
+
+		// Standard library packages have no dot in their first path element.
+		first, _, _ := strings.Cut(f.Module, "/")
+		f.InApp = strings.Contains(first, ".") && !strings.HasPrefix(f.Module, "go.uber.org/zap")
+
+		frames = append(frames, f)
+	}
+
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+
+	return frames
+}
+
+// newEventID returns a random event ID.
+func newEventID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+
+	return hex.EncodeToString(id[:])
+}
+
+// level maps a Zap level to a Sentry level.
+func level(l zapcore.Level) string {
+	switch {
+	case l < zapcore.InfoLevel:
+		return "debug"
+	case l == zapcore.InfoLevel:
+		return "info"
+	case l == zapcore.WarnLevel:
+		return "warning"
+	case l == zapcore.ErrorLevel:
+		return "error"
+	default:
+		return "fatal"
+	}
+}
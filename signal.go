@@ -0,0 +1,105 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// levelToggle raises a level to Debug and restores the level it had before.
+type levelToggle struct {
+	l        Logger
+	level    zap.AtomicLevel
+	previous zapcore.Level
+	raised   bool
+}
+
+// HandleLevelSignals lets operators briefly inspect a running process without
+// redeploying: the minimum level of l switches to Debug on the debug signal
+// (SIGUSR1) and back to the level in effect when it was raised on the restore
+// signal (SIGUSR2), so levels changed since HandleLevelSignals was called, e.g.
+// by Reload, are kept:
+//
+//	kill -USR1 <pid> # debug logs on
+//	kill -USR2 <pid> # back to the previous level
+//
+// On platforms without these signals, such as Windows, it does nothing.
+//
+// Parameters:
+//   - l: The logger whose level is toggled
+//
+// Returns:
+//   - A function that stops handling the signals, safe to call more than once
+func HandleLevelSignals(l Logger) (stop func()) {
+	debug, restore := levelSignals()
+	if debug == nil {
+		return func() {}
+	}
+
+	toggle := &levelToggle{l: l, level: l.AtomicLevel()}
+
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, debug, restore)
+
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				if sig == debug {
+					toggle.raise()
+				} else {
+					toggle.restore()
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
+
+// raise records the level in effect and switches to Debug. Repeated debug
+// signals keep the level recorded by the first one.
+func (t *levelToggle) raise() {
+	if !t.raised {
+		t.previous, t.raised = t.level.Level(), true
+	}
+
+	t.set(zapcore.DebugLevel)
+}
+
+// restore switches back to the level recorded by raise, if any.
+func (t *levelToggle) restore() {
+	if !t.raised {
+		return
+	}
+
+	t.raised = false
+	t.set(t.previous)
+}
+
+// set applies the level requested by a signal and logs the change.
+func (t *levelToggle) set(next zapcore.Level) {
+	if t.level.Level() == next {
+		return
+	}
+
+	t.level.SetLevel(next)
+	t.l.Warn("log level changed by signal", zap.Stringer("level", next))
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+//go:build !unix
+
+package logging
+
+import "os"
+
+// levelSignals returns no signals: the platform has no user-defined signals.
+func levelSignals() (debug, restore os.Signal) {
+	return nil, nil
+}
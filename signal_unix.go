@@ -0,0 +1,17 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+//go:build unix
+
+package logging
+
+import (
+	"os"
+	"syscall"
+)
+
+// levelSignals returns the signals switching to Debug and back.
+func levelSignals() (debug, restore os.Signal) {
+	return syscall.SIGUSR1, syscall.SIGUSR2
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+
+	"github.com/goxkit/configs"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// slogHandler implements slog.Handler on top of a zapcore.Core.
+type slogHandler struct {
+	core   zapcore.Core
+	name   string
+	groups []string
+}
+
+// NewSlogHandler returns a *slog.Logger backed by the logger configured in cfgs.
+// When cfgs.Logger is not yet set, the logger is created through NewLogger.
+// The context passed to the slog *Context methods is forwarded to the
+// OpenTelemetry core, keeping the trace correlation of the exported records.
+//
+// Parameters:
+//   - cfgs: Application configurations holding (or used to build) the logger
+//
+// Returns:
+//   - A *slog.Logger writing into the Zap/OTLP pipeline
+//   - An error if the logger initialization fails
+func NewSlogHandler(cfgs *configs.Configs) (*slog.Logger, error) {
+	logger := cfgs.Logger
+	if logger == nil {
+		l, err := NewLogger(context.Background(), cfgs)
+		if err != nil {
+			return nil, err
+		}
+
+		logger = l.With()
+	}
+
+	return slog.New(SlogHandler(logger)), nil
+}
+
+// SlogHandler returns a slog.Handler writing into the core of the given logger,
+// for third-party libraries that accept a slog.Handler.
+//
+// Parameters:
+//   - logger: The Zap logger records are routed to
+//
+// Returns:
+//   - A slog.Handler backed by the logger's core
+func SlogHandler(logger *zap.Logger) slog.Handler {
+	return &slogHandler{core: logger.Core(), name: logger.Name()}
+}
+
+// Enabled reports whether the underlying core accepts entries at the given level.
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.core.Enabled(slogToZapLevel(level))
+}
+
+// Handle converts the record into a Zap entry and writes it to the core.
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	entry := zapcore.Entry{
+		Level:      slogToZapLevel(record.Level),
+		Time:       record.Time,
+		Message:    record.Message,
+		LoggerName: h.name,
+	}
+
+	checked := h.core.Check(entry, nil)
+	if checked == nil {
+		return nil
+	}
+
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		checked.Caller = zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, frame.PC != 0)
+		checked.Caller.Function = frame.Function
+	}
+
+	fields := make([]zap.Field, 0, record.NumAttrs()+len(h.groups)+1)
+	if ctx != nil {
+		fields = append(fields, ContextField(ctx))
+	}
+
+	if record.NumAttrs() > 0 {
+		fields = appendGroups(fields, h.groups)
+		record.Attrs(func(attr slog.Attr) bool {
+			fields = appendSlogAttr(fields, attr)
+			return true
+		})
+	}
+
+	checked.Write(fields...)
+
+	return nil
+}
+
+// WithAttrs returns a handler whose core includes the given attributes.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	fields := appendGroups(make([]zap.Field, 0, len(attrs)+len(h.groups)), h.groups)
+	fields = append(fields, appendSlogAttrs(attrs)...)
+
+	return &slogHandler{core: h.core.With(fields), name: h.name}
+}
+
+// WithGroup returns a handler that nests the following attributes under name.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	groups := make([]string, 0, len(h.groups)+1)
+	groups = append(groups, h.groups...)
+
+	return &slogHandler{core: h.core, name: h.name, groups: append(groups, name)}
+}
+
+// appendGroups opens a namespace for each pending group.
+func appendGroups(fields []zap.Field, groups []string) []zap.Field {
+	for _, g := range groups {
+		fields = append(fields, zap.Namespace(g))
+	}
+
+	return fields
+}
+
+// appendSlogAttr converts a slog.Attr to a zap.Field, following the slog.Handler
+// rules: empty attributes are ignored and groups without a key are inlined.
+func appendSlogAttr(fields []zap.Field, attr slog.Attr) []zap.Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindBool:
+		return append(fields, zap.Bool(attr.Key, attr.Value.Bool()))
+	case slog.KindDuration:
+		return append(fields, zap.Duration(attr.Key, attr.Value.Duration()))
+	case slog.KindFloat64:
+		return append(fields, zap.Float64(attr.Key, attr.Value.Float64()))
+	case slog.KindInt64:
+		return append(fields, zap.Int64(attr.Key, attr.Value.Int64()))
+	case slog.KindString:
+		return append(fields, zap.String(attr.Key, attr.Value.String()))
+	case slog.KindTime:
+		return append(fields, zap.Time(attr.Key, attr.Value.Time()))
+	case slog.KindUint64:
+		return append(fields, zap.Uint64(attr.Key, attr.Value.Uint64()))
+	case slog.KindGroup:
+		group := attr.Value.Group()
+		if len(group) == 0 {
+			return fields
+		}
+
+		marshaler := slogGroup(group)
+		if attr.Key == "" {
+			return append(fields, zap.Inline(marshaler))
+		}
+
+		return append(fields, zap.Object(attr.Key, marshaler))
+	default:
+		if err, ok := attr.Value.Any().(error); ok {
+			return append(fields, zap.NamedError(attr.Key, err))
+		}
+
+		return append(fields, zap.Any(attr.Key, attr.Value.Any()))
+	}
+}
+
+// slogGroup marshals the attributes of a slog group as a Zap object.
+type slogGroup []slog.Attr
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (g slogGroup) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, field := range appendSlogAttrs(g) {
+		field.AddTo(enc)
+	}
+
+	return nil
+}
+
+// appendSlogAttrs converts a list of slog attributes to Zap fields.
+func appendSlogAttrs(attrs []slog.Attr) []zap.Field {
+	fields := make([]zap.Field, 0, len(attrs))
+	for _, attr := range attrs {
+		fields = appendSlogAttr(fields, attr)
+	}
+
+	return fields
+}
+
+// slogToZapLevel maps slog levels to the closest Zap level.
+func slogToZapLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zapcore.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zapcore.WarnLevel
+	case level >= slog.LevelInfo:
+		return zapcore.InfoLevel
+	default:
+		return zapcore.DebugLevel
+	}
+}
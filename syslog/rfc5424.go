@@ -0,0 +1,146 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package syslog
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Severity is the syslog severity of a message.
+type Severity int
+
+// Syslog severities defined by RFC 5424.
+const (
+	Emergency Severity = iota
+	Alert
+	Critical
+	Error
+	Warning
+	Notice
+	Informational
+	Debug
+)
+
+// severity maps a Zap level to its syslog severity.
+func severity(level zapcore.Level) Severity {
+	switch level {
+	case zapcore.DebugLevel:
+		return Debug
+	case zapcore.InfoLevel:
+		return Informational
+	case zapcore.WarnLevel:
+		return Warning
+	case zapcore.ErrorLevel:
+		return Error
+	case zapcore.DPanicLevel:
+		return Critical
+	case zapcore.PanicLevel:
+		return Alert
+	case zapcore.FatalLevel:
+		return Emergency
+	default:
+		return Notice
+	}
+}
+
+// structuredData renders the fields as a single RFC 5424 SD-ELEMENT. Nested
+// objects are flattened with dotted parameter names. It returns the nil value
+// when there are no fields.
+func structuredData(sdID string, fields []zapcore.Field) string {
+	if len(fields) == 0 {
+		return nilValue
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	params := map[string]string{}
+	flatten("", enc.Fields, params)
+	if len(params) == 0 {
+		return nilValue
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteByte('[')
+	b.WriteString(sdID)
+
+	for _, name := range names {
+		fmt.Fprintf(&b, ` %s="%s"`, name, escapeParamValue(params[name]))
+	}
+
+	b.WriteByte(']')
+
+	return b.String()
+}
+
+// flatten converts nested values into dotted SD-PARAM names and string values.
+func flatten(prefix string, value any, params map[string]string) {
+	if m, ok := value.(map[string]any); ok {
+		for k, v := range m {
+			name := k
+			if prefix != "" {
+				name = prefix + "." + k
+			}
+			flatten(name, v, params)
+		}
+		return
+	}
+
+	name := paramName(prefix)
+	if name == "" {
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		params[name] = v
+	case fmt.Stringer:
+		params[name] = v.String()
+	case []any:
+		raw, _ := json.Marshal(v)
+		params[name] = string(raw)
+	default:
+		if raw, err := json.Marshal(v); err == nil {
+			params[name] = strings.Trim(string(raw), `"`)
+		} else {
+			params[name] = fmt.Sprint(v)
+		}
+	}
+}
+
+// paramName sanitizes an SD-PARAM name: printable ASCII except '=', ' ', ']'
+// and '"', with at most 32 characters.
+func paramName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, name)
+
+	if len(name) > 32 {
+		return name[:32]
+	}
+
+	return name
+}
+
+// escapeParamValue escapes '"', '\' and ']' as required by RFC 5424.
+func escapeParamValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
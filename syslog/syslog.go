@@ -0,0 +1,336 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package syslog provides an RFC 5424 syslog sink for the logging framework.
+// Entries are sent over UDP, TCP (optionally TLS) or unix sockets, with Zap
+// fields mapped to RFC 5424 structured data and Zap levels mapped to syslog
+// severities.
+package syslog
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+	"github.com/goxkit/logging/internal/pool"
+)
+
+// Facility is the syslog facility code of the messages.
+type Facility int
+
+// Syslog facilities defined by RFC 5424.
+const (
+	Kern Facility = iota
+	User
+	Mail
+	Daemon
+	Auth
+	Syslog
+	Lpr
+	News
+	Uucp
+	Cron
+	Authpriv
+	Ftp
+	_
+	_
+	_
+	_
+	Local0
+	Local1
+	Local2
+	Local3
+	Local4
+	Local5
+	Local6
+	Local7
+)
+
+// facilities maps the conventional facility names to their codes.
+var facilities = map[string]Facility{
+	"kern": Kern, "user": User, "mail": Mail, "daemon": Daemon,
+	"auth": Auth, "syslog": Syslog, "lpr": Lpr, "news": News,
+	"uucp": Uucp, "cron": Cron, "authpriv": Authpriv, "ftp": Ftp,
+	"local0": Local0, "local1": Local1, "local2": Local2, "local3": Local3,
+	"local4": Local4, "local5": Local5, "local6": Local6, "local7": Local7,
+}
+
+// ParseFacility returns the facility with the given conventional name, e.g.
+// "daemon" or "local0".
+//
+// Parameters:
+//   - name: The case-insensitive facility name
+//
+// Returns:
+//   - The facility
+//   - An error if the name is unknown
+func ParseFacility(name string) (Facility, error) {
+	if f, ok := facilities[strings.ToLower(name)]; ok {
+		return f, nil
+	}
+
+	return 0, fmt.Errorf("unknown syslog facility %q", name)
+}
+
+const (
+	// DefaultSDID is the structured data ID holding the entry fields.
+	DefaultSDID = "fields@32473"
+	// DefaultTimeout bounds dialing and writing to the syslog server.
+	DefaultTimeout = 5 * time.Second
+	// nilValue is the RFC 5424 placeholder for empty header fields.
+	nilValue = "-"
+)
+
+// Config describes the syslog sink.
+type Config struct {
+	// Network is "udp", "tcp", "unix" or "unixgram". Defaults to "udp".
+	Network string
+	// Address is the syslog server address (host:port or socket path).
+	Address string
+	// TLSConfig, when set with the "tcp" network, dials the server over TLS.
+	TLSConfig *tls.Config
+	// Facility of the messages. Defaults to User.
+	Facility Facility
+	// AppName identifies the application. Defaults to the process name.
+	AppName string
+	// Hostname identifies the host. Defaults to os.Hostname.
+	Hostname string
+	// SDID is the structured data ID holding the fields. Defaults to DefaultSDID.
+	SDID string
+	// Timeout bounds dialing and each write. Defaults to DefaultTimeout.
+	Timeout time.Duration
+}
+
+// conn is the connection to the syslog server, shared by every core derived
+// through With.
+type conn struct {
+	mu      sync.Mutex
+	cfg     *Config
+	c       net.Conn
+	framing bool
+	// closed rejects the writes once Close was called.
+	closed bool
+}
+
+// core is a zapcore.Core writing RFC 5424 messages to a syslog server.
+type core struct {
+	zapcore.LevelEnabler
+	conn   *conn
+	fields []zapcore.Field
+}
+
+// NewCore connects to the syslog server described by cfg and returns a core
+// writing entries at or above level to it.
+//
+// Parameters:
+//   - cfg: Syslog sink configuration
+//   - level: Minimum level sent to syslog
+//
+// Returns:
+//   - A zapcore.Core ready to be teed into a logger; its Close method closes
+//     the connection
+//   - An error if the connection to the server fails
+func NewCore(cfg Config, level zapcore.LevelEnabler) (zapcore.Core, error) {
+	if cfg.Network == "" {
+		cfg.Network = "udp"
+	}
+	if cfg.AppName == "" {
+		cfg.AppName = appName()
+	}
+	if cfg.Hostname == "" {
+		cfg.Hostname, _ = os.Hostname()
+	}
+	if cfg.SDID == "" {
+		cfg.SDID = DefaultSDID
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	c := &conn{cfg: &cfg, framing: cfg.Network == "tcp"}
+	if err := c.dial(); err != nil {
+		return nil, err
+	}
+
+	return &core{LevelEnabler: level, conn: c}, nil
+}
+
+// With adds structured context to the core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	merged = append(merged, c.fields...)
+	merged = append(merged, fields...)
+
+	return &core{LevelEnabler: c.LevelEnabler, conn: c.conn, fields: merged}
+}
+
+// Check adds the core to the checked entry when the level is enabled.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write formats the entry as an RFC 5424 message and sends it to the server.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	all := pool.Concat(c.fields, fields)
+	msg := c.conn.format(ent, *all)
+	pool.PutFields(all)
+	defer msg.Free()
+
+	return c.conn.write(msg.Bytes())
+}
+
+// Sync is a no-op, messages are sent unbuffered.
+func (c *core) Sync() error {
+	return nil
+}
+
+// Close closes the connection to the syslog server, shared with the With children.
+// Entries written afterwards fail with net.ErrClosed.
+func (c *core) Close() error {
+	c.conn.mu.Lock()
+	defer c.conn.mu.Unlock()
+
+	c.conn.closed = true
+	if c.conn.c == nil {
+		return nil
+	}
+
+	err := c.conn.c.Close()
+	c.conn.c = nil
+
+	return err
+}
+
+// dial opens the connection to the syslog server.
+func (c *conn) dial() error {
+	dialer := &net.Dialer{Timeout: c.cfg.Timeout}
+
+	var (
+		nc  net.Conn
+		err error
+	)
+
+	if c.cfg.TLSConfig != nil && c.cfg.Network == "tcp" {
+		nc, err = tls.DialWithDialer(dialer, "tcp", c.cfg.Address, c.cfg.TLSConfig)
+	} else {
+		nc, err = dialer.Dial(c.cfg.Network, c.cfg.Address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to syslog server: %w", err)
+	}
+
+	c.c = nc
+
+	return nil
+}
+
+// write sends a message, reconnecting once when the connection was lost.
+func (c *conn) write(msg []byte) error {
+	if c.framing {
+		// RFC 6587 octet counting, required by stream transports.
+		framed := pool.GetBuffer()
+		defer framed.Free()
+
+		framed.AppendInt(int64(len(msg)))
+		framed.AppendByte(' ')
+		_, _ = framed.Write(msg)
+		msg = framed.Bytes()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return net.ErrClosed
+	}
+
+	if c.c != nil {
+		_ = c.c.SetWriteDeadline(time.Now().Add(c.cfg.Timeout))
+		if _, err := c.c.Write(msg); err == nil {
+			return nil
+		}
+
+		_ = c.c.Close()
+		c.c = nil
+	}
+
+	if err := c.dial(); err != nil {
+		return err
+	}
+
+	_ = c.c.SetWriteDeadline(time.Now().Add(c.cfg.Timeout))
+	_, err := c.c.Write(msg)
+
+	return err
+}
+
+// format renders an entry as an RFC 5424 message:
+// <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [SD-ID params] MSG, in a
+// pooled buffer freed by the caller.
+func (c *conn) format(ent zapcore.Entry, fields []zapcore.Field) *buffer.Buffer {
+	b := pool.GetBuffer()
+
+	pri := int(c.cfg.Facility)*8 + int(severity(ent.Level))
+	fmt.Fprintf(b, "<%d>1 %s %s %s %d %s ",
+		pri,
+		ent.Time.UTC().Format(time.RFC3339Nano),
+		header(c.cfg.Hostname, 255),
+		header(c.cfg.AppName, 48),
+		os.Getpid(),
+		header(ent.LoggerName, 32),
+	)
+
+	b.AppendString(structuredData(c.cfg.SDID, fields))
+	b.AppendByte(' ')
+	b.AppendString(ent.Message)
+
+	if ent.Stack != "" {
+		b.AppendByte('\n')
+		b.AppendString(ent.Stack)
+	}
+
+	return b
+}
+
+// header sanitizes an RFC 5424 header field: printable ASCII, bounded length,
+// and the nil value when empty.
+func header(value string, maxLen int) string {
+	value = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, value)
+
+	if value == "" {
+		return nilValue
+	}
+	if len(value) > maxLen {
+		return value[:maxLen]
+	}
+
+	return value
+}
+
+// appName returns the executable name.
+func appName() string {
+	name := os.Args[0]
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return name
+}
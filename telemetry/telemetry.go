@@ -0,0 +1,252 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package telemetry holds the self-telemetry of the logging pipeline: the
+// entries emitted per level, the entries dropped by the samplers, the rate
+// limits and the asynchronous queues, and the OTLP export batches, failures and
+// retries. The counters are process-wide, so operators can detect silent log
+// loss through OpenTelemetry metrics (see Register) or an expvar snapshot (see
+// PublishExpvar).
+package telemetry
+
+import (
+	"context"
+	"expvar"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap/zapcore"
+)
+
+// ScopeName is the instrumentation scope of the self-telemetry metrics.
+const ScopeName = "github.com/goxkit/logging"
+
+// DefaultExpvarName is the expvar name of the snapshot published by PublishExpvar.
+const DefaultExpvarName = "logging"
+
+// Reasons of the dropped entries, recorded as the reason attribute of the
+// log.entries.dropped metric.
+const (
+	SamplingReason  = "sampling"
+	RateLimitReason = "rate_limit"
+	AsyncReason     = "async_queue"
+)
+
+// Stats is a snapshot of the self-telemetry counters.
+type Stats struct {
+	// Emitted is the number of entries written to the outputs, per level name.
+	Emitted map[string]uint64 `json:"emitted"`
+	// Sampled is the number of entries dropped by the samplers.
+	Sampled uint64 `json:"sampled"`
+	// RateLimited is the number of entries dropped by the rate limits.
+	RateLimited uint64 `json:"rate_limited"`
+	// AsyncDropped is the number of entries dropped by full asynchronous queues.
+	AsyncDropped uint64 `json:"async_dropped"`
+	// ExportBatches is the number of OTLP export requests.
+	ExportBatches uint64 `json:"export_batches"`
+	// ExportedRecords is the number of records sent in the OTLP export requests.
+	ExportedRecords uint64 `json:"exported_records"`
+	// ExportFailures is the number of failed OTLP export requests.
+	ExportFailures uint64 `json:"export_failures"`
+	// DeadLettered is the number of records written to the OTLP dead-letter file.
+	DeadLettered uint64 `json:"dead_lettered"`
+	// ExportRetries is the number of attempts to recreate an unavailable OTLP
+	// exporter.
+	ExportRetries uint64 `json:"export_retries"`
+}
+
+var (
+	emitted [zapcore.FatalLevel - zapcore.DebugLevel + 1]atomic.Uint64
+
+	sampled, rateLimited, asyncDropped atomic.Uint64
+
+	exportBatches, exportedRecords, exportFailures, exportRetries, deadLettered atomic.Uint64
+
+	// registered holds the meter providers the metrics are registered with.
+	registered   = map[metric.MeterProvider]struct{}{}
+	registeredMu sync.Mutex
+)
+
+// AddEmitted counts an entry written to the outputs at level.
+func AddEmitted(level zapcore.Level) {
+	if level >= zapcore.DebugLevel && level <= zapcore.FatalLevel {
+		emitted[level-zapcore.DebugLevel].Add(1)
+	}
+}
+
+// AddSampled counts an entry dropped by a sampler.
+func AddSampled() {
+	sampled.Add(1)
+}
+
+// AddRateLimited counts an entry dropped by a rate limit.
+func AddRateLimited() {
+	rateLimited.Add(1)
+}
+
+// AddAsyncDropped counts an entry dropped by a full asynchronous queue.
+func AddAsyncDropped() {
+	asyncDropped.Add(1)
+}
+
+// AddExport counts an OTLP export request of records, failed when err is set.
+func AddExport(records int, err error) {
+	exportBatches.Add(1)
+	exportedRecords.Add(uint64(records))
+
+	if err != nil {
+		exportFailures.Add(1)
+	}
+}
+
+// AddExportRetry counts an attempt to recreate an unavailable OTLP exporter.
+func AddExportRetry() {
+	exportRetries.Add(1)
+}
+
+// AddDeadLettered counts records written to the OTLP dead-letter file.
+func AddDeadLettered(records int) {
+	deadLettered.Add(uint64(records))
+}
+
+// Snapshot returns the current values of the counters.
+//
+// Returns:
+//   - The Stats of the process
+func Snapshot() Stats {
+	s := Stats{
+		Emitted:         make(map[string]uint64, len(emitted)),
+		Sampled:         sampled.Load(),
+		RateLimited:     rateLimited.Load(),
+		AsyncDropped:    asyncDropped.Load(),
+		ExportBatches:   exportBatches.Load(),
+		ExportedRecords: exportedRecords.Load(),
+		ExportFailures:  exportFailures.Load(),
+		ExportRetries:   exportRetries.Load(),
+		DeadLettered:    deadLettered.Load(),
+	}
+
+	for i := range emitted {
+		s.Emitted[(zapcore.DebugLevel + zapcore.Level(i)).String()] = emitted[i].Load()
+	}
+
+	return s
+}
+
+// PublishExpvar publishes the snapshot of the counters as an expvar variable,
+// served as JSON by the /debug/vars handler of the expvar package. Publishing
+// an already published name does nothing.
+//
+// Parameters:
+//   - name: The expvar name, DefaultExpvarName when empty
+func PublishExpvar(name string) {
+	if name == "" {
+		name = DefaultExpvarName
+	}
+
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+
+	if expvar.Get(name) == nil {
+		expvar.Publish(name, expvar.Func(func() any { return Snapshot() }))
+	}
+}
+
+// Register exposes the counters as OpenTelemetry observable counters of
+// provider: log.entries.emitted (level attribute), log.entries.dropped (reason
+// attribute), log.export.batches, log.export.records, log.export.failures and
+// log.export.retries and log.export.dead_lettered. Registering the same provider again does nothing.
+//
+// Parameters:
+//   - provider: The meter provider of the metrics
+//
+// Returns:
+//   - An error if an instrument or the callback cannot be registered
+func Register(provider metric.MeterProvider) error {
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+
+	// Providers that cannot be map keys are registered every time.
+	comparable := reflect.TypeOf(provider).Comparable()
+	if comparable {
+		if _, ok := registered[provider]; ok {
+			return nil
+		}
+	}
+
+	meter := provider.Meter(ScopeName)
+
+	emittedCounter, err := meter.Int64ObservableCounter("log.entries.emitted",
+		metric.WithDescription("Number of entries written to the outputs."), metric.WithUnit("{entry}"))
+	if err != nil {
+		return err
+	}
+
+	droppedCounter, err := meter.Int64ObservableCounter("log.entries.dropped",
+		metric.WithDescription("Number of entries dropped before reaching the outputs."), metric.WithUnit("{entry}"))
+	if err != nil {
+		return err
+	}
+
+	batchCounter, err := meter.Int64ObservableCounter("log.export.batches",
+		metric.WithDescription("Number of OTLP export requests."), metric.WithUnit("{batch}"))
+	if err != nil {
+		return err
+	}
+
+	recordCounter, err := meter.Int64ObservableCounter("log.export.records",
+		metric.WithDescription("Number of records sent in OTLP export requests."), metric.WithUnit("{record}"))
+	if err != nil {
+		return err
+	}
+
+	failureCounter, err := meter.Int64ObservableCounter("log.export.failures",
+		metric.WithDescription("Number of failed OTLP export requests."), metric.WithUnit("{batch}"))
+	if err != nil {
+		return err
+	}
+
+	retryCounter, err := meter.Int64ObservableCounter("log.export.retries",
+		metric.WithDescription("Number of attempts to recreate an unavailable OTLP exporter."), metric.WithUnit("{attempt}"))
+	if err != nil {
+		return err
+	}
+
+	deadLetterCounter, err := meter.Int64ObservableCounter("log.export.dead_lettered",
+		metric.WithDescription("Number of records written to the OTLP dead-letter file."), metric.WithUnit("{record}"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		s := Snapshot()
+
+		for level, n := range s.Emitted {
+			o.ObserveInt64(emittedCounter, int64(n), metric.WithAttributes(attribute.String("level", level)))
+		}
+
+		o.ObserveInt64(droppedCounter, int64(s.Sampled), metric.WithAttributes(attribute.String("reason", SamplingReason)))
+		o.ObserveInt64(droppedCounter, int64(s.RateLimited), metric.WithAttributes(attribute.String("reason", RateLimitReason)))
+		o.ObserveInt64(droppedCounter, int64(s.AsyncDropped), metric.WithAttributes(attribute.String("reason", AsyncReason)))
+		o.ObserveInt64(batchCounter, int64(s.ExportBatches))
+		o.ObserveInt64(recordCounter, int64(s.ExportedRecords))
+		o.ObserveInt64(failureCounter, int64(s.ExportFailures))
+		o.ObserveInt64(retryCounter, int64(s.ExportRetries))
+		o.ObserveInt64(deadLetterCounter, int64(s.DeadLettered))
+
+		return nil
+	}, emittedCounter, droppedCounter, batchCounter, recordCounter, failureCounter, retryCounter, deadLetterCounter)
+	if err != nil {
+		return err
+	}
+
+	if comparable {
+		registered[provider] = struct{}{}
+	}
+
+	return nil
+}
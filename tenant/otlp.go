@@ -0,0 +1,133 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package tenant
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+
+	"go.opentelemetry.io/contrib/bridges/otelzap"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/otlp"
+)
+
+// DefaultOTLPHeader is the header carrying the tenant ID when OTLP.Header is
+// empty, as expected by Loki, Mimir and the multi-tenant collectors.
+const DefaultOTLPHeader = "X-Scope-OrgID"
+
+// ErrSharedConn is returned when the OTLP configuration shares a gRPC
+// connection, whose requests cannot carry per-tenant headers.
+var ErrSharedConn = errors.New("tenant otlp export cannot use a shared grpc connection")
+
+type (
+	// OTLP builds the outputs of the tenants exporting to OpenTelemetry, each
+	// tenant through a logger provider of its own sending the tenant ID in a
+	// header of the export requests. Its New method is meant for Config.New.
+	OTLP struct {
+		// Config is the export pipeline of every tenant. Its Headers are
+		// completed with the tenant header; Conn must be nil.
+		Config otlp.Config
+		// Header carries the tenant ID. Defaults to DefaultOTLPHeader.
+		Header string
+		// Name is the instrumentation scope of the records. Defaults to the
+		// service name of Config.
+		Name string
+
+		mu        sync.Mutex
+		providers []*sdklog.LoggerProvider
+	}
+
+	// providerCore is an OpenTelemetry core whose Sync exports the records
+	// pending in its provider.
+	providerCore struct {
+		zapcore.Core
+		provider *sdklog.LoggerProvider
+	}
+)
+
+// New creates the logger provider of a tenant and returns a core exporting to it.
+//
+// Parameters:
+//   - tenant: The tenant ID
+//
+// Returns:
+//   - A zapcore.Core exporting the entries of tenant
+//   - ErrSharedConn if Config shares a gRPC connection, or an error if the
+//     provider cannot be created
+func (o *OTLP) New(tenant string) (zapcore.Core, error) {
+	cfg := o.Config
+	if cfg.Conn != nil && (cfg.Protocol == otlp.GRPCProtocol || cfg.Protocol == "") {
+		return nil, ErrSharedConn
+	}
+
+	header := o.Header
+	if header == "" {
+		header = DefaultOTLPHeader
+	}
+
+	cfg.Headers = maps.Clone(cfg.Headers)
+	if cfg.Headers == nil {
+		cfg.Headers = make(map[string]string, 1)
+	}
+	cfg.Headers[header] = tenant
+
+	ctx, cancel := otlp.WithStartupTimeout(context.Background(), 0)
+	defer cancel()
+
+	provider, err := otlp.NewProvider(ctx, &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	o.mu.Lock()
+	o.providers = append(o.providers, provider)
+	o.mu.Unlock()
+
+	name := o.Name
+	if name == "" {
+		name = cfg.ServiceName
+	}
+
+	return &providerCore{
+		Core:     otelzap.NewCore(name, otelzap.WithLoggerProvider(provider)),
+		provider: provider,
+	}, nil
+}
+
+// Shutdown exports the pending records of every tenant and stops their
+// providers. It should be called on graceful shutdown.
+//
+// Parameters:
+//   - ctx: Context bounding the shutdown
+//
+// Returns:
+//   - The errors of the providers, joined
+func (o *OTLP) Shutdown(ctx context.Context) error {
+	o.mu.Lock()
+	providers := o.providers
+	o.providers = nil
+	o.mu.Unlock()
+
+	var errs []error
+	for _, p := range providers {
+		errs = append(errs, p.Shutdown(ctx))
+	}
+
+	return errors.Join(errs...)
+}
+
+// With adds structured context to the core.
+func (c *providerCore) With(fields []zapcore.Field) zapcore.Core {
+	return &providerCore{Core: c.Core.With(fields), provider: c.provider}
+}
+
+// Sync exports the records pending in the provider.
+func (c *providerCore) Sync() error {
+	return c.provider.ForceFlush(context.Background())
+}
@@ -0,0 +1,266 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package tenant provides a core routing entries to per-tenant outputs, so SaaS
+// platforms keep the log streams of their customers apart. The tenant of an
+// entry is the value of its tenant field, usually added once to a child logger
+// through With; entries without tenant go to a default output.
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging"
+	"github.com/goxkit/logging/internal/checked"
+	"github.com/goxkit/logging/internal/pool"
+)
+
+const (
+	// DefaultField is the field holding the tenant ID when Config.Field is empty.
+	DefaultField = "tenant_id"
+	// DefaultMaxTenants bounds the outputs built by Config.New when
+	// Config.MaxTenants is zero.
+	DefaultMaxTenants = 1000
+)
+
+// ErrTooManyTenants is reported when the outputs of MaxTenants tenants are
+// built; the entries of new tenants go to the default output.
+var ErrTooManyTenants = errors.New("too many tenants, routing new tenants to the default log output")
+
+type (
+	// Config describes the routing of the entries.
+	Config struct {
+		// Field names the field holding the tenant ID. Defaults to DefaultField.
+		Field string
+		// Cores are the outputs of known tenants, by tenant ID.
+		Cores map[string]zapcore.Core
+		// New builds the output of a tenant missing from Cores on its first
+		// entry, e.g. OTLP.New. The output is reused for the following
+		// entries; when New fails, the error is reported and the entries of
+		// the tenant go to Default.
+		New func(tenant string) (zapcore.Core, error)
+		// Default receives the entries without tenant, or whose tenant has no
+		// output. When nil, these entries are dropped.
+		Default zapcore.Core
+		// MaxTenants bounds the number of outputs built by New. Defaults to
+		// DefaultMaxTenants.
+		MaxTenants int
+	}
+
+	// state is shared by a core and its With children.
+	state struct {
+		cfg *Config
+
+		mu    sync.RWMutex
+		cores map[string]zapcore.Core
+		built int
+		full  bool
+	}
+
+	// core is a zapcore.Core writing each entry to the output of its tenant.
+	core struct {
+		zapcore.LevelEnabler
+		state  *state
+		fields []zapcore.Field
+		tenant string
+	}
+)
+
+// NewCore returns a core routing the entries at or above level to the output of
+// their tenant. Context fields are kept by the core and written with every
+// entry, so the tenant may be added before or after them.
+//
+//	router := tenant.NewCore(tenant.Config{New: otlpTenants.New, Default: sharedCore}, zapcore.InfoLevel)
+//	logger, err := logging.NewLogger(ctx, cfgs, zapInstance.WithCores(router))
+//
+// Parameters:
+//   - cfg: Routing configuration
+//   - level: Minimum level routed to the tenant outputs
+//
+// Returns:
+//   - A zapcore.Core ready to be teed into a logger; Sync flushes every output
+func NewCore(cfg Config, level zapcore.LevelEnabler) zapcore.Core {
+	if cfg.Field == "" {
+		cfg.Field = DefaultField
+	}
+	if cfg.MaxTenants <= 0 {
+		cfg.MaxTenants = DefaultMaxTenants
+	}
+
+	cores := make(map[string]zapcore.Core, len(cfg.Cores))
+	for id, c := range cfg.Cores {
+		cores[id] = c
+	}
+
+	return &core{LevelEnabler: level, state: &state{cfg: &cfg, cores: cores}}
+}
+
+// Field returns the tenant field of DefaultField.
+//
+// Parameters:
+//   - tenant: The tenant ID
+//
+// Returns:
+//   - A zap.Field selecting the output of tenant
+func Field(tenant string) zap.Field {
+	return zap.String(DefaultField, tenant)
+}
+
+// With returns a child logger whose entries are routed to the output of tenant.
+//
+// Parameters:
+//   - l: The parent logger
+//   - tenant: The tenant ID
+//
+// Returns:
+//   - A Logger adding the DefaultField tenant field to every entry
+func With(l logging.Logger, tenant string) logging.Logger {
+	return logging.WithFields(l, Field(tenant))
+}
+
+// With adds structured context to the core, recording the tenant it selects.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	merged = append(merged, c.fields...)
+	merged = append(merged, fields...)
+
+	clone := &core{LevelEnabler: c.LevelEnabler, state: c.state, fields: merged, tenant: c.tenant}
+	if id, ok := c.state.tenantOf(fields); ok {
+		clone.tenant = id
+	}
+
+	return clone
+}
+
+// Check adds the core to the checked entry when the level is enabled.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write writes the entry to the output of its tenant.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	id := c.tenant
+	if v, ok := c.state.tenantOf(fields); ok {
+		id = v
+	}
+
+	target := c.state.output(id)
+	if target == nil || !target.Enabled(ent.Level) {
+		return nil
+	}
+
+	all := pool.Concat(c.fields, fields)
+	defer pool.PutFields(all)
+
+	return target.Write(ent, *all)
+}
+
+// Sync flushes the default output and the output of every tenant.
+func (c *core) Sync() error {
+	s := c.state
+
+	s.mu.RLock()
+	cores := make([]zapcore.Core, 0, len(s.cores)+1)
+	for _, tc := range s.cores {
+		if tc != nil {
+			cores = append(cores, tc)
+		}
+	}
+	s.mu.RUnlock()
+
+	if s.cfg.Default != nil {
+		cores = append(cores, s.cfg.Default)
+	}
+
+	var errs []error
+	for _, tc := range cores {
+		errs = append(errs, tc.Sync())
+	}
+
+	return errors.Join(errs...)
+}
+
+// tenantOf returns the value of the last tenant field among fields.
+func (s *state) tenantOf(fields []zapcore.Field) (string, bool) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		if fields[i].Key == s.cfg.Field {
+			return fieldValue(fields[i]), true
+		}
+	}
+
+	return "", false
+}
+
+// output returns the output of a tenant, building it on its first entry, or
+// the default output.
+func (s *state) output(id string) zapcore.Core {
+	if id == "" {
+		return s.cfg.Default
+	}
+
+	s.mu.RLock()
+	c, ok := s.cores[id]
+	s.mu.RUnlock()
+
+	if !ok && s.cfg.New != nil {
+		c = s.build(id)
+	}
+	if c == nil {
+		return s.cfg.Default
+	}
+
+	return c
+}
+
+// build creates and records the output of a tenant. Failures are recorded as
+// a nil output, so New is not called for every entry of a misconfigured tenant.
+func (s *state) build(id string) zapcore.Core {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.cores[id]; ok {
+		return c
+	}
+
+	if s.built >= s.cfg.MaxTenants {
+		if !s.full {
+			s.full = true
+			otel.Handle(ErrTooManyTenants)
+		}
+		return nil
+	}
+
+	c, err := s.cfg.New(id)
+	if err != nil {
+		otel.Handle(fmt.Errorf("log output of tenant %s: %w", id, err))
+		c = nil
+	}
+
+	s.cores[id] = c
+	s.built++
+
+	return c
+}
+
+// fieldValue returns the string form of the value of f, as a tenant ID.
+func fieldValue(f zapcore.Field) string {
+	if f.Type == zapcore.StringType {
+		return f.String
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+
+	return fmt.Sprint(enc.Fields[f.Key])
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+
+	zapInstance "github.com/goxkit/logging/zap"
+)
+
+type (
+	// TestOption configures the logger created by NewTestLogger.
+	TestOption func(*testOptions)
+
+	// testOptions holds the test logger settings.
+	testOptions struct {
+		level       zapcore.Level
+		failOnError bool
+	}
+
+	// testFatalHook fails the test instead of exiting the process on Fatal.
+	testFatalHook struct {
+		t testing.TB
+	}
+)
+
+// WithTestLevel sets the minimum level written by the test logger. Defaults to Debug.
+func WithTestLevel(level zapcore.Level) TestOption {
+	return func(o *testOptions) {
+		o.level = level
+	}
+}
+
+// WithFailOnError marks the test as failed when an entry is logged at Error
+// level or above, catching the errors the code under test only logs.
+func WithFailOnError() TestOption {
+	return func(o *testOptions) {
+		o.failOnError = true
+	}
+}
+
+// NewTestLogger creates a Logger writing its entries through t.Log, prefixed
+// with their level, so the output is scoped to the test, shown only when it
+// fails or with -v, and interleaved with the other test logs:
+//
+//	func TestCreateOrder(t *testing.T) {
+//		service := orders.NewService(logging.NewTestLogger(t, logging.WithFailOnError()))
+//		...
+//	}
+//
+// Fatal entries stop the test with t.FailNow instead of exiting the process.
+// The logger must not be used once the test completed.
+//
+// Parameters:
+//   - t: The test, benchmark or fuzz target writing the entries
+//   - opts: Options customizing the logger
+//
+// Returns:
+//   - A Logger writing through t
+func NewTestLogger(t testing.TB, opts ...TestOption) Logger {
+	o := &testOptions{level: zapcore.DebugLevel}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	zapOpts := []zap.Option{zap.WithFatalHook(testFatalHook{t: t})}
+	if o.failOnError {
+		zapOpts = append(zapOpts, zap.Hooks(func(ent zapcore.Entry) error {
+			if ent.Level >= zapcore.ErrorLevel {
+				t.Errorf("unexpected %s entry logged: %s", ent.Level, ent.Message)
+			}
+			return nil
+		}))
+	}
+
+	level := zap.NewAtomicLevelAt(o.level)
+	z := zaptest.NewLogger(t, zaptest.Level(level), zaptest.WrapOptions(zapOpts...))
+
+	return &logger{Logger: z, levels: zapInstance.NewLevels(level, nil)}
+}
+
+// OnWrite fails the test once the Fatal entry is written.
+func (h testFatalHook) OnWrite(_ *zapcore.CheckedEntry, _ []zapcore.Field) {
+	h.t.FailNow()
+}
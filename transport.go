@@ -0,0 +1,99 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// attemptsKey is the context key of the round trip counter of HTTPAttempts.
+type attemptsKey struct{}
+
+// httpTransport logs the round trips of its base transport.
+type httpTransport struct {
+	base   http.RoundTripper
+	logger Logger
+}
+
+// NewHTTPTransport returns an http.RoundTripper logging every outbound request
+// sent through base with its method, URL (without credentials), status,
+// latency, attempt number and trace correlation fields, so the dependence on
+// upstream services is visible in the logs. Entries are written at Info level
+// for successful responses, Warn for 4xx and Error for 5xx and failed round
+// trips.
+//
+//	client := &http.Client{Transport: logging.NewHTTPTransport(nil, logger)}
+//
+// Parameters:
+//   - base: The transport sending the requests; defaults to http.DefaultTransport
+//   - logger: The logger used to write the entries
+//
+// Returns:
+//   - An http.RoundTripper
+func NewHTTPTransport(base http.RoundTripper, logger Logger) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &httpTransport{base: base, logger: logger}
+}
+
+// HTTPAttempts returns a copy of ctx counting the round trips of the requests
+// it carries, so the transport of NewHTTPTransport logs the attempt number of
+// the requests retried by a retrying client or loop sharing ctx.
+//
+// Parameters:
+//   - ctx: The context of the requests
+//
+// Returns:
+//   - A context counting round trips
+func HTTPAttempts(ctx context.Context) context.Context {
+	return context.WithValue(ctx, attemptsKey{}, new(atomic.Int32))
+}
+
+// RoundTrip sends the request through the base transport and logs it.
+func (t *httpTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	latency := time.Since(start)
+
+	fields := []zap.Field{
+		zap.String("method", req.Method),
+		zap.String("url", req.URL.Redacted()),
+		zap.Duration("latency", latency),
+	}
+
+	if counter, ok := ctx.Value(attemptsKey{}).(*atomic.Int32); ok {
+		fields = append(fields, zap.Int32("attempt", counter.Add(1)))
+	}
+
+	fields = append(fields, TraceFields(ctx)...)
+
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+		t.logger.Error("http client request", fields...)
+		return resp, err
+	}
+
+	fields = append(fields, zap.Int("status", resp.StatusCode))
+
+	switch {
+	case resp.StatusCode >= http.StatusInternalServerError:
+		t.logger.Error("http client request", fields...)
+	case resp.StatusCode >= http.StatusBadRequest:
+		t.logger.Warn("http client request", fields...)
+	default:
+		t.logger.Info("http client request", fields...)
+	}
+
+	return resp, err
+}
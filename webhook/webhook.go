@@ -0,0 +1,373 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+// Package webhook provides a generic HTTP sink, POSTing batches of entries as
+// JSON arrays to an arbitrary URL, to feed in-house log collectors without
+// writing a dedicated sink. Headers are templated, failed requests are retried
+// with exponential backoff, and a circuit breaker stops calling a collector
+// that keeps failing until it had time to recover.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/batch"
+	"github.com/goxkit/logging/internal/checked"
+	zapInstance "github.com/goxkit/logging/zap"
+)
+
+const (
+	// DefaultTimeout bounds each request.
+	DefaultTimeout = 10 * time.Second
+	// DefaultMaxRetries is the number of retries of a failed request when
+	// Config.MaxRetries is zero.
+	DefaultMaxRetries = 3
+	// DefaultRetryBackoff is the delay before the first retry when
+	// Config.RetryBackoff is zero; it doubles with every retry.
+	DefaultRetryBackoff = 500 * time.Millisecond
+	// DefaultBreakerThreshold is the number of consecutive failed batches
+	// opening the circuit when Config.BreakerThreshold is zero.
+	DefaultBreakerThreshold = 5
+	// DefaultBreakerCooldown is how long the circuit stays open when
+	// Config.BreakerCooldown is zero.
+	DefaultBreakerCooldown = 30 * time.Second
+
+	// maxBackoff caps the delay between retries.
+	maxBackoff = 30 * time.Second
+)
+
+var (
+	// ErrMissingURL is returned when the sink is configured without URL.
+	ErrMissingURL = errors.New("webhook url is required")
+
+	// ErrCircuitOpen is reported when a batch is dropped because the circuit
+	// is open after repeated failures.
+	ErrCircuitOpen = errors.New("webhook circuit open, dropping log entries")
+)
+
+type (
+	// Config describes the webhook sink.
+	Config struct {
+		// URL receives the batches. Required.
+		URL string
+		// Method is the HTTP method of the requests. Defaults to POST.
+		Method string
+		// Headers are added to every request. Values are text/template
+		// templates executed with a HeaderData per request, and an env
+		// function reading environment variables, e.g.
+		// "Bearer {{ env \"LOG_TOKEN\" }}" or "{{ .Count }}".
+		Headers map[string]string
+		// BatchSize is the maximum number of entries per request. Defaults to 100.
+		BatchSize int
+		// FlushInterval is the maximum delay before buffered entries are sent.
+		// Defaults to 5s.
+		FlushInterval time.Duration
+		// Timeout bounds each request. Defaults to DefaultTimeout.
+		Timeout time.Duration
+		// MaxRetries is the number of retries of a request failing with a
+		// network error, a 429 or a 5xx response. Negative values disable
+		// retries. Defaults to DefaultMaxRetries.
+		MaxRetries int
+		// RetryBackoff is the delay before the first retry, doubled with every
+		// retry up to 30s. A Retry-After response header takes precedence.
+		// Defaults to DefaultRetryBackoff.
+		RetryBackoff time.Duration
+		// BreakerThreshold is the number of consecutive failed batches after
+		// which the circuit opens: batches are dropped without calling the URL
+		// until BreakerCooldown elapses, then a single batch probes the
+		// collector. Negative values disable the breaker. Defaults to
+		// DefaultBreakerThreshold.
+		BreakerThreshold int
+		// BreakerCooldown is how long the circuit stays open. Defaults to
+		// DefaultBreakerCooldown.
+		BreakerCooldown time.Duration
+		// Client sends the requests. Defaults to an http.Client with Timeout.
+		Client *http.Client
+		// EncoderOptions customizes the JSON encoder of the entries.
+		EncoderOptions zapInstance.EncoderOptions
+	}
+
+	// HeaderData is the data the header templates are executed with.
+	HeaderData struct {
+		// Count is the number of entries of the request.
+		Count int
+		// Time is the time of the request.
+		Time time.Time
+	}
+
+	// breaker is a consecutive-failure circuit breaker.
+	breaker struct {
+		threshold int
+		cooldown  time.Duration
+
+		mu        sync.Mutex
+		failures  int
+		openUntil time.Time
+	}
+
+	// core is a zapcore.Core batching entries to a webhook.
+	core struct {
+		zapcore.LevelEnabler
+		cfg     *Config
+		enc     zapcore.Encoder
+		headers map[string]*template.Template
+		breaker *breaker
+		batcher *batch.Batcher[[]byte]
+	}
+
+	// statusError is the error of a request answered with a failure status.
+	statusError struct {
+		status     string
+		code       int
+		body       []byte
+		retryAfter time.Duration
+	}
+)
+
+// NewCore returns a core sending entries at or above level to the webhook of
+// cfg.
+//
+// Parameters:
+//   - cfg: Webhook sink configuration
+//   - level: Minimum level sent to the webhook
+//
+// Returns:
+//   - A zapcore.Core ready to be teed into a logger; Sync sends the buffered entries
+//   - ErrMissingURL if the URL is not set, or an error if a header template is invalid
+func NewCore(cfg Config, level zapcore.LevelEnabler) (zapcore.Core, error) {
+	if cfg.URL == "" {
+		return nil, ErrMissingURL
+	}
+	if cfg.Method == "" {
+		cfg.Method = http.MethodPost
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = DefaultMaxRetries
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = DefaultRetryBackoff
+	}
+	if cfg.BreakerThreshold == 0 {
+		cfg.BreakerThreshold = DefaultBreakerThreshold
+	}
+	if cfg.BreakerCooldown <= 0 {
+		cfg.BreakerCooldown = DefaultBreakerCooldown
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: cfg.Timeout}
+	}
+
+	headers := make(map[string]*template.Template, len(cfg.Headers))
+	funcs := template.FuncMap{"env": os.Getenv}
+	for name, value := range cfg.Headers {
+		tmpl, err := template.New(name).Funcs(funcs).Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("webhook header %s: %w", name, err)
+		}
+		headers[name] = tmpl
+	}
+
+	c := &core{
+		LevelEnabler: level,
+		cfg:          &cfg,
+		enc:          zapInstance.NewEncoderWithOptions(zapInstance.JSONEncoder, cfg.EncoderOptions),
+		headers:      headers,
+		breaker:      &breaker{threshold: cfg.BreakerThreshold, cooldown: cfg.BreakerCooldown},
+	}
+	c.batcher = batch.New(batch.Config{
+		Size:     cfg.BatchSize,
+		Interval: cfg.FlushInterval,
+		OnError:  otel.Handle,
+	}, c.send)
+
+	return c, nil
+}
+
+// With adds structured context to the core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.enc = c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(clone.enc)
+	}
+
+	return &clone
+}
+
+// Check adds the core to the checked entry when the level is enabled.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write encodes the entry and buffers it for the next request.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	entry := bytes.TrimSuffix(append([]byte(nil), buf.Bytes()...), []byte("\n"))
+	buf.Free()
+
+	c.batcher.Add(entry)
+
+	return nil
+}
+
+// Sync sends the buffered entries.
+func (c *core) Sync() error {
+	return c.batcher.Flush()
+}
+
+// Close stops the background flusher and sends the buffered entries.
+func (c *core) Close() error {
+	return c.batcher.Close()
+}
+
+// send posts a batch of entries as a JSON array, retrying failed requests,
+// unless the circuit is open.
+func (c *core) send(entries [][]byte) error {
+	if !c.breaker.allow(time.Now()) {
+		return ErrCircuitOpen
+	}
+
+	body := make([]byte, 0, 2+len(entries)*256)
+	body = append(body, '[')
+	body = append(body, bytes.Join(entries, []byte(","))...)
+	body = append(body, ']')
+
+	backoff := c.cfg.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := c.post(body, len(entries))
+		if err == nil {
+			c.breaker.record(true, time.Now())
+			return nil
+		}
+
+		if attempt >= c.cfg.MaxRetries || !retryable(err) {
+			c.breaker.record(false, time.Now())
+			return err
+		}
+
+		delay := backoff
+		var se *statusError
+		if errors.As(err, &se) && se.retryAfter > 0 {
+			delay = se.retryAfter
+		}
+		time.Sleep(min(delay, maxBackoff))
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// post sends a request.
+func (c *core) post(body []byte, count int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, c.cfg.Method, c.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	data := HeaderData{Count: count, Time: time.Now()}
+	for name, tmpl := range c.headers {
+		var value strings.Builder
+		if err := tmpl.Execute(&value, data); err != nil {
+			return fmt.Errorf("webhook header %s: %w", name, err)
+		}
+		req.Header.Set(name, value.String())
+	}
+
+	resp, err := c.cfg.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send logs to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		seconds, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return &statusError{
+			status:     resp.Status,
+			code:       resp.StatusCode,
+			body:       msg,
+			retryAfter: time.Duration(seconds) * time.Second,
+		}
+	}
+
+	return nil
+}
+
+// Error implements error.
+func (e *statusError) Error() string {
+	return fmt.Sprintf("failed to send logs to webhook: %s: %s", e.status, e.body)
+}
+
+// retryable reports whether a failed request may succeed when sent again:
+// network errors, 408, 429 and 5xx responses.
+func retryable(err error) bool {
+	var se *statusError
+	if !errors.As(err, &se) {
+		return true
+	}
+
+	return se.code == http.StatusRequestTimeout || se.code == http.StatusTooManyRequests || se.code >= http.StatusInternalServerError
+}
+
+// allow reports whether a batch may be sent at now: the circuit is closed, or
+// its cooldown elapsed and the batch probes the collector.
+func (b *breaker) allow(now time.Time) bool {
+	if b.threshold < 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return !now.Before(b.openUntil)
+}
+
+// record counts the outcome of a batch, opening the circuit for the cooldown
+// once the failures reach the threshold. A failed probe reopens it at once.
+func (b *breaker) record(ok bool, now time.Time) {
+	if b.threshold < 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ok {
+		b.failures, b.openUntil = 0, time.Time{}
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = now.Add(b.cooldown)
+	}
+}
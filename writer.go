@@ -0,0 +1,136 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// writer turns the lines written to it into entries.
+	writer struct {
+		logger *zap.Logger
+		level  zapcore.Level
+	}
+
+	// PrintLogger adapts a Logger to the Print, Printf and Println methods
+	// expected by libraries taking a printf-style logger. Every call writes
+	// one entry at the level of the adapter.
+	PrintLogger struct {
+		logger *zap.Logger
+		level  zapcore.Level
+	}
+)
+
+// NewWriter returns an io.Writer logging every line written to it as an entry
+// at level, for libraries that only accept a writer. Each Write is expected to
+// hold complete lines, as written by log.Logger and most libraries; empty
+// lines are skipped. The caller is not recorded, since it would always be the
+// library's I/O plumbing.
+//
+//	cmd.Stderr = logging.NewWriter(logger, zapcore.WarnLevel)
+//
+// Parameters:
+//   - l: The logger the lines are routed to
+//   - level: Level of the entries
+//
+// Returns:
+//   - An io.Writer writing into l; it never fails
+func NewWriter(l Logger, level zapcore.Level) io.Writer {
+	return &writer{logger: adapterLogger(l).WithOptions(zap.WithCaller(false)), level: level}
+}
+
+// NewStdLog returns a *log.Logger writing its messages as entries at level, for
+// libraries configured with a standard library logger.
+//
+// Parameters:
+//   - l: The logger the messages are routed to
+//   - level: Level of the entries
+//
+// Returns:
+//   - A *log.Logger writing into l
+func NewStdLog(l Logger, level zapcore.Level) *log.Logger {
+	return log.New(NewWriter(l, level), "", 0)
+}
+
+// NewPrintLogger returns a PrintLogger writing entries at level, recording the
+// caller of its methods.
+//
+//	client.SetLogger(logging.NewPrintLogger(logger, zapcore.DebugLevel))
+//
+// Parameters:
+//   - l: The logger the messages are routed to
+//   - level: Level of the entries
+//
+// Returns:
+//   - A PrintLogger writing into l
+func NewPrintLogger(l Logger, level zapcore.Level) *PrintLogger {
+	return &PrintLogger{logger: adapterLogger(l).WithOptions(zap.AddCallerSkip(2)), level: level}
+}
+
+// Write logs each non-empty line of p.
+func (w *writer) Write(p []byte) (int, error) {
+	if !w.logger.Core().Enabled(w.level) {
+		return len(p), nil
+	}
+
+	for line := range bytes.Lines(p) {
+		line = bytes.TrimRight(line, "\r\n")
+		if len(line) == 0 {
+			continue
+		}
+
+		if ce := w.logger.Check(w.level, string(line)); ce != nil {
+			ce.Write()
+		}
+	}
+
+	return len(p), nil
+}
+
+// Print logs args formatted as fmt.Sprint does.
+func (p *PrintLogger) Print(args ...any) {
+	if p.logger.Core().Enabled(p.level) {
+		p.write(fmt.Sprint(args...))
+	}
+}
+
+// Printf logs args formatted as fmt.Sprintf does.
+func (p *PrintLogger) Printf(format string, args ...any) {
+	if p.logger.Core().Enabled(p.level) {
+		p.write(fmt.Sprintf(format, args...))
+	}
+}
+
+// Println logs args formatted as fmt.Sprintln does, without the trailing newline.
+func (p *PrintLogger) Println(args ...any) {
+	if p.logger.Core().Enabled(p.level) {
+		p.write(sprintln(args...))
+	}
+}
+
+// write logs msg, stripped of the trailing newline printf-style callers often add.
+func (p *PrintLogger) write(msg string) {
+	if ce := p.logger.Check(p.level, strings.TrimRight(msg, "\r\n")); ce != nil {
+		ce.Write()
+	}
+}
+
+// adapterLogger returns the zap.Logger of l, or a no-op logger for loggers
+// without one, such as MockLogger.
+func adapterLogger(l Logger) *zap.Logger {
+	if z := l.With(); z != nil {
+		return z
+	}
+
+	return zap.NewNop()
+}
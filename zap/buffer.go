@@ -0,0 +1,54 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// DefaultBufferSize is the size of the output buffers when Buffer.Size is zero.
+	DefaultBufferSize = 256 * 1024
+	// DefaultFlushInterval is the flush interval of the output buffers when
+	// Buffer.FlushInterval is zero.
+	DefaultFlushInterval = time.Second
+)
+
+// Buffer buffers the writes to an output, flushing them when the buffer is
+// full, every FlushInterval, on Sync and after every entry above Error level,
+// which reduces the syscalls of services logging thousands of lines per second.
+// Entries still buffered are lost if the process exits without syncing the
+// logger, e.g. through logging.Logger.Shutdown.
+type Buffer struct {
+	// Size is the size of the buffer in bytes. Defaults to DefaultBufferSize.
+	Size int
+	// FlushInterval is the maximum delay before buffered entries are written.
+	// Defaults to DefaultFlushInterval.
+	FlushInterval time.Duration
+}
+
+// NewWriteSyncer returns ws buffered as described by b. Its Stop method flushes
+// the buffer and stops the periodic flush.
+//
+// Parameters:
+//   - ws: The output to buffer
+//
+// Returns:
+//   - The buffered WriteSyncer
+func (b *Buffer) NewWriteSyncer(ws zapcore.WriteSyncer) *zapcore.BufferedWriteSyncer {
+	size := b.Size
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+
+	interval := b.FlushInterval
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+
+	return &zapcore.BufferedWriteSyncer{WS: ws, Size: size, FlushInterval: interval}
+}
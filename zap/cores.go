@@ -0,0 +1,91 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// errBuilt is returned when changing the configuration of a logger New has
+// already built.
+var errBuilt = errors.New("logger pipeline is already built")
+
+// WithCores returns a zap.Option adding cores, such as a homegrown sink, to the
+// tee of a logger built by New, NewZapLogger or NewStdoutZapLogger, so they go
+// through the same levels, redaction, limits, sampling and rate limits as the
+// built-in outputs. Applied later through WithOptions, or to other loggers, it
+// tees the cores with the core of the resulting logger only, leaving the
+// logger it derives from unchanged.
+//
+//	logger, err := logging.NewLogger(ctx, cfgs, zapInstance.WithCores(teamSink))
+//
+// Parameters:
+//   - cores: The additional cores
+//
+// Returns:
+//   - A zap.Option to pass to the logger constructor
+func WithCores(cores ...zapcore.Core) zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		if len(cores) == 0 {
+			return core
+		}
+
+		err := configure(core, func(cfg *Config) {
+			cfg.Cores = append(cfg.Cores[:len(cfg.Cores):len(cfg.Cores)], cores...)
+		})
+		if err != nil {
+			return zapcore.NewTee(append([]zapcore.Core{core}, cores...)...)
+		}
+
+		return core
+	})
+}
+
+// WithEncoder returns a zap.Option forcing the format of the local output of a
+// logger built by New, NewZapLogger or NewStdoutZapLogger, regardless of the
+// environment, e.g. to read the output of a production configuration locally.
+// Sinks keep their own encoders. The option only applies when passed to the
+// constructor: applied later through WithOptions, or to other loggers, it
+// leaves the logger unchanged, as its outputs are shared with the loggers
+// derived from it.
+//
+//	logger, err := logging.NewLogger(ctx, cfgs, zapInstance.WithEncoder(zapInstance.ConsoleEncoder))
+//
+// Parameters:
+//   - kind: The format of the local output
+//
+// Returns:
+//   - A zap.Option to pass to the logger constructor
+func WithEncoder(kind Encoder) zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		_ = configure(core, func(cfg *Config) { cfg.Encoder = kind })
+		return core
+	})
+}
+
+// configure records a change of the configuration of a logger New is building.
+// New rebuilds the pipeline once every option is applied.
+func configure(core zapcore.Core, change func(*Config)) error {
+	_, sc, ok := swapCoreOf(core)
+	if !ok {
+		return ErrNotReloadable
+	}
+
+	s := sc.state
+	if !s.building {
+		return errBuilt
+	}
+
+	if s.pending == nil {
+		cfg := *s.current.Load().cfg
+		s.pending = &cfg
+	}
+	change(s.pending)
+
+	return nil
+}
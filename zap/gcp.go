@@ -0,0 +1,170 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// Special fields of the Google Cloud Logging structured format.
+const (
+	GCPTraceKey       = "logging.googleapis.com/trace"
+	GCPSpanIDKey      = "logging.googleapis.com/spanId"
+	GCPHTTPRequestKey = "httpRequest"
+)
+
+// gcpProjectEnvKeys are the variables holding the Google Cloud project ID,
+// required to link entries to Cloud Trace.
+var gcpProjectEnvKeys = []string{"GOOGLE_CLOUD_PROJECT", "GCP_PROJECT", "GCLOUD_PROJECT"}
+
+// gcpEncoder renames the trace correlation fields into the keys Cloud Logging
+// links to Cloud Trace.
+type gcpEncoder struct {
+	zapcore.Encoder
+	project string
+}
+
+// gcpEncoderConfig returns the JSON encoder config of the GCPEncoder preset.
+func gcpEncoderConfig() zapcore.EncoderConfig {
+	encoderCfg := zap.NewProductionEncoderConfig()
+	encoderCfg.TimeKey = "time"
+	encoderCfg.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+	encoderCfg.LevelKey = "severity"
+	encoderCfg.EncodeLevel = gcpSeverityEncoder
+	encoderCfg.MessageKey = "message"
+	encoderCfg.StacktraceKey = "stack_trace"
+
+	return encoderCfg
+}
+
+// newGCPEncoder builds the JSON encoder of the GCPEncoder preset from encoderCfg.
+func newGCPEncoder(encoderCfg zapcore.EncoderConfig) zapcore.Encoder {
+	project := ""
+	for _, key := range gcpProjectEnvKeys {
+		if project = os.Getenv(key); project != "" {
+			break
+		}
+	}
+
+	return &gcpEncoder{Encoder: zapcore.NewJSONEncoder(encoderCfg), project: project}
+}
+
+// Clone copies the encoder, keeping the project.
+func (e *gcpEncoder) Clone() zapcore.Encoder {
+	return &gcpEncoder{Encoder: e.Encoder.Clone(), project: e.project}
+}
+
+// AddString renames the trace correlation fields added through With.
+func (e *gcpEncoder) AddString(key, value string) {
+	key, value = e.rename(key, value)
+	e.Encoder.AddString(key, value)
+}
+
+// EncodeEntry renames the trace correlation fields of the entry.
+func (e *gcpEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	renamed, copied := fields, false
+	for i, f := range fields {
+		if f.Type != zapcore.StringType || (f.Key != "trace_id" && f.Key != "span_id") {
+			continue
+		}
+
+		// Copy on first rename, the caller owns the fields.
+		if !copied {
+			renamed, copied = make([]zapcore.Field, len(fields)), true
+			copy(renamed, fields)
+		}
+
+		renamed[i].Key, renamed[i].String = e.rename(f.Key, f.String)
+	}
+
+	return e.Encoder.EncodeEntry(ent, renamed)
+}
+
+// rename maps trace_id and span_id to their Cloud Logging keys.
+func (e *gcpEncoder) rename(key, value string) (string, string) {
+	switch key {
+	case "trace_id":
+		if e.project != "" {
+			value = fmt.Sprintf("projects/%s/traces/%s", e.project, value)
+		}
+		return GCPTraceKey, value
+	case "span_id":
+		return GCPSpanIDKey, value
+	default:
+		return key, value
+	}
+}
+
+// gcpSeverityEncoder encodes levels as Cloud Logging severities.
+func gcpSeverityEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	switch l {
+	case zapcore.DebugLevel:
+		enc.AppendString("DEBUG")
+	case zapcore.InfoLevel:
+		enc.AppendString("INFO")
+	case zapcore.WarnLevel:
+		enc.AppendString("WARNING")
+	case zapcore.ErrorLevel:
+		enc.AppendString("ERROR")
+	case zapcore.DPanicLevel:
+		enc.AppendString("CRITICAL")
+	case zapcore.PanicLevel:
+		enc.AppendString("ALERT")
+	case zapcore.FatalLevel:
+		enc.AppendString("EMERGENCY")
+	default:
+		enc.AppendString("DEFAULT")
+	}
+}
+
+// gcpHTTPRequest is the httpRequest object of the Cloud Logging format.
+type gcpHTTPRequest struct {
+	r       *http.Request
+	status  int
+	size    int64
+	latency time.Duration
+}
+
+// GCPHTTPRequest returns the httpRequest field Cloud Logging renders as a
+// request log line (method, URL, status, latency, user agent, remote IP).
+//
+// Parameters:
+//   - r: The served request
+//   - status: The response status code
+//   - size: The response body size in bytes
+//   - latency: The time taken to serve the request
+//
+// Returns:
+//   - The httpRequest field
+func GCPHTTPRequest(r *http.Request, status int, size int64, latency time.Duration) zap.Field {
+	return zap.Object(GCPHTTPRequestKey, &gcpHTTPRequest{r: r, status: status, size: size, latency: latency})
+}
+
+// MarshalLogObject encodes the request with the Cloud Logging field names.
+func (h *gcpHTTPRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("requestMethod", h.r.Method)
+	enc.AddString("requestUrl", h.r.URL.String())
+	enc.AddInt("status", h.status)
+	enc.AddString("responseSize", fmt.Sprint(h.size))
+	enc.AddString("latency", fmt.Sprintf("%.9fs", h.latency.Seconds()))
+	enc.AddString("protocol", h.r.Proto)
+	enc.AddString("remoteIp", h.r.RemoteAddr)
+
+	if ua := h.r.UserAgent(); ua != "" {
+		enc.AddString("userAgent", ua)
+	}
+	if referer := h.r.Referer(); referer != "" {
+		enc.AddString("referer", referer)
+	}
+
+	return nil
+}
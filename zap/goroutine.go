@@ -0,0 +1,63 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+)
+
+// goroutineCore adds the ID of the goroutine writing each entry.
+type goroutineCore struct {
+	zapcore.Core
+}
+
+// newGoroutineCore wraps core, adding a goroutine field to every entry.
+func newGoroutineCore(core zapcore.Core) zapcore.Core {
+	return &goroutineCore{Core: core}
+}
+
+// With adds structured context to the wrapped core.
+func (c *goroutineCore) With(fields []zapcore.Field) zapcore.Core {
+	return &goroutineCore{Core: c.Core.With(fields)}
+}
+
+// Check adds the core itself, so the goroutine field is added on Write.
+func (c *goroutineCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write adds the goroutine field, Write being called by the logging goroutine,
+// and writes the entry to the wrapped core.
+func (c *goroutineCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	fields = append(fields[:len(fields):len(fields)], zap.Uint64("goroutine", goroutineID()))
+
+	return checked.Write(c.Core, ent, fields)
+}
+
+// goroutineID returns the ID of the calling goroutine, parsed from the header
+// of its stack trace ("goroutine 42 [running]:").
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"errors"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+)
+
+// Hook is a callback invoked with the entries written by a logger, for side
+// effects such as incrementing counters or tripping circuit breakers.
+type Hook struct {
+	// Level selects the entries passed to Func, e.g. zapcore.ErrorLevel.
+	Level zapcore.LevelEnabler
+	// Func is called with each selected entry. Its error is reported to the
+	// error output of the logger.
+	Func func(zapcore.Entry) error
+}
+
+// hookCore is the leaf of the tee calling the hooks with the entries written
+// through the pipeline.
+type hookCore struct {
+	hooks []Hook
+}
+
+// newHookCore returns the core calling hooks.
+func newHookCore(hooks []Hook) zapcore.Core {
+	return &hookCore{hooks: hooks}
+}
+
+// Enabled reports whether a hook selects the level.
+func (c *hookCore) Enabled(lvl zapcore.Level) bool {
+	for _, h := range c.hooks {
+		if h.Level.Enabled(lvl) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// With returns the core itself, as hooks only receive the entry.
+func (c *hookCore) With([]zapcore.Field) zapcore.Core {
+	return c
+}
+
+// Check adds the core when a hook selects the entry.
+func (c *hookCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write calls the hooks selecting the entry.
+func (c *hookCore) Write(ent zapcore.Entry, _ []zapcore.Field) error {
+	var err error
+	for _, h := range c.hooks {
+		if h.Level.Enabled(ent.Level) {
+			err = errors.Join(err, h.Func(ent))
+		}
+	}
+
+	return err
+}
+
+// Sync does nothing, as hooks are called synchronously.
+func (c *hookCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// levelCore filters every entry through the logger Levels before handing it to the
+// wrapped core. Wrapping the whole tee applies the same, runtime-adjustable minimum
+// levels to the local output and to the OpenTelemetry exporter.
+type levelCore struct {
+	zapcore.Core
+	levels *Levels
+}
+
+// newLevelCore wraps core so it only accepts entries enabled by levels.
+func newLevelCore(core zapcore.Core, levels *Levels) zapcore.Core {
+	return &levelCore{Core: core, levels: levels}
+}
+
+// Enabled reports whether both the levels and the wrapped core accept the level.
+func (c *levelCore) Enabled(lvl zapcore.Level) bool {
+	return c.levels.Enabled(lvl) && c.Core.Enabled(lvl)
+}
+
+// Level reports the lowest enabled level, allowing zapcore.LevelOf to inspect it.
+func (c *levelCore) Level() zapcore.Level {
+	return c.levels.Level()
+}
+
+// With adds structured context to the wrapped core, keeping the level filter.
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+// Check drops entries below the level of their logger name before consulting the
+// wrapped core.
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.EnabledFor(ent.LoggerName, ent.Level) {
+		return ce
+	}
+
+	return c.Core.Check(ent, ce)
+}
+
+// AtomicLevelOf returns the zap.AtomicLevel controlling a core built by this package.
+//
+// Parameters:
+//   - core: The core of a logger created by New, NewZapLogger or NewStdoutZapLogger
+//
+// Returns:
+//   - The atomic level controlling the core
+//   - false if the core was not built by this package
+func AtomicLevelOf(core zapcore.Core) (zap.AtomicLevel, bool) {
+	if c, ok := core.(*levelCore); ok {
+		return c.levels.Global(), true
+	}
+
+	return zap.AtomicLevel{}, false
+}
+
+// LevelsOf returns the Levels controlling a core built by this package.
+//
+// Parameters:
+//   - core: The core of a logger created by New, NewZapLogger or NewStdoutZapLogger
+//
+// Returns:
+//   - The levels controlling the core
+//   - false if the core was not built by this package
+func LevelsOf(core zapcore.Core) (*Levels, bool) {
+	if c, ok := core.(*levelCore); ok {
+		return c.levels, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelsEnvKey is the environment variable holding per-logger-name levels,
+// e.g. LOG_LEVELS="repository=debug,http=warn".
+const LevelsEnvKey = "LOG_LEVELS"
+
+// Levels is the level registry of a logger: a global, runtime-adjustable minimum
+// level plus overrides for specific logger names. An override for "repository"
+// applies to every logger whose dot-separated name contains the "repository"
+// segment (e.g. "my-service.repository" and "my-service.repository.users");
+// when several overrides match, the longest name wins.
+//
+// Levels implements zapcore.LevelEnabler, enabling the lowest level required by
+// the global level or by any override.
+type Levels struct {
+	global zap.AtomicLevel
+
+	mu    sync.RWMutex
+	names map[string]zapcore.Level
+	// floor is the lowest overridden level, or zapcore.InvalidLevel without overrides.
+	floor atomic.Int32
+	// resolved caches the level resolved for each logger name.
+	resolved sync.Map
+}
+
+// NewLevels creates a Levels registry.
+//
+// Parameters:
+//   - global: The level applied to loggers without an override
+//   - overrides: Initial levels per logger name, may be nil
+//
+// Returns:
+//   - The configured Levels
+func NewLevels(global zap.AtomicLevel, overrides map[string]zapcore.Level) *Levels {
+	l := &Levels{global: global, names: make(map[string]zapcore.Level, len(overrides))}
+	for name, level := range overrides {
+		l.names[name] = level
+	}
+	l.update()
+
+	return l
+}
+
+// Global returns the level applied to loggers without an override.
+func (l *Levels) Global() zap.AtomicLevel {
+	return l.global
+}
+
+// Set overrides the minimum level of the loggers matching name.
+func (l *Levels) Set(name string, level zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.names[name] = level
+	l.update()
+}
+
+// Unset removes the override of name, falling back to the global level.
+func (l *Levels) Unset(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.names, name)
+	l.update()
+}
+
+// Overrides returns a copy of the levels per logger name.
+func (l *Levels) Overrides() map[string]zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	overrides := make(map[string]zapcore.Level, len(l.names))
+	for name, level := range l.names {
+		overrides[name] = level
+	}
+
+	return overrides
+}
+
+// Enabled reports whether any logger may write entries at lvl.
+func (l *Levels) Enabled(lvl zapcore.Level) bool {
+	return l.global.Enabled(lvl) || lvl >= zapcore.Level(l.floor.Load())
+}
+
+// Level returns the lowest level enabled for any logger.
+func (l *Levels) Level() zapcore.Level {
+	return min(l.global.Level(), zapcore.Level(l.floor.Load()))
+}
+
+// EnabledFor reports whether the logger called name writes entries at lvl.
+func (l *Levels) EnabledFor(name string, lvl zapcore.Level) bool {
+	if zapcore.Level(l.floor.Load()) == zapcore.InvalidLevel {
+		return l.global.Enabled(lvl)
+	}
+
+	if level, ok := l.resolve(name); ok {
+		return lvl >= level
+	}
+
+	return l.global.Enabled(lvl)
+}
+
+// resolve returns the override applying to name, if any.
+func (l *Levels) resolve(name string) (zapcore.Level, bool) {
+	if cached, ok := l.resolved.Load(name); ok {
+		level := cached.(zapcore.Level)
+		return level, level != zapcore.InvalidLevel
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	level, longest := zapcore.InvalidLevel, -1
+	for key, keyLevel := range l.names {
+		if len(key) > longest && matchName(name, key) {
+			level, longest = keyLevel, len(key)
+		}
+	}
+
+	l.resolved.Store(name, level)
+
+	return level, level != zapcore.InvalidLevel
+}
+
+// update recomputes the floor and drops resolved names. Callers hold mu.
+func (l *Levels) update() {
+	floor := zapcore.InvalidLevel
+	for _, level := range l.names {
+		floor = min(floor, level)
+	}
+
+	l.floor.Store(int32(floor))
+	l.resolved.Clear()
+}
+
+// matchName reports whether key is a run of dot-separated segments of name.
+func matchName(name, key string) bool {
+	return name == key ||
+		strings.HasPrefix(name, key+".") ||
+		strings.HasSuffix(name, "."+key) ||
+		strings.Contains(name, "."+key+".")
+}
+
+// ParseLevels parses per-logger-name levels in the "name=level,name=level" format.
+//
+// Parameters:
+//   - raw: The comma-separated list, e.g. "repository=debug,http=warn"
+//
+// Returns:
+//   - The levels per logger name
+//   - An error if an item is malformed or names an unknown level
+func ParseLevels(raw string) (map[string]zapcore.Level, error) {
+	levels := map[string]zapcore.Level{}
+
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid logger level %q, expected name=level", item)
+		}
+
+		level, err := zapcore.ParseLevel(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid logger level %q: %w", item, err)
+		}
+
+		levels[name] = level
+	}
+
+	return levels, nil
+}
+
+// LevelsFromEnv parses the per-logger-name levels of the LOG_LEVELS variable.
+//
+// Returns:
+//   - The levels per logger name, empty when the variable is not set
+//   - An error if the variable is malformed
+func LevelsFromEnv() (map[string]zapcore.Level, error) {
+	return ParseLevels(os.Getenv(LevelsEnvKey))
+}
@@ -0,0 +1,361 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+)
+
+// ErrorMetricName is the name of the counter of the entries logged at Error
+// level and above.
+const ErrorMetricName = "log.errors_total"
+
+// Kinds of the metrics derived from the entries.
+const (
+	// CounterMetric counts the matching entries, or sums their Field.
+	CounterMetric MetricKind = iota
+	// HistogramMetric records the distribution of the Field of the matching entries.
+	HistogramMetric
+)
+
+type (
+	// MetricKind is the kind of instrument of a Metric.
+	MetricKind int
+
+	// Metric declares an OpenTelemetry instrument derived from the entries, e.g.
+	// the count of the "payment declined" entries or the histogram of their
+	// latency_ms field. Every entry enabled by the levels is considered,
+	// including those later dropped by sampling, deduplication or rate limits.
+	Metric struct {
+		// Name is the name of the instrument. Required.
+		Name string
+		// Description and Unit describe the instrument.
+		Description string
+		Unit        string
+		// Kind is the kind of the instrument. Defaults to CounterMetric.
+		Kind MetricKind
+		// Level, when set, restricts the metric to the entries it enables.
+		Level zapcore.LevelEnabler
+		// Message, when set, restricts the metric to the entries with this message.
+		Message string
+		// Filter, when set, restricts the metric to the records it accepts.
+		Filter func(Record) bool
+		// Field is the numeric field recorded by histograms and summed by
+		// counters, instead of counting the entries. Durations are recorded in
+		// seconds and numeric strings are parsed. Entries without the field are
+		// skipped. Required for histograms.
+		Field string
+		// Attributes are the keys of the fields recorded as attributes.
+		Attributes []string
+		// LoggerAttribute records the logger name as the logger attribute.
+		LoggerAttribute bool
+		// ConstAttributes are recorded with every measurement.
+		ConstAttributes []attribute.KeyValue
+	}
+
+	// ErrorMetrics counts the entries logged at Error level and above in the
+	// log.errors_total counter, with the logger and service attributes. Every
+	// entry enabled by the levels is counted, including those later dropped by
+	// sampling, deduplication or rate limits.
+	ErrorMetrics struct {
+		// MeterProvider provides the counter. Defaults to Config.MeterProvider.
+		MeterProvider metric.MeterProvider
+		// Service is the service attribute of the counter. Defaults to Config.Name.
+		Service string
+	}
+
+	// metricsCore adds the recorder of the derived metrics to the entries before
+	// passing them to the wrapped core.
+	metricsCore struct {
+		zapcore.Core
+		recorder *recorder
+	}
+
+	// recorder is the core added to the checked entries to record them in the
+	// derived metrics when they are written. It holds the With fields, so the
+	// filters and attributes see every field of the entries.
+	recorder struct {
+		instruments []*instrument
+		fields      []zapcore.Field
+	}
+
+	// instrument is a derived metric and its OpenTelemetry instrument.
+	instrument struct {
+		Metric
+		intCounter   metric.Int64Counter
+		floatCounter metric.Float64Counter
+		histogram    metric.Float64Histogram
+	}
+)
+
+// ErrorMetric returns the Metric counting the entries logged at Error level
+// and above, as described by ErrorMetrics.
+//
+// Parameters:
+//   - service: The service attribute of the counter
+//
+// Returns:
+//   - The log.errors_total Metric
+func ErrorMetric(service string) Metric {
+	return Metric{
+		Name:            ErrorMetricName,
+		Description:     "Number of entries logged at Error level and above.",
+		Unit:            "{entry}",
+		Level:           zapcore.ErrorLevel,
+		LoggerAttribute: true,
+		ConstAttributes: []attribute.KeyValue{attribute.String("service", service)},
+	}
+}
+
+// newMetricsCore wraps core, recording its entries in the metrics of cfg.
+func newMetricsCore(core zapcore.Core, cfg *Config) (zapcore.Core, error) {
+	provider := cfg.MeterProvider
+	if provider == nil {
+		provider = otel.GetMeterProvider()
+	}
+	meter := provider.Meter(cfg.Name)
+
+	instruments := make([]*instrument, 0, len(cfg.Metrics)+1)
+	for _, m := range cfg.Metrics {
+		inst, err := newInstrument(meter, m)
+		if err != nil {
+			return nil, err
+		}
+		instruments = append(instruments, inst)
+	}
+
+	if em := cfg.ErrorMetrics; em != nil {
+		service := em.Service
+		if service == "" {
+			service = cfg.Name
+		}
+
+		m := meter
+		if em.MeterProvider != nil {
+			m = em.MeterProvider.Meter(cfg.Name)
+		}
+
+		inst, err := newInstrument(m, ErrorMetric(service))
+		if err != nil {
+			return nil, err
+		}
+		instruments = append(instruments, inst)
+	}
+
+	return &metricsCore{Core: core, recorder: &recorder{instruments: instruments}}, nil
+}
+
+// newInstrument creates the instrument of m.
+func newInstrument(meter metric.Meter, m Metric) (*instrument, error) {
+	inst := &instrument{Metric: m}
+
+	var err error
+	switch {
+	case m.Name == "":
+		err = errors.New("log metric: name is required")
+	case m.Kind == HistogramMetric && m.Field == "":
+		err = fmt.Errorf("log metric %s: histograms require a field", m.Name)
+	case m.Kind == HistogramMetric:
+		inst.histogram, err = meter.Float64Histogram(m.Name, metric.WithDescription(m.Description), metric.WithUnit(m.Unit))
+	case m.Kind != CounterMetric:
+		err = fmt.Errorf("log metric %s: unknown kind %d", m.Name, m.Kind)
+	case m.Field != "":
+		inst.floatCounter, err = meter.Float64Counter(m.Name, metric.WithDescription(m.Description), metric.WithUnit(m.Unit))
+	default:
+		inst.intCounter, err = meter.Int64Counter(m.Name, metric.WithDescription(m.Description), metric.WithUnit(m.Unit))
+	}
+
+	return inst, err
+}
+
+// With adds structured context to the wrapped core and to the recorder.
+func (c *metricsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &metricsCore{Core: c.Core.With(fields), recorder: c.recorder.with(fields)}
+}
+
+// Check adds the recorder to the entries matching a metric and delegates to
+// the wrapped core, so the entries are recorded even when the wrapped core
+// drops them.
+func (c *metricsCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) && c.recorder.matches(ent) {
+		ce = checked.AddCore(ce, ent, c.recorder)
+	}
+
+	return c.Core.Check(ent, ce)
+}
+
+// matches reports whether an instrument may record ent, before its fields are
+// known.
+func (r *recorder) matches(ent zapcore.Entry) bool {
+	for _, inst := range r.instruments {
+		if inst.matches(ent) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// with returns a recorder also holding fields.
+func (r *recorder) with(fields []zapcore.Field) *recorder {
+	merged := make([]zapcore.Field, 0, len(r.fields)+len(fields))
+	merged = append(merged, r.fields...)
+	merged = append(merged, fields...)
+
+	return &recorder{instruments: r.instruments, fields: merged}
+}
+
+// Enabled accepts every level, the recorder being added only to the entries
+// it records.
+func (r *recorder) Enabled(zapcore.Level) bool {
+	return true
+}
+
+// With returns a recorder also holding fields.
+func (r *recorder) With(fields []zapcore.Field) zapcore.Core {
+	return r.with(fields)
+}
+
+// Check adds the recorder.
+func (r *recorder) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checked.AddCore(ce, ent, r)
+}
+
+// Write records the entry in the matching instruments.
+func (r *recorder) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	rec := Record{Entry: ent, Fields: fields}
+	if len(r.fields) > 0 {
+		rec.Fields = append(r.fields[:len(r.fields):len(r.fields)], fields...)
+	}
+
+	for _, inst := range r.instruments {
+		if inst.matches(ent) {
+			inst.record(rec)
+		}
+	}
+
+	return nil
+}
+
+// Sync does nothing.
+func (r *recorder) Sync() error {
+	return nil
+}
+
+// matches reports whether the level and message of ent match the metric.
+func (i *instrument) matches(ent zapcore.Entry) bool {
+	if i.Level != nil && !i.Level.Enabled(ent.Level) {
+		return false
+	}
+
+	return i.Message == "" || i.Message == ent.Message
+}
+
+// record records r when it passes the filter and carries the field of the
+// metric, if any.
+func (i *instrument) record(r Record) {
+	if i.Filter != nil && !i.Filter(r) {
+		return
+	}
+
+	value := 1.0
+	if i.Field != "" {
+		var ok bool
+		if value, ok = fieldValue(r.Fields, i.Field); !ok {
+			return
+		}
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(i.ConstAttributes)+len(i.Attributes)+1)
+	attrs = append(attrs, i.ConstAttributes...)
+	if i.LoggerAttribute {
+		attrs = append(attrs, attribute.String("logger", r.Entry.LoggerName))
+	}
+	for _, key := range i.Attributes {
+		if attr, ok := fieldAttribute(r.Fields, key); ok {
+			attrs = append(attrs, attr)
+		}
+	}
+
+	ctx, opt := context.Background(), metric.WithAttributes(attrs...)
+	switch {
+	case i.histogram != nil:
+		i.histogram.Record(ctx, value, opt)
+	case i.floatCounter != nil:
+		i.floatCounter.Add(ctx, value, opt)
+	default:
+		i.intCounter.Add(ctx, 1, opt)
+	}
+}
+
+// fieldValue returns the numeric value of the last field named key.
+func fieldValue(fields []zapcore.Field, key string) (float64, bool) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		f := fields[i]
+		if f.Key != key {
+			continue
+		}
+
+		switch f.Type {
+		case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type:
+			return float64(f.Integer), true
+		case zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type, zapcore.UintptrType:
+			return float64(uint64(f.Integer)), true
+		case zapcore.Float64Type:
+			return math.Float64frombits(uint64(f.Integer)), true
+		case zapcore.Float32Type:
+			return float64(math.Float32frombits(uint32(f.Integer))), true
+		case zapcore.DurationType:
+			return time.Duration(f.Integer).Seconds(), true
+		case zapcore.StringType:
+			v, err := strconv.ParseFloat(f.String, 64)
+			return v, err == nil
+		}
+
+		return 0, false
+	}
+
+	return 0, false
+}
+
+// fieldAttribute returns the last field named key as an attribute.
+func fieldAttribute(fields []zapcore.Field, key string) (attribute.KeyValue, bool) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		f := fields[i]
+		if f.Key != key {
+			continue
+		}
+
+		switch f.Type {
+		case zapcore.StringType:
+			return attribute.String(key, f.String), true
+		case zapcore.BoolType:
+			return attribute.Bool(key, f.Integer == 1), true
+		case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type,
+			zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type:
+			return attribute.Int64(key, f.Integer), true
+		case zapcore.StringerType:
+			if s, ok := f.Interface.(fmt.Stringer); ok {
+				return attribute.String(key, s.String()), true
+			}
+		}
+
+		return attribute.KeyValue{}, false
+	}
+
+	return attribute.KeyValue{}, false
+}
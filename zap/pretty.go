@@ -0,0 +1,248 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// Colors of the JSON blocks of the pretty encoder.
+const (
+	prettyKeyColor     = Cyan
+	prettyStringColor  = Green
+	prettyNumberColor  = Yellow
+	prettyLiteralColor = Magenta
+)
+
+// prettyIndent indents the JSON blocks and each of their nesting levels.
+const prettyIndent = "  "
+
+// prettyEncoder renders the entry line with a console encoder and the fields,
+// collected in its embedded map encoder, as an indented JSON block underneath.
+type prettyEncoder struct {
+	*zapcore.MapObjectEncoder
+	line  zapcore.Encoder
+	color bool
+	// namespaces is the path of the open namespaces, reopened by Clone.
+	namespaces []string
+}
+
+// newPrettyEncoder returns a pretty encoder rendering the entry lines with
+// encoderCfg, colored unless the theme or NO_COLOR disables colors.
+func newPrettyEncoder(encoderCfg zapcore.EncoderConfig, theme *Theme) zapcore.Encoder {
+	return &prettyEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		line:             zapcore.NewConsoleEncoder(encoderCfg),
+		color:            theme.colored(),
+	}
+}
+
+// OpenNamespace nests the following fields under key.
+func (e *prettyEncoder) OpenNamespace(key string) {
+	e.MapObjectEncoder.OpenNamespace(key)
+	e.namespaces = append(e.namespaces[:len(e.namespaces):len(e.namespaces)], key)
+}
+
+// Clone deep-copies the encoder and its context fields, keeping the open
+// namespaces open, so the fields added to the clone do not leak into e.
+func (e *prettyEncoder) Clone() zapcore.Encoder {
+	clone := &prettyEncoder{MapObjectEncoder: zapcore.NewMapObjectEncoder(), line: e.line, color: e.color}
+
+	// The map encoder stores reflected values as is, which copies every level
+	// into the namespace the clone has open.
+	fields := e.Fields
+	for _, ns := range e.namespaces {
+		for key, value := range fields {
+			if key != ns {
+				_ = clone.AddReflected(key, copyPretty(value))
+			}
+		}
+		clone.OpenNamespace(ns)
+		fields, _ = fields[ns].(map[string]any)
+	}
+	for key, value := range fields {
+		_ = clone.AddReflected(key, copyPretty(value))
+	}
+
+	return clone
+}
+
+// EncodeEntry renders the entry line, its context and entry fields as a JSON
+// block, then its stack trace.
+func (e *prettyEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	stack := ent.Stack
+	ent.Stack = ""
+
+	buf, err := e.line.EncodeEntry(ent, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	all := e
+	if len(fields) > 0 {
+		all = e.Clone().(*prettyEncoder)
+		for _, f := range fields {
+			f.AddTo(all)
+		}
+	}
+
+	if len(all.Fields) > 0 {
+		e.writeBlock(buf, all.Fields)
+	}
+
+	if stack != "" {
+		buf.AppendString(stack)
+		buf.AppendByte('\n')
+	}
+
+	return buf, nil
+}
+
+// writeBlock writes the fields as an indented JSON object. Values JSON cannot
+// represent are written with their Go formatting.
+func (e *prettyEncoder) writeBlock(buf *buffer.Buffer, fields map[string]any) {
+	raw, err := json.Marshal(normalizePretty(fields))
+	if err != nil {
+		fmt.Fprintf(buf, "%s%+v\n", prettyIndent, fields)
+		return
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		fmt.Fprintf(buf, "%s%s\n", prettyIndent, raw)
+		return
+	}
+
+	buf.AppendString(prettyIndent)
+	e.writeValue(buf, value, prettyIndent)
+	buf.AppendByte('\n')
+}
+
+// writeValue writes a decoded JSON value, nested lines starting with indent.
+func (e *prettyEncoder) writeValue(buf *buffer.Buffer, value any, indent string) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			buf.AppendString("{}")
+			return
+		}
+
+		buf.AppendString("{\n")
+		for i, key := range slices.Sorted(maps.Keys(v)) {
+			buf.AppendString(indent + prettyIndent)
+			buf.AppendString(e.paint(prettyKeyColor, quote(key)))
+			buf.AppendString(": ")
+			e.writeValue(buf, v[key], indent+prettyIndent)
+			if i < len(v)-1 {
+				buf.AppendByte(',')
+			}
+			buf.AppendByte('\n')
+		}
+		buf.AppendString(indent + "}")
+	case []any:
+		if len(v) == 0 {
+			buf.AppendString("[]")
+			return
+		}
+
+		buf.AppendString("[\n")
+		for i, item := range v {
+			buf.AppendString(indent + prettyIndent)
+			e.writeValue(buf, item, indent+prettyIndent)
+			if i < len(v)-1 {
+				buf.AppendByte(',')
+			}
+			buf.AppendByte('\n')
+		}
+		buf.AppendString(indent + "]")
+	case string:
+		buf.AppendString(e.paint(prettyStringColor, quote(v)))
+	case json.Number:
+		buf.AppendString(e.paint(prettyNumberColor, v.String()))
+	case bool:
+		buf.AppendString(e.paint(prettyLiteralColor, fmt.Sprint(v)))
+	case nil:
+		buf.AppendString(e.paint(prettyLiteralColor, "null"))
+	}
+}
+
+// paint colors s when colors are enabled.
+func (e *prettyEncoder) paint(color Color, s string) string {
+	if !e.color {
+		return s
+	}
+
+	return paint(color, s)
+}
+
+// quote returns s as a JSON string, without escaping HTML characters.
+func quote(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+
+	return string(bytes.TrimSuffix(b.Bytes(), []byte("\n")))
+}
+
+// copyPretty deep-copies the objects and arrays stored by
+// zapcore.MapObjectEncoder.
+func copyPretty(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, nested := range v {
+			out[key] = copyPretty(nested)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, nested := range v {
+			out[i] = copyPretty(nested)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// normalizePretty converts the values stored by zapcore.MapObjectEncoder that
+// JSON renders poorly or not at all: durations and times become their string
+// forms, complex numbers their Go formatting.
+func normalizePretty(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, nested := range v {
+			out[key] = normalizePretty(nested)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, nested := range v {
+			out[i] = normalizePretty(nested)
+		}
+		return out
+	case time.Duration:
+		return v.String()
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case complex128, complex64:
+		return fmt.Sprint(v)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+)
+
+type (
+	// Record is an entry and its fields, including those added through With, as
+	// seen by a Processor.
+	Record struct {
+		Entry  zapcore.Entry
+		Fields []zapcore.Field
+	}
+
+	// Processor transforms or filters the records before they are encoded and
+	// exported. Process returns the record to pass on, which may be modified,
+	// and false to drop it. Processors must not modify the Fields slice they
+	// receive in place; they return a new slice instead.
+	Processor interface {
+		Process(Record) (Record, bool)
+	}
+
+	// ProcessorFunc adapts a function to a Processor.
+	ProcessorFunc func(Record) (Record, bool)
+
+	// processorCore applies a chain of processors to the entries before handing
+	// them to the wrapped core. The With fields are kept by the core itself, so
+	// processors see every field of the entry.
+	processorCore struct {
+		zapcore.Core
+		processors []Processor
+		fields     []zapcore.Field
+	}
+)
+
+// Process calls f.
+func (f ProcessorFunc) Process(r Record) (Record, bool) {
+	return f(r)
+}
+
+// newProcessorCore wraps core with the processors, applied in order.
+func newProcessorCore(core zapcore.Core, processors []Processor) zapcore.Core {
+	return &processorCore{Core: core, processors: processors}
+}
+
+// With keeps the fields, so they are passed to the processors.
+func (c *processorCore) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	merged = append(merged, c.fields...)
+	merged = append(merged, fields...)
+
+	return &processorCore{Core: c.Core, processors: c.processors, fields: merged}
+}
+
+// Check adds the core itself, so the processors run on Write.
+func (c *processorCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return checked.AddCore(ce, ent, c)
+	}
+
+	return ce
+}
+
+// Write runs the processors and writes the resulting record, unless a
+// processor dropped it.
+func (c *processorCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	r := Record{Entry: ent, Fields: fields}
+	if len(c.fields) > 0 {
+		r.Fields = make([]zapcore.Field, 0, len(c.fields)+len(fields))
+		r.Fields = append(r.Fields, c.fields...)
+		r.Fields = append(r.Fields, fields...)
+	}
+
+	for _, p := range c.processors {
+		var keep bool
+		if r, keep = p.Process(r); !keep {
+			return nil
+		}
+	}
+
+	return checked.Write(c.Core, r.Entry, r.Fields)
+}
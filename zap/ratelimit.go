@@ -0,0 +1,292 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+	"github.com/goxkit/logging/telemetry"
+)
+
+const (
+	// RateLimitsEnvKey is the environment variable holding per-logger-name rate
+	// limits, e.g. LOG_RATE_LIMITS="*=1000,kafka=100:500" for 1000 entries per
+	// second by default and 100 per second with bursts of 500 for "kafka".
+	RateLimitsEnvKey = "LOG_RATE_LIMITS"
+	// DefaultRateLimitName is the name of the default limit in LOG_RATE_LIMITS.
+	DefaultRateLimitName = "*"
+	// SuppressedKey is the field of the summary entries holding the number of
+	// suppressed entries.
+	SuppressedKey = "suppressed"
+)
+
+// RateLimit is a token bucket: Rate entries per second, with bursts of up to
+// Burst entries. A zero Rate disables the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimits caps the number of entries written by each logger name. Every
+// logger name gets its own token bucket, sized by the longest matching name of
+// Names (matched as the overrides of Levels) or by Default. Entries beyond the
+// limit are dropped and, every SummaryInterval, a warning reports how many
+// entries each logger had suppressed.
+//
+// Entries at Error level and above are never rate limited.
+type RateLimits struct {
+	// Default applies to logger names without a matching entry in Names.
+	Default RateLimit
+	// Names holds the limits of specific logger names.
+	Names map[string]RateLimit
+	// SummaryInterval is the period of the suppression summaries. Defaults to 10s.
+	SummaryInterval time.Duration
+}
+
+// bucket is the token bucket of a logger name.
+type bucket struct {
+	limit      RateLimit
+	tokens     float64
+	last       time.Time
+	suppressed int
+}
+
+// rateLimiter holds the buckets shared by a rateLimitCore and its With children.
+type rateLimiter struct {
+	cfg  RateLimits
+	core zapcore.Core
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// rateLimitCore drops the entries exceeding the limit of their logger name.
+type rateLimitCore struct {
+	zapcore.Core
+	limiter *rateLimiter
+}
+
+// newRateLimitCore wraps core with the limits of cfg. The returned stop func
+// ends the periodic summaries and writes the pending ones.
+func newRateLimitCore(core zapcore.Core, cfg *RateLimits) (zapcore.Core, func() error) {
+	limits := *cfg
+	if limits.SummaryInterval <= 0 {
+		limits.SummaryInterval = 10 * time.Second
+	}
+
+	r := &rateLimiter{
+		cfg:     limits,
+		core:    core,
+		buckets: map[string]*bucket{},
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go r.run()
+
+	return &rateLimitCore{Core: core, limiter: r}, r.close
+}
+
+// With adds structured context to the wrapped core.
+func (c *rateLimitCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitCore{Core: c.Core.With(fields), limiter: c.limiter}
+}
+
+// Check drops the entry when its logger name ran out of tokens.
+func (c *rateLimitCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	if ent.Level < zapcore.ErrorLevel && !c.limiter.allow(ent.LoggerName) {
+		return ce
+	}
+
+	return c.Core.Check(ent, ce)
+}
+
+// Sync writes the pending summaries and syncs the wrapped core.
+func (c *rateLimitCore) Sync() error {
+	return errors.Join(c.limiter.summarize(), c.Core.Sync())
+}
+
+// allow takes a token from the bucket of name, counting the entry as
+// suppressed when none is left.
+func (r *rateLimiter) allow(name string) bool {
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.buckets[name]
+	if !ok {
+		limit := r.limitFor(name)
+		b = &bucket{limit: limit, tokens: float64(limit.Burst), last: now}
+		r.buckets[name] = b
+	}
+
+	if b.limit.Rate <= 0 {
+		return true
+	}
+
+	b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate, float64(b.limit.Burst))
+	b.last = now
+
+	if b.tokens < 1 {
+		b.suppressed++
+		telemetry.AddRateLimited()
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+// limitFor returns the limit of the longest name of Names matching name, or
+// the default limit. Callers hold mu.
+func (r *rateLimiter) limitFor(name string) RateLimit {
+	limit, longest := r.cfg.Default, -1
+	for key, keyLimit := range r.cfg.Names {
+		if len(key) > longest && matchName(name, key) {
+			limit, longest = keyLimit, len(key)
+		}
+	}
+
+	if limit.Burst < 1 {
+		limit.Burst = max(int(limit.Rate), 1)
+	}
+
+	return limit
+}
+
+// run writes the suppression summaries every SummaryInterval until close.
+func (r *rateLimiter) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.cfg.SummaryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = r.summarize()
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+// close stops the periodic summaries and writes the pending ones.
+func (r *rateLimiter) close() error {
+	r.stopOnce.Do(func() { close(r.stop) })
+	<-r.done
+
+	return r.summarize()
+}
+
+// summarize writes a warning for every logger name that suppressed entries
+// since the previous summary.
+func (r *rateLimiter) summarize() error {
+	suppressed := map[string]int{}
+
+	r.mu.Lock()
+	for name, b := range r.buckets {
+		if b.suppressed > 0 {
+			suppressed[name] = b.suppressed
+			b.suppressed = 0
+		}
+	}
+	r.mu.Unlock()
+
+	var errs []error
+	for name, n := range suppressed {
+		ent := zapcore.Entry{
+			Level:      zapcore.WarnLevel,
+			Time:       time.Now(),
+			LoggerName: name,
+			Message:    fmt.Sprintf("%d log entries suppressed by rate limiting", n),
+		}
+
+		errs = append(errs, checked.Write(r.core, ent, []zapcore.Field{zap.Int(SuppressedKey, n)}))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ParseRateLimits parses per-logger-name rate limits in the
+// "name=rate[:burst],name=rate[:burst]" format, where the "*" name sets the
+// default limit.
+//
+// Parameters:
+//   - raw: The rate limits, e.g. "*=1000,kafka=100:500"
+//
+// Returns:
+//   - The parsed rate limits, nil when raw is empty
+//   - An error if an item is malformed
+func ParseRateLimits(raw string) (*RateLimits, error) {
+	var limits *RateLimits
+
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid rate limit %q, expected name=rate[:burst]", item)
+		}
+
+		rate, burst, hasBurst := strings.Cut(strings.TrimSpace(value), ":")
+
+		var limit RateLimit
+		var err error
+
+		if limit.Rate, err = strconv.ParseFloat(rate, 64); err != nil || limit.Rate < 0 {
+			return nil, fmt.Errorf("invalid rate limit %q: invalid rate", item)
+		}
+		if hasBurst {
+			if limit.Burst, err = strconv.Atoi(burst); err != nil || limit.Burst < 0 {
+				return nil, fmt.Errorf("invalid rate limit %q: invalid burst", item)
+			}
+		}
+
+		if limits == nil {
+			limits = &RateLimits{Names: map[string]RateLimit{}}
+		}
+		if name == DefaultRateLimitName {
+			limits.Default = limit
+		} else {
+			limits.Names[name] = limit
+		}
+	}
+
+	return limits, nil
+}
+
+// RateLimitsFromEnv parses the per-logger-name rate limits of the
+// LOG_RATE_LIMITS variable.
+//
+// Returns:
+//   - The rate limits, nil when the variable is not set
+//   - An error if the variable is malformed
+func RateLimitsFromEnv() (*RateLimits, error) {
+	return ParseRateLimits(os.Getenv(RateLimitsEnvKey))
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/telemetry"
+)
+
+// Sampling caps the number of entries logged per tick for each level and message.
+// Within every tick, the first Initial entries with the same level and message are
+// logged, then only every Thereafter-th entry. Since the sampler wraps the whole
+// tee, local output and OTLP export see exactly the same entries.
+//
+// Entries at Error level and above are never sampled, unless Levels explicitly
+// sets a rate for them, so high-QPS services keep full error visibility.
+// Levels whose rate is zero, such as the levels missing from Levels when
+// Initial and Thereafter are unset, are not sampled either.
+type Sampling struct {
+	// Tick is the sampling interval. Defaults to one second.
+	Tick time.Duration
+	// Initial is the number of entries logged per tick before sampling starts.
+	Initial int
+	// Thereafter logs every Thereafter-th entry once Initial is reached.
+	Thereafter int
+	// Levels overrides Initial and Thereafter for specific levels.
+	Levels map[zapcore.Level]SamplingRate
+	// Hook, when set, is called with every sampling decision, e.g. to count
+	// dropped entries.
+	Hook func(zapcore.Entry, zapcore.SamplingDecision)
+}
+
+// SamplingRate is the per-tick rate used for a single level.
+type SamplingRate struct {
+	Initial    int
+	Thereafter int
+}
+
+// samplingCore routes each entry to the sampler configured for its level, or to
+// the unsampled core when the level is not sampled.
+type samplingCore struct {
+	zapcore.Core
+	byLevel map[zapcore.Level]zapcore.Core
+}
+
+// newSamplingCore wraps core with one sampler per sampled level.
+func newSamplingCore(core zapcore.Core, cfg *Sampling) zapcore.Core {
+	tick := cfg.Tick
+	if tick <= 0 {
+		tick = time.Second
+	}
+
+	hook := cfg.Hook
+	opts := []zapcore.SamplerOption{zapcore.SamplerHook(func(ent zapcore.Entry, dec zapcore.SamplingDecision) {
+		if dec&zapcore.LogDropped != 0 {
+			telemetry.AddSampled()
+		}
+		if hook != nil {
+			hook(ent, dec)
+		}
+	})}
+
+	byLevel := map[zapcore.Level]zapcore.Core{}
+	for lvl := zapcore.DebugLevel; lvl <= zapcore.FatalLevel; lvl++ {
+		rate, ok := cfg.Levels[lvl]
+		if !ok {
+			if lvl >= zapcore.ErrorLevel {
+				continue
+			}
+
+			rate = SamplingRate{Initial: cfg.Initial, Thereafter: cfg.Thereafter}
+		}
+
+		// A zero rate would drop every entry of the level.
+		if rate == (SamplingRate{}) {
+			continue
+		}
+
+		byLevel[lvl] = zapcore.NewSamplerWithOptions(core, tick, rate.Initial, rate.Thereafter, opts...)
+	}
+
+	return &samplingCore{Core: core, byLevel: byLevel}
+}
+
+// With adds structured context to the unsampled core and to every sampler.
+// Samplers created by With share their counters with the original ones.
+func (c *samplingCore) With(fields []zapcore.Field) zapcore.Core {
+	byLevel := make(map[zapcore.Level]zapcore.Core, len(c.byLevel))
+	for lvl, core := range c.byLevel {
+		byLevel[lvl] = core.With(fields)
+	}
+
+	return &samplingCore{Core: c.Core.With(fields), byLevel: byLevel}
+}
+
+// Check hands the entry to the sampler of its level, if any.
+func (c *samplingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if sampler, ok := c.byLevel[ent.Level]; ok {
+		return sampler.Check(ent, ce)
+	}
+
+	return c.Core.Check(ent, ce)
+}
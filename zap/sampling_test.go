@@ -0,0 +1,84 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSamplingCore(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Sampling
+		// want is the number of entries written out of 10 per level.
+		want map[zapcore.Level]int
+	}{
+		{
+			name: "default rate",
+			cfg:  Sampling{Initial: 2, Thereafter: 4},
+			want: map[zapcore.Level]int{
+				zapcore.DebugLevel: 4,
+				zapcore.InfoLevel:  4,
+				zapcore.WarnLevel:  4,
+				zapcore.ErrorLevel: 10,
+			},
+		},
+		{
+			name: "only levels",
+			cfg:  Sampling{Levels: map[zapcore.Level]SamplingRate{zapcore.DebugLevel: {Initial: 1, Thereafter: 0}}},
+			want: map[zapcore.Level]int{
+				zapcore.DebugLevel: 1,
+				zapcore.InfoLevel:  10,
+				zapcore.WarnLevel:  10,
+				zapcore.ErrorLevel: 10,
+			},
+		},
+		{
+			name: "error rate",
+			cfg:  Sampling{Initial: 5, Thereafter: 0, Levels: map[zapcore.Level]SamplingRate{zapcore.ErrorLevel: {Initial: 3}}},
+			want: map[zapcore.Level]int{
+				zapcore.DebugLevel: 5,
+				zapcore.InfoLevel:  5,
+				zapcore.WarnLevel:  5,
+				zapcore.ErrorLevel: 3,
+			},
+		},
+		{
+			name: "zero level rate",
+			cfg:  Sampling{Initial: 1, Levels: map[zapcore.Level]SamplingRate{zapcore.InfoLevel: {}}},
+			want: map[zapcore.Level]int{
+				zapcore.DebugLevel: 1,
+				zapcore.InfoLevel:  10,
+				zapcore.WarnLevel:  1,
+				zapcore.ErrorLevel: 10,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obs, logs := observer.New(zapcore.DebugLevel)
+			core := newSamplingCore(obs, &tt.cfg)
+
+			for lvl := range tt.want {
+				for range 10 {
+					ent := zapcore.Entry{Level: lvl, Message: "sampled"}
+					if ce := core.Check(ent, nil); ce != nil {
+						ce.Write()
+					}
+				}
+			}
+
+			for lvl, want := range tt.want {
+				if got := logs.FilterLevelExact(lvl).Len(); got != want {
+					t.Errorf("level %s: %d entries written, want %d", lvl, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ErrNotReloadable is returned by Reload for cores not built by New.
+var ErrNotReloadable = errors.New("logger pipeline was not built by this package")
+
+// pipeline is a generation of the pipeline held by a swapCore.
+type pipeline struct {
+	core zapcore.Core
+	// close, when set, releases the resources of the pipeline once replaced.
+	close func() error
+	// closeOnce runs close once, as a pipeline closed by Close may be
+	// replaced by Reload afterwards.
+	closeOnce sync.Once
+	gen       uint64
+	// cfg is the description the pipeline was built from.
+	cfg *Config
+}
+
+// onClose registers fn to release a resource of the pipeline. The functions run
+// in the reverse order of their registration, so the outer cores, registered
+// last, flush into the inner ones before those stop.
+func (p *pipeline) onClose(fn func() error) {
+	next := p.close
+	if next == nil {
+		p.close = fn
+		return
+	}
+
+	p.close = func() error {
+		return errors.Join(fn(), next())
+	}
+}
+
+// retire flushes a replaced pipeline, ignoring the failure of syncing a terminal
+// or pipe, and releases its resources.
+func (p *pipeline) retire() error {
+	_ = p.core.Sync()
+
+	var err error
+	p.closeOnce.Do(func() {
+		if p.close != nil {
+			err = p.close()
+		}
+	})
+
+	return err
+}
+
+// derived caches a pipeline generation with the With fields of a swapCore applied.
+type derived struct {
+	core zapcore.Core
+	gen  uint64
+}
+
+// swapState is the current pipeline shared by a swapCore and its With children.
+type swapState struct {
+	mu      sync.Mutex
+	current atomic.Pointer[pipeline]
+	// building is set while New applies the options of the logger, which may
+	// change its configuration through WithCores and WithEncoder.
+	building bool
+	// pending is the configuration changed by these options, if any.
+	pending *Config
+}
+
+// swapCore delegates to the current pipeline, which Reload replaces while the
+// loggers derived from it keep working. The fields added through With are
+// applied to each new pipeline the first time it is used.
+type swapCore struct {
+	state  *swapState
+	fields []zapcore.Field
+	cache  atomic.Pointer[derived]
+}
+
+// newSwapCore wraps p in a replaceable pipeline.
+func newSwapCore(p *pipeline) *swapCore {
+	state := &swapState{}
+	state.current.Store(p)
+
+	return &swapCore{state: state}
+}
+
+// core returns the current pipeline with the With fields applied.
+func (c *swapCore) core() zapcore.Core {
+	p := c.state.current.Load()
+	if len(c.fields) == 0 {
+		return p.core
+	}
+
+	if d := c.cache.Load(); d != nil && d.gen == p.gen {
+		return d.core
+	}
+
+	core := p.core.With(c.fields)
+	c.cache.Store(&derived{core: core, gen: p.gen})
+
+	return core
+}
+
+// Enabled reports whether the current pipeline accepts the level.
+func (c *swapCore) Enabled(lvl zapcore.Level) bool {
+	return c.core().Enabled(lvl)
+}
+
+// With returns a swapCore adding fields to the current and future pipelines.
+func (c *swapCore) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	merged = append(merged, c.fields...)
+	merged = append(merged, fields...)
+
+	return &swapCore{state: c.state, fields: merged}
+}
+
+// Check hands the entry to the current pipeline.
+func (c *swapCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return c.core().Check(ent, ce)
+}
+
+// Write writes the entry to the current pipeline.
+func (c *swapCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.core().Write(ent, fields)
+}
+
+// Sync flushes the current pipeline.
+func (c *swapCore) Sync() error {
+	return c.core().Sync()
+}
+
+// Reload rebuilds the pipeline of a logger created by New from cfg and swaps it
+// in: the loggers derived from it, including their With fields, switch to the
+// new outputs, redaction, sampling and limits. The levels of the logger are kept;
+// change them through its Levels. The previous pipeline is flushed, and closed:
+// its background writers and flushers stop. The failure of syncing a terminal
+// or pipe is ignored.
+//
+// Parameters:
+//   - core: The core of a logger created by New
+//   - cfg: The new pipeline description; cfg.Levels is ignored
+//
+// Returns:
+//   - ErrNotReloadable if the core was not built by New, or the build error
+func Reload(core zapcore.Core, cfg *Config) error {
+	prev, err := swap(core, cfg)
+	if err != nil {
+		return err
+	}
+
+	return prev.retire()
+}
+
+// Close flushes the current pipeline of a logger created by New and releases
+// its resources: the asynchronous writer, the deduplication and rate limit
+// flushers and the output buffers stop. Entries logged afterwards are still
+// written, synchronously, and buffered ones only reach their output on Sync.
+// It is meant for shutdown.
+//
+// Parameters:
+//   - core: The core of a logger created by New, or of a logger derived from it
+//
+// Returns:
+//   - ErrNotReloadable if the core was not built by New, or the errors of the
+//     released resources
+func Close(core zapcore.Core) error {
+	_, sc, ok := swapCoreOf(core)
+	if !ok {
+		return ErrNotReloadable
+	}
+
+	return sc.state.current.Load().retire()
+}
+
+// swapCoreOf returns the level filter and replaceable pipeline of a core built
+// by New.
+func swapCoreOf(core zapcore.Core) (*levelCore, *swapCore, bool) {
+	lc, ok := core.(*levelCore)
+	if !ok {
+		return nil, nil, false
+	}
+
+	sc, ok := lc.Core.(*swapCore)
+
+	return lc, sc, ok
+}
+
+// swap builds the pipeline of cfg and swaps it in, returning the previous one.
+func swap(core zapcore.Core, cfg *Config) (*pipeline, error) {
+	lc, sc, ok := swapCoreOf(core)
+	if !ok {
+		return nil, ErrNotReloadable
+	}
+
+	next, err := build(cfg, lc.levels)
+	if err != nil {
+		return nil, err
+	}
+
+	sc.state.mu.Lock()
+	prev := sc.state.current.Load()
+	next.gen = prev.gen + 1
+	sc.state.current.Store(next)
+	sc.state.mu.Unlock()
+
+	return prev, nil
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/internal/checked"
+	"github.com/goxkit/logging/telemetry"
+)
+
+// telemetryCore counts the entries written to the outputs in the self-telemetry.
+type telemetryCore struct{}
+
+// Enabled accepts every level.
+func (telemetryCore) Enabled(zapcore.Level) bool {
+	return true
+}
+
+// With returns the core itself.
+func (c telemetryCore) With([]zapcore.Field) zapcore.Core {
+	return c
+}
+
+// Check adds the core.
+func (c telemetryCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checked.AddCore(ce, ent, c)
+}
+
+// Write counts the entry.
+func (telemetryCore) Write(ent zapcore.Entry, _ []zapcore.Field) error {
+	telemetry.AddEmitted(ent.Level)
+	return nil
+}
+
+// Sync does nothing.
+func (telemetryCore) Sync() error {
+	return nil
+}
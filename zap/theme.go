@@ -0,0 +1,142 @@
+// Copyright (c) 2025, The GoKit Authors
+// MIT License
+// All rights reserved.
+
+package zap
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// NoColorEnvKey is the variable disabling the colors of the console encoder
+// when set to any non-empty value, following the no-color.org convention.
+const NoColorEnvKey = "NO_COLOR"
+
+// Color is an ANSI SGR parameter, e.g. "31" for red or "1;33" for bold yellow.
+type Color string
+
+// Colors of the console encoder.
+const (
+	Red     Color = "31"
+	Green   Color = "32"
+	Yellow  Color = "33"
+	Blue    Color = "34"
+	Magenta Color = "35"
+	Cyan    Color = "36"
+	White   Color = "37"
+	Gray    Color = "90"
+
+	// dim renders text with decreased intensity.
+	dim Color = "2"
+)
+
+// defaultLevelColors are the level colors of zap's CapitalColorLevelEncoder.
+var defaultLevelColors = map[zapcore.Level]Color{
+	zapcore.DebugLevel:  Magenta,
+	zapcore.InfoLevel:   Blue,
+	zapcore.WarnLevel:   Yellow,
+	zapcore.ErrorLevel:  Red,
+	zapcore.DPanicLevel: Red,
+	zapcore.PanicLevel:  Red,
+	zapcore.FatalLevel:  Red,
+}
+
+// Theme customizes the colors of the console encoder, e.g. for accessibility
+// or for terminals with a light background. The zero value keeps the default
+// colors.
+type Theme struct {
+	// NoColor disables colors. Setting NO_COLOR has the same effect.
+	NoColor bool
+	// Levels are the colors of the levels. Levels missing from the map keep
+	// their default color: magenta for Debug, blue for Info, yellow for Warn
+	// and red above.
+	Levels map[zapcore.Level]Color
+	// DimTime renders the timestamps dimmed.
+	DimTime bool
+	// DimLogger renders the logger names dimmed.
+	DimLogger bool
+	// DimCaller renders the callers dimmed.
+	DimCaller bool
+}
+
+// apply customizes the console encoderCfg with the theme. A nil theme keeps
+// the default colors, unless NO_COLOR is set.
+func (t *Theme) apply(encoderCfg *zapcore.EncoderConfig) {
+	if t == nil {
+		t = &Theme{}
+	}
+
+	if !t.colored() {
+		encoderCfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		return
+	}
+
+	if len(t.Levels) > 0 {
+		encoderCfg.EncodeLevel = t.encodeLevel
+	}
+
+	if t.DimTime && encoderCfg.EncodeTime != nil {
+		encodeTime := encoderCfg.EncodeTime
+		encoderCfg.EncodeTime = func(ts time.Time, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendString(paint(dim, capture(func(ae zapcore.ArrayEncoder) { encodeTime(ts, ae) })))
+		}
+	}
+
+	if t.DimLogger {
+		encoderCfg.EncodeName = func(name string, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendString(paint(dim, name))
+		}
+	}
+
+	if t.DimCaller && encoderCfg.EncodeCaller != nil {
+		encodeCaller := encoderCfg.EncodeCaller
+		encoderCfg.EncodeCaller = func(caller zapcore.EntryCaller, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendString(paint(dim, capture(func(ae zapcore.ArrayEncoder) { encodeCaller(caller, ae) })))
+		}
+	}
+}
+
+// colored reports whether the theme, which may be nil, renders colors.
+func (t *Theme) colored() bool {
+	return (t == nil || !t.NoColor) && os.Getenv(NoColorEnvKey) == ""
+}
+
+// encodeLevel renders a level in capital letters with the color of the theme.
+func (t *Theme) encodeLevel(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	color, ok := t.Levels[l]
+	if !ok {
+		color = defaultLevelColors[l]
+	}
+
+	enc.AppendString(paint(color, l.CapitalString()))
+}
+
+// paint wraps s in the escape sequences of color.
+func paint(color Color, s string) string {
+	if color == "" {
+		return s
+	}
+
+	return "\x1b[" + string(color) + "m" + s + "\x1b[0m"
+}
+
+// capture returns the text appended by an element encoder, to wrap it in
+// escape sequences.
+func capture(encode func(zapcore.ArrayEncoder)) string {
+	enc := zapcore.NewMapObjectEncoder()
+	_ = enc.AddArray("v", zapcore.ArrayMarshalerFunc(func(ae zapcore.ArrayEncoder) error {
+		encode(ae)
+		return nil
+	}))
+
+	values, _ := enc.Fields["v"].([]any)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return fmt.Sprint(values[0])
+}
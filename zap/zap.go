@@ -9,15 +9,362 @@
 package zap
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/goxkit/configs"
 	"go.opentelemetry.io/contrib/bridges/otelzap"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/sdk/log"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/goxkit/logging/async"
+	"github.com/goxkit/logging/dedup"
+	"github.com/goxkit/logging/internal/checked"
+	"github.com/goxkit/logging/limit"
+	"github.com/goxkit/logging/redact"
+)
+
+// FormatEnvKey is the environment variable forcing the format of the local
+// output of NewZapLogger and NewStdoutZapLogger: "console", "json", "gcp" or
+// "pretty".
+const FormatEnvKey = "LOG_FORMAT"
+
+// Encoder identifies the output format used by the local (non-OTLP) core.
+type Encoder string
+
+const (
+	// ConsoleEncoder renders human-readable lines with colored levels.
+	ConsoleEncoder Encoder = "console"
+	// JSONEncoder renders one JSON object per entry, suited for machine parsing.
+	JSONEncoder Encoder = "json"
+	// GCPEncoder renders JSON in the Google Cloud Logging structured format, with
+	// severity, trace and span fields parsed by Cloud Run and GKE.
+	GCPEncoder Encoder = "gcp"
+	// PrettyEncoder renders console lines followed by their fields as an
+	// indented, highlighted JSON block, to read entries with many nested fields
+	// during development.
+	PrettyEncoder Encoder = "pretty"
 )
 
+// Sink is a local output with its own encoder and minimum level.
+type Sink struct {
+	// Encoder selects the format of the sink.
+	Encoder Encoder
+	// Output is the destination of the sink. Defaults to os.Stdout.
+	Output zapcore.WriteSyncer
+	// Level is the minimum level of the sink, on top of the levels of the
+	// logger. Defaults to the levels of the logger.
+	Level zapcore.LevelEnabler
+}
+
+// Config describes the pipeline built by New. It is independent of configs.Configs
+// so the logger can be assembled from any configuration source.
+type Config struct {
+	// Name is used as the logger name and as the OpenTelemetry instrumentation scope.
+	Name string
+	// Level is the minimum level of the logger, applied to every core. It can be
+	// changed at runtime. Defaults to Info.
+	Level zap.AtomicLevel
+	// Levels, when set, replaces Level with a registry that also holds levels per
+	// logger name. Without it, the LOG_LEVELS variable provides the overrides.
+	Levels *Levels
+	// Encoder selects the local output format.
+	Encoder Encoder
+	// Output is the destination of the local core. Defaults to os.Stdout.
+	Output zapcore.WriteSyncer
+	// EncoderOptions customizes the encoders of the local core and the Sinks.
+	EncoderOptions EncoderOptions
+	// Sinks, when set, replace the local output of Encoder and Output with
+	// several outputs, each with its own encoder and minimum level.
+	Sinks []Sink
+	// Provider, when set, adds an OpenTelemetry core that exports every entry.
+	Provider *log.LoggerProvider
+	// OTLPLevel, when set, is the minimum level of the entries exported to the
+	// Provider, on top of the levels of the logger.
+	OTLPLevel zapcore.LevelEnabler
+	// Cores are additional cores (e.g. file sinks) teed with the local and OpenTelemetry cores.
+	Cores []zapcore.Core
+	// Hooks are called with the entries written to the cores.
+	Hooks []Hook
+	// Processors transform or filter the entries, in order, before they are
+	// redacted, truncated and written to the cores.
+	Processors []Processor
+	// Sampling, when set, caps the volume of entries reaching every core.
+	Sampling *Sampling
+	// Redactor, when set, masks sensitive fields before they reach any core.
+	Redactor *redact.Redactor
+	// Limits, when set, truncates oversized messages, fields and entries after
+	// redaction and before they reach any core.
+	Limits *limit.Config
+	// RateLimits, when set, caps the entries written per logger name. Without
+	// it, the LOG_RATE_LIMITS variable provides the limits.
+	RateLimits *RateLimits
+	// Dedup, when set, collapses identical entries logged within a window into
+	// a single entry carrying a repeat_count field.
+	Dedup *dedup.Config
+	// Buffer, when set, buffers the writes to the local output and the Sinks.
+	Buffer *Buffer
+	// Async, when set, writes entries to every core from a background goroutine
+	// through a bounded buffer, so logging calls never block on the outputs.
+	Async *async.Config
+	// GoroutineID adds the ID of the goroutine logging each entry as a
+	// goroutine field, to debug concurrency issues without trace context.
+	GoroutineID bool
+	// ErrorMetrics, when set, counts the entries logged at Error level and
+	// above in an OpenTelemetry counter.
+	ErrorMetrics *ErrorMetrics
+	// Metrics are OpenTelemetry instruments derived from the entries.
+	Metrics []Metric
+	// MeterProvider provides the instruments of ErrorMetrics and Metrics.
+	// Defaults to the global meter provider.
+	MeterProvider metric.MeterProvider
+	// Telemetry counts the entries written to the outputs per level in the
+	// self-telemetry of the telemetry package. Dropped entries are always
+	// counted.
+	Telemetry bool
+}
+
+// New creates a Zap logger from the given Config. The local core writes to
+// Config.Output, or to each of Config.Sinks when set; when Config.Provider is set,
+// entries are also routed to the OpenTelemetry logger provider through the
+// otelzap bridge. Config.Cores are
+// appended to the resulting tee, and the whole tee is redacted by Config.Redactor,
+// truncated to Config.Limits, sampled according to Config.Sampling, deduplicated
+// by Config.Dedup, rate limited by Config.RateLimits and filtered by Config.Level.
+//
+// Parameters:
+//   - cfg: Pipeline description
+//   - opts: Additional zap options applied to the resulting logger
+//
+// Returns:
+//   - A configured zap.Logger instance
+//   - An error if logger initialization fails
+func New(cfg *Config, opts ...zap.Option) (*zap.Logger, error) {
+	levels := cfg.Levels
+	if levels == nil {
+		level := cfg.Level
+		if level == (zap.AtomicLevel{}) {
+			level = zap.NewAtomicLevel()
+		}
+
+		overrides, err := LevelsFromEnv()
+		if err != nil {
+			return nil, err
+		}
+
+		levels = NewLevels(level, overrides)
+	}
+
+	p, err := build(cfg, levels)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := newSwapCore(p)
+	core := newLevelCore(sc, levels)
+
+	sc.state.building = true
+	logger := zap.New(core, opts...)
+	sc.state.building = false
+
+	// The configuration changed by the options is built once all are applied.
+	if pending := sc.state.pending; pending != nil {
+		sc.state.pending = nil
+
+		prev, err := swap(core, pending)
+		if err != nil {
+			_ = p.retire()
+			return nil, err
+		}
+		_ = prev.retire()
+	}
+
+	return logger.Named(cfg.Name), nil
+}
+
+// build assembles the pipeline of cfg below the level filter: the tee of the
+// local, OpenTelemetry and additional cores wrapped by the limits, redaction,
+// processors, asynchronous writes, sampling, deduplication and rate limits.
+func build(cfg *Config, levels *Levels) (*pipeline, error) {
+	p := &pipeline{cfg: cfg}
+
+	sinks := cfg.Sinks
+	if len(sinks) == 0 {
+		sinks = []Sink{{Encoder: cfg.Encoder, Output: cfg.Output}}
+	}
+
+	cores := make([]zapcore.Core, 0, len(sinks)+len(cfg.Cores)+1)
+	for _, sink := range sinks {
+		if cfg.Buffer != nil {
+			buffered := cfg.Buffer.NewWriteSyncer(sink.output())
+			p.onClose(buffered.Stop)
+			sink.Output = buffered
+		}
+		cores = append(cores, sink.core(levels, cfg.EncoderOptions))
+	}
+
+	if cfg.Provider != nil {
+		var otelCore zapcore.Core = otelzap.NewCore(
+			cfg.Name,
+			otelzap.WithLoggerProvider(cfg.Provider),
+		)
+
+		if cfg.OTLPLevel != nil {
+			var err error
+			if otelCore, err = zapcore.NewIncreaseLevelCore(otelCore, cfg.OTLPLevel); err != nil {
+				return nil, err
+			}
+		}
+
+		cores = append(cores, otelCore)
+	}
+
+	cores = append(cores, cfg.Cores...)
+
+	if len(cfg.Hooks) > 0 {
+		cores = append(cores, newHookCore(cfg.Hooks))
+	}
+
+	if cfg.Telemetry {
+		cores = append(cores, telemetryCore{})
+	}
+
+	core := zapcore.NewTee(cores...)
+	if cfg.Limits != nil {
+		core = limit.NewCore(core, *cfg.Limits)
+	}
+
+	if cfg.Redactor != nil {
+		core = redact.NewCore(core, cfg.Redactor)
+	}
+
+	if len(cfg.Processors) > 0 {
+		core = newProcessorCore(core, cfg.Processors)
+	}
+
+	if cfg.Async != nil {
+		// The asynchronous writer is drained into the buffers before they are
+		// flushed and stopped.
+		asyncCore := async.NewCore(core, *cfg.Async)
+		p.onClose(asyncCore.Close)
+		core = asyncCore
+	}
+
+	if cfg.Sampling != nil {
+		core = newSamplingCore(core, cfg.Sampling)
+	}
+
+	if cfg.Dedup != nil {
+		dedupCore := dedup.NewCore(core, *cfg.Dedup)
+		p.onClose(dedupCore.Close)
+		core = dedupCore
+	}
+
+	rateLimits := cfg.RateLimits
+	if rateLimits == nil {
+		var err error
+		if rateLimits, err = RateLimitsFromEnv(); err != nil {
+			return nil, err
+		}
+	}
+
+	if rateLimits != nil {
+		var stop func() error
+		core, stop = newRateLimitCore(core, rateLimits)
+		p.onClose(stop)
+	}
+
+	if cfg.GoroutineID {
+		core = newGoroutineCore(core)
+	}
+
+	if cfg.ErrorMetrics != nil || len(cfg.Metrics) > 0 {
+		var err error
+		if core, err = newMetricsCore(core, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	p.core = core
+
+	return p, nil
+}
+
+// core creates the core writing the entries enabled by the sink, or by levels
+// when the sink has no level of its own, encoded with opts.
+func (s Sink) core(levels *Levels, opts EncoderOptions) zapcore.Core {
+	var level zapcore.LevelEnabler = levels
+	if s.Level != nil {
+		level = s.Level
+	}
+
+	return checked.NewCore(NewEncoderWithOptions(s.Encoder, opts), s.output(), level)
+}
+
+// output returns the output of the sink, defaulting to os.Stdout.
+func (s Sink) output() zapcore.WriteSyncer {
+	if s.Output == nil {
+		return zapcore.AddSync(os.Stdout)
+	}
+
+	return s.Output
+}
+
+// EncoderForEnvironment returns the default Encoder for the given environment:
+// JSON for Production/Staging and console for everything else.
+func EncoderForEnvironment(env configs.Environment) Encoder {
+	if env == configs.ProductionEnv || env == configs.StagingEnv {
+		return JSONEncoder
+	}
+
+	return ConsoleEncoder
+}
+
+// ParseEncoder validates the name of an Encoder.
+//
+// Parameters:
+//   - raw: The encoder name, e.g. "json"
+//
+// Returns:
+//   - The Encoder
+//   - An error if the name is unknown
+func ParseEncoder(raw string) (Encoder, error) {
+	encoder := Encoder(strings.ToLower(strings.TrimSpace(raw)))
+	switch encoder {
+	case ConsoleEncoder, JSONEncoder, GCPEncoder, PrettyEncoder:
+		return encoder, nil
+	}
+
+	return "", fmt.Errorf("unknown encoder %q", raw)
+}
+
+// EncoderFromEnv returns the Encoder forced by the LOG_FORMAT variable, or the
+// default Encoder of env when it is not set, so a production configuration
+// can be read locally with LOG_FORMAT=console, and vice versa.
+//
+// Parameters:
+//   - env: The environment selecting the default Encoder
+//
+// Returns:
+//   - The Encoder of the local output
+//   - An error if the variable holds an unknown encoder
+func EncoderFromEnv(env configs.Environment) (Encoder, error) {
+	raw := os.Getenv(FormatEnvKey)
+	if raw == "" {
+		return EncoderForEnvironment(env), nil
+	}
+
+	encoder, err := ParseEncoder(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", FormatEnvKey, err)
+	}
+
+	return encoder, nil
+}
+
 // NewZapLogger creates a Zap logger configured for both local output and OpenTelemetry
 // export. It sets up a combined core that routes log entries to both standard output
 // and the OpenTelemetry logger provider, allowing logs to be displayed locally while
@@ -27,42 +374,32 @@ import (
 // - Development/QA/Local: Console output with colored level encoding
 // - Production/Staging: JSON output for better machine parsing
 //
+// The LOG_FORMAT variable, or the WithEncoder option, forces another format.
+//
 // Parameters:
 //   - cfgs: Application configurations including environment and log level settings
 //   - provider: OpenTelemetry logger provider for exporting logs
+//   - opts: Additional zap options (hooks, fields, core wrappers, ...) applied
+//     after the caller and stack trace options
 //
 // Returns:
 //   - A configured zap.Logger instance with both local and OTLP output
 //   - An error if logger initialization fails
-func NewZapLogger(cfgs *configs.Configs, provider *log.LoggerProvider) (*zap.Logger, error) {
-	encoderCfg := zap.NewProductionEncoderConfig()
-	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
-	fmtEncoder := zapcore.NewJSONEncoder(encoderCfg)
-
-	if cfgs.AppConfigs.Environment == configs.DevelopmentEnv ||
-		cfgs.AppConfigs.Environment == configs.QaEnv ||
-		cfgs.AppConfigs.Environment == configs.LocalEnv ||
-		cfgs.AppConfigs.Environment == configs.UnknownEnv {
-		encoderCfg.EncodeLevel = zapcore.CapitalColorLevelEncoder
-		fmtEncoder = zapcore.NewConsoleEncoder(encoderCfg)
+func NewZapLogger(cfgs *configs.Configs, provider *log.LoggerProvider, opts ...zap.Option) (*zap.Logger, error) {
+	encoder, err := EncoderFromEnv(cfgs.AppConfigs.Environment)
+	if err != nil {
+		return nil, err
 	}
 
-	stdout := zapcore.AddSync(os.Stdout)
-	minLevel := mapZapLogLevel(cfgs.AppConfigs)
-	defaultCore := zapcore.NewCore(fmtEncoder, stdout, minLevel)
-
-	otelCore := otelzap.NewCore(
-		cfgs.AppConfigs.Name,
-		otelzap.WithLoggerProvider(provider),
+	return New(
+		&Config{
+			Name:     cfgs.AppConfigs.Name,
+			Level:    zap.NewAtomicLevelAt(mapZapLogLevel(cfgs.AppConfigs)),
+			Encoder:  encoder,
+			Provider: provider,
+		},
+		append([]zap.Option{zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel)}, opts...)...,
 	)
-
-	combinedCore := zapcore.NewTee(defaultCore, otelCore)
-
-	logger := zap.
-		New(combinedCore, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel)).
-		Named(cfgs.AppConfigs.Name)
-
-	return logger, nil
 }
 
 // NewStdoutZapLogger creates a Zap logger that only outputs to stdout without
@@ -74,48 +411,154 @@ func NewZapLogger(cfgs *configs.Configs, provider *log.LoggerProvider) (*zap.Log
 // - Development/QA/Local: Console output with colored level encoding
 // - Production/Staging: JSON output for better machine parsing
 //
+// The LOG_FORMAT variable, or the WithEncoder option, forces another format.
+//
 // Parameters:
 //   - cfgs: Application configurations including environment and log level settings
+//   - opts: Additional zap options (hooks, fields, core wrappers, ...)
 //
 // Returns:
 //   - A configured zap.Logger instance for standard output
 //   - An error if logger initialization fails
-func NewStdoutZapLogger(cfgs *configs.Configs) (*zap.Logger, error) {
-	zapLogLevel := mapZapLogLevel(cfgs.AppConfigs)
-
-	if cfgs.AppConfigs.Environment == configs.ProductionEnv || cfgs.AppConfigs.Environment == configs.StagingEnv {
-		logConfig := zap.NewProductionEncoderConfig()
-		logConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-		encoder := zapcore.NewJSONEncoder(logConfig)
-
-		cfgs.Logger = zap.New(
-			zapcore.NewCore(
-				encoder,
-				zapcore.AddSync(os.Stdout),
-				zapLogLevel,
-			),
-		).
-			Named(cfgs.AppConfigs.Name)
-
-		return cfgs.Logger, nil
-	}
-
-	logConfig := zap.NewDevelopmentEncoderConfig()
-	logConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-	logConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-	consoleEncoder := zapcore.NewConsoleEncoder(logConfig)
-
-	cfgs.Logger = zap.New(
-		zapcore.NewCore(
-			consoleEncoder,
-			zapcore.AddSync(os.Stdout),
-			zapLogLevel,
-		),
-	).Named(cfgs.AppConfigs.Name)
+func NewStdoutZapLogger(cfgs *configs.Configs, opts ...zap.Option) (*zap.Logger, error) {
+	encoder, err := EncoderFromEnv(cfgs.AppConfigs.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	logger, err := New(&Config{
+		Name:    cfgs.AppConfigs.Name,
+		Level:   zap.NewAtomicLevelAt(mapZapLogLevel(cfgs.AppConfigs)),
+		Encoder: encoder,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	cfgs.Logger = logger
 
 	return cfgs.Logger, nil
 }
 
+// NewEncoder builds the zapcore.Encoder for the given Encoder kind. Console output
+// uses the development encoder config with colored levels, unless NO_COLOR is
+// set, JSON output uses the production encoder config. Both use ISO8601
+// timestamps. GCP output follows the Google Cloud Logging structured format,
+// and pretty output renders the fields of console lines as indented JSON.
+func NewEncoder(kind Encoder) zapcore.Encoder {
+	return NewEncoderWithOptions(kind, EncoderOptions{})
+}
+
+// NewEncoderWithOptions builds the zapcore.Encoder for the given Encoder kind,
+// as NewEncoder, customized by opts.
+func NewEncoderWithOptions(kind Encoder, opts EncoderOptions) zapcore.Encoder {
+	if kind == GCPEncoder {
+		encoderCfg := gcpEncoderConfig()
+		opts.apply(&encoderCfg)
+		return newGCPEncoder(encoderCfg)
+	}
+
+	if kind == JSONEncoder {
+		encoderCfg := zap.NewProductionEncoderConfig()
+		encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+		opts.apply(&encoderCfg)
+		return zapcore.NewJSONEncoder(encoderCfg)
+	}
+
+	if kind == PrettyEncoder {
+		return newPrettyEncoder(consoleEncoderConfig(opts), opts.Theme)
+	}
+
+	return zapcore.NewConsoleEncoder(consoleEncoderConfig(opts))
+}
+
+// consoleEncoderConfig returns the encoder config of the console output.
+func consoleEncoderConfig(opts EncoderOptions) zapcore.EncoderConfig {
+	encoderCfg := zap.NewDevelopmentEncoderConfig()
+	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	encoderCfg.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	opts.apply(&encoderCfg)
+	opts.Theme.apply(&encoderCfg)
+
+	return encoderCfg
+}
+
+// TimeFormat identifies the encoding of the entry timestamps.
+type TimeFormat string
+
+const (
+	// ISO8601TimeFormat encodes timestamps as "2006-01-02T15:04:05.000Z0700".
+	ISO8601TimeFormat TimeFormat = "iso8601"
+	// RFC3339TimeFormat encodes timestamps as "2006-01-02T15:04:05Z07:00".
+	RFC3339TimeFormat TimeFormat = "rfc3339"
+	// RFC3339NanoTimeFormat encodes timestamps as "2006-01-02T15:04:05.999999999Z07:00".
+	RFC3339NanoTimeFormat TimeFormat = "rfc3339nano"
+	// EpochTimeFormat encodes timestamps as floating-point seconds since the Unix epoch.
+	EpochTimeFormat TimeFormat = "epoch"
+	// EpochMillisTimeFormat encodes timestamps as floating-point milliseconds
+	// since the Unix epoch.
+	EpochMillisTimeFormat TimeFormat = "epoch_millis"
+	// EpochNanosTimeFormat encodes timestamps as integer nanoseconds since the
+	// Unix epoch.
+	EpochNanosTimeFormat TimeFormat = "epoch_nanos"
+)
+
+// EncoderOptions customizes the encoders built by NewEncoderWithOptions. The
+// zero value keeps the defaults of each Encoder kind.
+type EncoderOptions struct {
+	// FullCaller reports the full path of the caller file instead of its
+	// package directory and name.
+	FullCaller bool
+	// TimeFormat is the encoding of the timestamps.
+	TimeFormat TimeFormat
+	// TimeKey is the name of the timestamp field, e.g. "@timestamp".
+	TimeKey string
+	// Theme customizes the colors of the ConsoleEncoder.
+	Theme *Theme
+}
+
+// ParseTimeFormat validates the name of a TimeFormat.
+//
+// Parameters:
+//   - raw: The format name, e.g. "epoch_millis"
+//
+// Returns:
+//   - The TimeFormat
+//   - An error if the name is unknown
+func ParseTimeFormat(raw string) (TimeFormat, error) {
+	format := TimeFormat(strings.ToLower(raw))
+	if _, ok := timeEncoders[format]; !ok {
+		return "", fmt.Errorf("unknown time format %q", raw)
+	}
+
+	return format, nil
+}
+
+// timeEncoders are the zapcore.TimeEncoder of each TimeFormat.
+var timeEncoders = map[TimeFormat]zapcore.TimeEncoder{
+	ISO8601TimeFormat:     zapcore.ISO8601TimeEncoder,
+	RFC3339TimeFormat:     zapcore.RFC3339TimeEncoder,
+	RFC3339NanoTimeFormat: zapcore.RFC3339NanoTimeEncoder,
+	EpochTimeFormat:       zapcore.EpochTimeEncoder,
+	EpochMillisTimeFormat: zapcore.EpochMillisTimeEncoder,
+	EpochNanosTimeFormat:  zapcore.EpochNanosTimeEncoder,
+}
+
+// apply customizes encoderCfg with the options.
+func (o EncoderOptions) apply(encoderCfg *zapcore.EncoderConfig) {
+	if o.FullCaller {
+		encoderCfg.EncodeCaller = zapcore.FullCallerEncoder
+	}
+
+	if encodeTime, ok := timeEncoders[o.TimeFormat]; ok {
+		encoderCfg.EncodeTime = encodeTime
+	}
+
+	if o.TimeKey != "" {
+		encoderCfg.TimeKey = o.TimeKey
+	}
+}
+
 // mapZapLogLevel converts the application config log level to the corresponding
 // Zap log level. It provides appropriate mapping between the configs package
 // log level constants and Zap's level constants.